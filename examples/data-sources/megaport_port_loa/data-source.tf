@@ -0,0 +1,13 @@
+# Download the LOA of a port for the data centre, and report whether its
+# cross connect is up. For a LAG, read each of its lag_port_uids.
+data "megaport_port_loa" "port" {
+  product_uid = megaport_port.port.product_uid
+  output_path = "${path.module}/loa-${megaport_port.port.product_name}.pdf"
+}
+
+output "cross_connect" {
+  value = {
+    demarcation = data.megaport_port_loa.port.demarcation
+    up          = data.megaport_port_loa.port.cross_connect_up
+  }
+}
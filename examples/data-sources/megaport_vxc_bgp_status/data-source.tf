@@ -0,0 +1,21 @@
+# Check that the BGP sessions of a VXC to an MCR came up after apply.
+# megaport_vxc.aws_vxc is a VXC with bgp_connections in its a_end_partner_config.
+check "aws_vxc_bgp" {
+  data "megaport_vxc_bgp_status" "aws_vxc" {
+    product_uid = megaport_vxc.aws_vxc.product_uid
+  }
+
+  assert {
+    condition     = data.megaport_vxc_bgp_status.aws_vxc.all_sessions_up
+    error_message = "Not all BGP sessions of the AWS VXC are up."
+  }
+
+  assert {
+    condition = alltrue([
+      for session in data.megaport_vxc_bgp_status.aws_vxc.sessions :
+      coalesce(session.prefixes_received, 0) > 0
+    ])
+    error_message = "A BGP session of the AWS VXC is not receiving any prefixes."
+  }
+}
+
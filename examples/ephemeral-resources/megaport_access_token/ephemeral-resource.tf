@@ -0,0 +1,12 @@
+ephemeral "megaport_access_token" "api" {}
+
+# The token is available to provisioners and other ephemeral contexts, but is
+# never written to plan or state.
+resource "terraform_data" "list_locations" {
+  provisioner "local-exec" {
+    command = "curl -sf -H \"Authorization: Bearer $MEGAPORT_TOKEN\" https://api.megaport.com/v3/locations > locations.json"
+    environment = {
+      MEGAPORT_TOKEN = ephemeral.megaport_access_token.api.access_token
+    }
+  }
+}
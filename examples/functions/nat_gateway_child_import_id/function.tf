@@ -0,0 +1,4 @@
+import {
+  to = megaport_nat_gateway_packet_filter.example
+  id = provider::megaport::nat_gateway_child_import_id(var.nat_gateway_uid, 42)
+}
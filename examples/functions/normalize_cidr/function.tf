@@ -0,0 +1,4 @@
+# Returns "162.43.146.92/31"
+output "normalized_prefix" {
+  value = provider::megaport::normalize_cidr("162.43.146.93/31")
+}
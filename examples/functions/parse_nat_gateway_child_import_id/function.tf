@@ -0,0 +1,13 @@
+locals {
+  parsed = provider::megaport::parse_nat_gateway_child_import_id("11111111-2222-3333-4444-555555555555:42")
+}
+
+# Returns "11111111-2222-3333-4444-555555555555"
+output "nat_gateway_uid" {
+  value = local.parsed.nat_gateway_uid
+}
+
+# Returns 42
+output "id" {
+  value = local.parsed.id
+}
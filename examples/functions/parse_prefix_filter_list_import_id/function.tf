@@ -0,0 +1,13 @@
+locals {
+  parsed = provider::megaport::parse_prefix_filter_list_import_id("11111111-2222-3333-4444-555555555555:123")
+}
+
+# Returns "11111111-2222-3333-4444-555555555555"
+output "mcr_uid" {
+  value = local.parsed.mcr_uid
+}
+
+# Returns 123
+output "prefix_list_id" {
+  value = local.parsed.prefix_list_id
+}
@@ -0,0 +1,4 @@
+import {
+  to = megaport_mcr_prefix_filter_list.example
+  id = provider::megaport::prefix_filter_list_import_id(var.mcr_uid, 123)
+}
@@ -0,0 +1,18 @@
+locals {
+  bounds = provider::megaport::prefix_ge_le("10.0.0.0/16")
+}
+
+resource "megaport_mcr_prefix_filter_list" "example" {
+  mcr_id         = megaport_mcr.example.product_uid
+  description    = "Example prefix list"
+  address_family = "IPv4"
+
+  entries = [
+    {
+      action = "permit"
+      prefix = "10.0.0.0/16"
+      ge     = local.bounds.ge # 16
+      le     = local.bounds.le # 32
+    },
+  ]
+}
@@ -0,0 +1,8 @@
+list "megaport_mcr" "production" {
+  provider         = megaport
+  include_resource = true
+
+  config {
+    name_contains = "prod"
+  }
+}
@@ -0,0 +1,5 @@
+# Vendor configuration is not returned by the API, so generated configuration
+# must have its vendor_config block completed by hand.
+list "megaport_mve" "all" {
+  provider = megaport
+}
@@ -0,0 +1,10 @@
+# Run with `terraform query -generate-config-out=generated.tf` to write
+# import blocks and configuration for the matching ports.
+list "megaport_port" "sydney" {
+  provider         = megaport
+  include_resource = true
+
+  config {
+    location_id = 3
+  }
+}
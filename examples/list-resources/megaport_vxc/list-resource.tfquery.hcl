@@ -0,0 +1,8 @@
+list "megaport_vxc" "from_port" {
+  provider         = megaport
+  include_resource = true
+
+  config {
+    a_end_product_uid = "11111111-1111-1111-1111-111111111111"
+  }
+}
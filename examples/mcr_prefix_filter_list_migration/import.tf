@@ -0,0 +1,50 @@
+# Adopt each inline list with its import ID, MCR_UID:PREFIX_LIST_ID.
+import {
+  to = megaport_mcr_prefix_filter_list.private_networks
+  id = "11111111-1111-1111-1111-111111111111:101"
+}
+
+import {
+  to = megaport_mcr_prefix_filter_list.default_route
+  id = "11111111-1111-1111-1111-111111111111:102"
+}
+
+resource "megaport_mcr" "example" {
+  product_name         = "Megaport Example MCR"
+  port_speed           = 1000
+  location_id          = 5
+  contract_term_months = 12
+
+  # The prefix_filter_lists attribute is removed from configuration.
+  lifecycle {
+    ignore_changes = [prefix_filter_lists]
+  }
+}
+
+resource "megaport_mcr_prefix_filter_list" "private_networks" {
+  mcr_id         = megaport_mcr.example.product_uid
+  description    = "Allow private networks"
+  address_family = "IPv4"
+
+  entries = [
+    {
+      action = "permit"
+      prefix = "10.0.0.0/8"
+      ge     = 16
+      le     = 24
+    }
+  ]
+}
+
+resource "megaport_mcr_prefix_filter_list" "default_route" {
+  mcr_id         = megaport_mcr.example.product_uid
+  description    = "Deny default route"
+  address_family = "IPv4"
+
+  entries = [
+    {
+      action = "deny"
+      prefix = "0.0.0.0/0"
+    }
+  ]
+}
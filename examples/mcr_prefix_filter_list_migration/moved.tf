@@ -0,0 +1,50 @@
+# The MCR previously held a single inline prefix filter list:
+#
+# resource "megaport_mcr" "example" {
+#   ...
+#   prefix_filter_lists = [
+#     {
+#       description    = "Allow private networks"
+#       address_family = "IPv4"
+#       entries        = [...]
+#     }
+#   ]
+# }
+
+# Hand the inline list over to a standalone resource.
+moved {
+  from = megaport_mcr.example
+  to   = megaport_mcr_prefix_filter_list.private_networks
+}
+
+# The move uses up the MCR's state entry, so adopt the MCR again.
+import {
+  to = megaport_mcr.router
+  id = "11111111-1111-1111-1111-111111111111" # Replace with your MCR UID
+}
+
+resource "megaport_mcr" "router" {
+  product_name         = "Megaport Example MCR"
+  port_speed           = 1000
+  location_id          = 5
+  contract_term_months = 12
+
+  lifecycle {
+    ignore_changes = [prefix_filter_lists]
+  }
+}
+
+resource "megaport_mcr_prefix_filter_list" "private_networks" {
+  mcr_id         = megaport_mcr.router.product_uid
+  description    = "Allow private networks"
+  address_family = "IPv4"
+
+  entries = [
+    {
+      action = "permit"
+      prefix = "10.0.0.0/8"
+      ge     = 16
+      le     = 24
+    }
+  ]
+}
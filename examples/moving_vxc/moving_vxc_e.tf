@@ -0,0 +1,66 @@
+provider "megaport" {
+  environment           = "staging"
+  access_key            = "access_key"
+  secret_key            = "secret_Key"
+  accept_purchase_terms = true
+}
+
+data "megaport_location" "loc" {
+  id = 5 # NextDC Brisbane B1
+}
+
+resource "megaport_mcr" "mcr" {
+  product_name         = "Example MCR"
+  location_id          = data.megaport_location.loc.id
+  contract_term_months = 12
+  port_speed           = 1000
+  asn                  = 64555
+}
+
+resource "megaport_port" "port_2" {
+  product_name           = "Port 2"
+  port_speed             = 1000
+  location_id            = data.megaport_location.loc.id
+  contract_term_months   = 12
+  marketplace_visibility = false
+}
+
+resource "megaport_port" "port_3" {
+  product_name           = "Port 3"
+  port_speed             = 1000
+  location_id            = data.megaport_location.loc.id
+  contract_term_months   = 12
+  marketplace_visibility = false
+}
+
+resource "megaport_vxc" "vxc" {
+  product_name         = "Example VXC"
+  rate_limit           = 500
+  contract_term_months = 12
+  move_strategy        = "make_before_break"
+
+  a_end = {
+    requested_product_uid = megaport_mcr.mcr.product_uid
+    ordered_vlan          = 100
+  }
+
+  a_end_partner_config = {
+    partner = "vrouter"
+    vrouter_config = {
+      interfaces = [{
+        ip_addresses = ["10.0.0.1/30"]
+        bgp_connections = [{
+          peer_asn         = 64512
+          local_ip_address = "10.0.0.1"
+          peer_ip_address  = "10.0.0.2"
+        }]
+      }]
+    }
+  }
+
+  b_end = {
+    # Previously megaport_port.port_2.product_uid
+    requested_product_uid = megaport_port.port_3.product_uid
+    ordered_vlan          = 200
+  }
+}
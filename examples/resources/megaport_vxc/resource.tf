@@ -147,8 +147,15 @@ resource "megaport_vxc" "aws_vxc" {
     ordered_vlan          = 191
   }
 
+  # Instead of a megaport_partner data source, the partner port can be selected
+  # on the B-End itself. The port chosen when the VXC is created is kept.
   b_end = {
-    requested_product_uid = data.megaport_partner.aws_port.product_uid
+    partner_port_selector = {
+      connect_type = "AWS"
+      company_name = "AWS"
+      product_name = "Asia Pacific (Sydney) (ap-southeast-2)"
+      location_id  = data.megaport_location.syd_gs.id
+    }
   }
 
   b_end_partner_config = {
@@ -236,6 +243,30 @@ resource "megaport_vxc" "azure_vxc" {
   }
 }
 
+# Partners without a dedicated configuration block can be connected to with
+# generic_config. The fields are passed through to the Megaport API, and the
+# partner's side of the connection is reported in csp_connections.
+resource "megaport_vxc" "alibaba_vxc" {
+  product_name         = "Megaport VXC Example - Alibaba Cloud"
+  rate_limit           = 100
+  contract_term_months = 12
+
+  a_end = {
+    requested_product_uid = megaport_mcr.mcr.product_uid
+    ordered_vlan          = 183
+  }
+
+  b_end = {}
+
+  b_end_partner_config = {
+    partner = "generic"
+    generic_config = {
+      connect_type = "ALIBABA"
+      pairing_key  = "ALIBABA_PAIRING_KEY_HERE"
+    }
+  }
+}
+
 resource "megaport_vxc" "transit_vxc" {
   product_name         = "Transit VXC Example"
   rate_limit           = 100
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+)
+
+// accessTokenEphemeralResource is the ephemeral resource implementation.
+type accessTokenEphemeralResource struct {
+	client *megaport.Client
+}
+
+// accessTokenEphemeralResourceModel maps the ephemeral resource schema data.
+type accessTokenEphemeralResourceModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+// NewAccessTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *accessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (e *accessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Access Token Ephemeral Resource for the Megaport Terraform Provider. Issues a bearer token for the Megaport API using the provider's configured credentials, for use with `local-exec` provisioners or other tooling that calls the API directly. The token is never written to plan or state. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Description: "Bearer token for the Megaport API. Send it in the `Authorization: Bearer <token>` header.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "Time the access token expires, in RFC 3339 format. Null when the expiry is not known to the provider.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Open issues the access token.
+func (e *accessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data accessTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	auth, err := e.client.Authorize(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error issuing access token",
+			"Could not obtain a Megaport API access token: "+err.Error(),
+		)
+		return
+	}
+
+	data.AccessToken = types.StringValue(auth.AccessToken)
+	if auth.Expiration.IsZero() {
+		data.ExpiresAt = types.StringNull()
+	} else {
+		data.ExpiresAt = types.StringValue(auth.Expiration.UTC().Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (e *accessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*megaportProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *megaportProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = data.client
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAuthServer stands in for the Megaport OAuth token endpoint, returning
+// the given status and body and counting the requests it receives.
+func newTestAuthServer(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth2/token" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "test-access-key" || pass != "test-secret-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newTestAuthClient(t *testing.T, server *httptest.Server) *megaport.Client {
+	t.Helper()
+	client, err := megaport.New(nil,
+		megaport.WithBaseURL(server.URL),
+		megaport.WithTokenURL(server.URL+"/oauth2/token"),
+		megaport.WithCredentials("test-access-key", "test-secret-key"),
+	)
+	require.NoError(t, err)
+	return client
+}
+
+// openAccessToken runs Open on the ephemeral resource with an empty config and
+// returns the response.
+func openAccessToken(t *testing.T, e *accessTokenEphemeralResource) *ephemeral.OpenResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+	configRaw := tftypes.NewValue(tfType, map[string]tftypes.Value{
+		"access_token": tftypes.NewValue(tftypes.String, nil),
+		"expires_at":   tftypes.NewValue(tftypes.String, nil),
+	})
+
+	req := ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: configRaw},
+	}
+	resp := &ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(tfType, nil)},
+	}
+	e.Open(ctx, req, resp)
+	return resp
+}
+
+func TestAccessTokenEphemeralResource_Open(t *testing.T) {
+	server, calls := newTestAuthServer(t, http.StatusOK, `{"access_token":"issued-token","expires_in":3600,"token_type":"Bearer"}`)
+	e := &accessTokenEphemeralResource{client: newTestAuthClient(t, server)}
+
+	before := time.Now()
+	resp := openAccessToken(t, e)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	var result accessTokenEphemeralResourceModel
+	require.False(t, resp.Result.Get(context.Background(), &result).HasError())
+
+	assert.Equal(t, "issued-token", result.AccessToken.ValueString())
+	expiresAt, err := time.Parse(time.RFC3339, result.ExpiresAt.ValueString())
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(time.Hour), expiresAt, time.Minute)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestAccessTokenEphemeralResource_OpenAuthError(t *testing.T) {
+	server, _ := newTestAuthServer(t, http.StatusOK, `{"error":"invalid_grant"}`)
+	e := &accessTokenEphemeralResource{client: newTestAuthClient(t, server)}
+
+	resp := openAccessToken(t, e)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Error issuing access token", resp.Diagnostics.Errors()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "invalid_grant")
+}
+
+func TestAccessTokenEphemeralResource_OpenReusesValidToken(t *testing.T) {
+	server, calls := newTestAuthServer(t, http.StatusOK, `{"access_token":"issued-token","expires_in":3600}`)
+	client := newTestAuthClient(t, server)
+	_, err := client.Authorize(context.Background())
+	require.NoError(t, err)
+
+	// The provider authorises during Configure, so the ephemeral resource should
+	// hand out the client's current token rather than logging in again.
+	e := &accessTokenEphemeralResource{client: client}
+	resp := openAccessToken(t, e)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	var result accessTokenEphemeralResourceModel
+	require.False(t, resp.Result.Get(context.Background(), &result).HasError())
+	assert.Equal(t, "issued-token", result.AccessToken.ValueString())
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestAccessTokenEphemeralResource_Configure(t *testing.T) {
+	e := &accessTokenEphemeralResource{}
+	client := &megaport.Client{}
+
+	resp := &ephemeral.ConfigureResponse{}
+	e.Configure(context.Background(), ephemeral.ConfigureRequest{ProviderData: &megaportProviderData{client: client}}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+	assert.Same(t, client, e.client)
+
+	resp = &ephemeral.ConfigureResponse{}
+	e.Configure(context.Background(), ephemeral.ConfigureRequest{ProviderData: "not provider data"}, resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
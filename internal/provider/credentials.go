@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultProfileName is the credentials profile used when neither the profile
+// attribute nor MEGAPORT_PROFILE is set.
+const defaultProfileName = "default"
+
+// credentialsProfile is one named section of the shared credentials file.
+type credentialsProfile struct {
+	AccessKey         string
+	SecretKey         string
+	Environment       string
+	ManagedAccountUID string
+}
+
+// defaultSharedCredentialsFile returns ~/.megaport/credentials, taking the
+// home directory from HOME when getenv has it.
+func defaultSharedCredentialsFile(getenv func(string) string) (string, error) {
+	home := getenv("HOME")
+	if home == "" {
+		var err error
+		if home, err = os.UserHomeDir(); err != nil {
+			return "", err
+		}
+	}
+	return filepath.Join(home, ".megaport", "credentials"), nil
+}
+
+// parseSharedCredentials parses a shared credentials file. The format is INI
+// style, with one section per profile:
+//
+//	[default]
+//	access_key  = ...
+//	secret_key  = ...
+//	environment = production
+//
+//	[customer-a]
+//	access_key          = ...
+//	secret_key          = ...
+//	managed_account_uid = ...
+//
+// Lines starting with # or ; are comments. Unknown keys are rejected so that a
+// typo doesn't silently fall back to another credential source.
+func parseSharedCredentials(r io.Reader) (map[string]credentialsProfile, error) {
+	profiles := map[string]credentialsProfile{}
+	var section string
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid profile header %q", lineNo, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNo)
+			}
+			if _, ok := profiles[section]; ok {
+				return nil, fmt.Errorf("line %d: profile %q is defined more than once", lineNo, section)
+			}
+			profiles[section] = credentialsProfile{}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNo)
+		}
+		if section == "" {
+			return nil, fmt.Errorf("line %d: %q is outside of a [profile] section", lineNo, strings.TrimSpace(key))
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+
+		profile := profiles[section]
+		switch key {
+		case "access_key":
+			profile.AccessKey = value
+		case "secret_key":
+			profile.SecretKey = value
+		case "environment":
+			profile.Environment = value
+		case "managed_account_uid":
+			profile.ManagedAccountUID = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q in profile %q", lineNo, key, section)
+		}
+		profiles[section] = profile
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// providerSettings are the connection settings resolved from the provider
+// configuration, environment variables and the shared credentials file.
+type providerSettings struct {
+	accessKey         string
+	secretKey         string
+	environment       string
+	managedAccountUID string
+
+	// Human-readable description of where each value came from, used in logs
+	// and diagnostics.
+	credentialsSource       string
+	environmentSource       string
+	managedAccountUIDSource string
+
+	// profileName and credentialsFile describe the profile that was looked
+	// up, for use in error messages when credentials are missing.
+	profileName     string
+	credentialsFile string
+	// profileExplicit is true when the profile was chosen with the profile
+	// attribute or MEGAPORT_PROFILE rather than defaulted.
+	profileExplicit bool
+	// fileExplicit is true when the file was chosen with the
+	// shared_credentials_file attribute or MEGAPORT_SHARED_CREDENTIALS_FILE.
+	fileExplicit bool
+	// profileLoaded is true when the profile was found in the file.
+	profileLoaded bool
+	// ignoredLoadErr is why the default profile could not be loaded from the
+	// default file. It is reported as a warning rather than failing Configure.
+	ignoredLoadErr error
+}
+
+// profileSource describes a value read from the selected profile.
+func (s providerSettings) profileSource() string {
+	return fmt.Sprintf("profile %q in %s", s.profileName, s.credentialsFile)
+}
+
+// shadowedProfileSettings lists the settings of an explicitly selected profile
+// that were overridden by the provider configuration or the environment.
+func (s providerSettings) shadowedProfileSettings(profile credentialsProfile) []string {
+	var shadowed []string
+	if (profile.AccessKey != "" || profile.SecretKey != "") && s.credentialsSource != s.profileSource() {
+		shadowed = append(shadowed, "access_key and secret_key ("+s.credentialsSource+")")
+	}
+	if profile.Environment != "" && s.environmentSource != s.profileSource() {
+		shadowed = append(shadowed, "environment ("+s.environmentSource+")")
+	}
+	if profile.ManagedAccountUID != "" && s.managedAccountUIDSource != s.profileSource() {
+		shadowed = append(shadowed, "managed_account_uid ("+s.managedAccountUIDSource+")")
+	}
+	return shadowed
+}
+
+// resolveProviderSettings works out the connection settings. Each setting is
+// taken from the first of these that sets it:
+//
+//  1. the provider configuration
+//  2. its MEGAPORT_* environment variable
+//  3. the selected profile in the shared credentials file
+//
+// The access and secret keys are only read from the profile as a pair, when
+// neither is set in the configuration or environment, so keys from different
+// accounts are never combined.
+//
+// The profile is chosen by the profile attribute, then MEGAPORT_PROFILE, then
+// "default"; the file by shared_credentials_file, then
+// MEGAPORT_SHARED_CREDENTIALS_FILE, then ~/.megaport/credentials. When
+// neither is chosen explicitly, the file is not read at all if both keys are
+// already set, and a file that cannot be read or parsed is ignored.
+func resolveProviderSettings(config megaportProviderModel, getenv func(string) string) (providerSettings, credentialsProfile, error) {
+	var s providerSettings
+
+	s.profileName, s.profileExplicit = firstSet(config.Profile, getenv("MEGAPORT_PROFILE"))
+	if s.profileName == "" {
+		s.profileName = defaultProfileName
+	}
+
+	s.credentialsFile, s.fileExplicit = firstSet(config.SharedCredentialsFile, getenv("MEGAPORT_SHARED_CREDENTIALS_FILE"))
+	explicit := s.profileExplicit || s.fileExplicit
+	if s.credentialsFile == "" {
+		path, err := defaultSharedCredentialsFile(getenv)
+		if err != nil && explicit {
+			return s, credentialsProfile{}, fmt.Errorf("could not find the shared credentials file: %w", err)
+		}
+		s.credentialsFile = path
+	}
+
+	s.accessKey = valueOrEnv(config.AccessKey, getenv("MEGAPORT_ACCESS_KEY"))
+	s.secretKey = valueOrEnv(config.SecretKey, getenv("MEGAPORT_SECRET_KEY"))
+	keysSet := s.accessKey != "" && s.secretKey != ""
+
+	var profile credentialsProfile
+	if s.credentialsFile != "" && (explicit || !keysSet) {
+		var err error
+		profile, s.profileLoaded, err = loadCredentialsProfile(s.credentialsFile, s.profileName)
+		switch {
+		case err != nil && explicit:
+			return s, profile, err
+		case err != nil:
+			if !errors.Is(err, fs.ErrNotExist) {
+				s.ignoredLoadErr = err
+			}
+		case !s.profileLoaded && s.profileExplicit:
+			return s, profile, fmt.Errorf("profile %q was not found in %s", s.profileName, s.credentialsFile)
+		}
+	}
+
+	switch {
+	case !config.AccessKey.IsNull() || !config.SecretKey.IsNull():
+		s.credentialsSource = "provider configuration"
+	case s.accessKey != "" || s.secretKey != "":
+		s.credentialsSource = "environment variables MEGAPORT_ACCESS_KEY and MEGAPORT_SECRET_KEY"
+	case s.profileLoaded:
+		s.accessKey, s.secretKey = profile.AccessKey, profile.SecretKey
+		s.credentialsSource = s.profileSource()
+	default:
+		s.credentialsSource = "none"
+	}
+
+	s.environment, s.environmentSource = s.pick(config.Environment, "MEGAPORT_ENVIRONMENT", getenv, profile.Environment)
+	s.managedAccountUID, s.managedAccountUIDSource = s.pick(config.ManagedAccountUID, "MEGAPORT_MANAGED_ACCOUNT_UID", getenv, profile.ManagedAccountUID)
+
+	return s, profile, nil
+}
+
+// pick returns a setting from the configuration, the environment or the
+// profile, in that order, along with a description of its source.
+func (s providerSettings) pick(configValue types.String, envName string, getenv func(string) string, profileValue string) (string, string) {
+	if !configValue.IsNull() {
+		return configValue.ValueString(), "provider configuration"
+	}
+	if v := getenv(envName); v != "" {
+		return v, "environment variable " + envName
+	}
+	if profileValue != "" {
+		return profileValue, s.profileSource()
+	}
+	return "", "default"
+}
+
+// loadCredentialsProfile reads the named profile from a shared credentials
+// file. found is false when the file exists but has no such profile.
+func loadCredentialsProfile(path, name string) (credentialsProfile, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return credentialsProfile{}, false, fmt.Errorf("could not read shared credentials file: %w", err)
+	}
+	defer f.Close()
+
+	profiles, err := parseSharedCredentials(f)
+	if err != nil {
+		return credentialsProfile{}, false, fmt.Errorf("could not parse shared credentials file %s: %w", path, err)
+	}
+	profile, found := profiles[name]
+	return profile, found, nil
+}
+
+// firstSet returns the configured value if set, otherwise envValue. set
+// reports whether either was non-empty.
+func firstSet(configValue types.String, envValue string) (value string, set bool) {
+	if !configValue.IsNull() && configValue.ValueString() != "" {
+		return configValue.ValueString(), true
+	}
+	if envValue != "" {
+		return envValue, true
+	}
+	return "", false
+}
+
+// valueOrEnv returns the configured value if set, otherwise envValue.
+func valueOrEnv(configValue types.String, envValue string) string {
+	if !configValue.IsNull() {
+		return configValue.ValueString()
+	}
+	return envValue
+}
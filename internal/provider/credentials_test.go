@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCredentialsFile = `
+# Default account
+[default]
+access_key  = default-access
+secret_key  = default-secret
+
+; Reseller account acting for a customer
+[customer-a]
+access_key          = "customer-access"
+secret_key          = customer-secret
+environment         = production
+managed_account_uid = 11111111-2222-3333-4444-555555555555
+`
+
+// writeCredentialsFile writes content to a credentials file in a temporary
+// directory and returns its path.
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// testEnv returns a getenv function backed by vars.
+// writeDefaultCredentialsFile writes .megaport/credentials under a new home
+// directory, and returns the directory.
+func writeDefaultCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	home := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(home, ".megaport"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".megaport", "credentials"), []byte(content), 0o600))
+	return home
+}
+
+func testEnv(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestParseSharedCredentials(t *testing.T) {
+	profiles, err := parseSharedCredentials(strings.NewReader(testCredentialsFile))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]credentialsProfile{
+		"default": {AccessKey: "default-access", SecretKey: "default-secret"},
+		"customer-a": {
+			AccessKey:         "customer-access",
+			SecretKey:         "customer-secret",
+			Environment:       "production",
+			ManagedAccountUID: "11111111-2222-3333-4444-555555555555",
+		},
+	}, profiles)
+}
+
+func TestParseSharedCredentials_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown key", content: "[default]\nacess_key = x\n", wantErr: `line 2: unknown key "acess_key"`},
+		{name: "key outside profile", content: "access_key = x\n", wantErr: "outside of a [profile] section"},
+		{name: "missing equals", content: "[default]\naccess_key\n", wantErr: "line 2: expected key = value"},
+		{name: "unterminated header", content: "[default\n", wantErr: "invalid profile header"},
+		{name: "empty header", content: "[ ]\n", wantErr: "empty profile name"},
+		{name: "duplicate profile", content: "[a]\n[a]\n", wantErr: `profile "a" is defined more than once`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSharedCredentials(strings.NewReader(tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestResolveProviderSettings(t *testing.T) {
+	file := writeCredentialsFile(t, testCredentialsFile)
+	malformedHome := writeDefaultCredentialsFile(t, "[default]\nregion = x\n")
+
+	tests := []struct {
+		name        string
+		config      megaportProviderModel
+		env         map[string]string
+		wantAccess  string
+		wantSecret  string
+		wantEnv     string
+		wantAccount string
+		wantSource  string
+	}{
+		{
+			name:       "default profile when nothing else is set",
+			env:        map[string]string{"MEGAPORT_SHARED_CREDENTIALS_FILE": file},
+			wantAccess: "default-access",
+			wantSecret: "default-secret",
+			wantSource: `profile "default"`,
+		},
+		{
+			name:        "profile selected by environment variable",
+			env:         map[string]string{"MEGAPORT_SHARED_CREDENTIALS_FILE": file, "MEGAPORT_PROFILE": "customer-a"},
+			wantAccess:  "customer-access",
+			wantSecret:  "customer-secret",
+			wantEnv:     "production",
+			wantAccount: "11111111-2222-3333-4444-555555555555",
+			wantSource:  `profile "customer-a"`,
+		},
+		{
+			name: "profile attribute overrides environment variable",
+			config: megaportProviderModel{
+				Profile:               types.StringValue("default"),
+				SharedCredentialsFile: types.StringValue(file),
+			},
+			env:        map[string]string{"MEGAPORT_PROFILE": "customer-a"},
+			wantAccess: "default-access",
+			wantSecret: "default-secret",
+			wantSource: `profile "default"`,
+		},
+		{
+			name:        "environment variable keys override the profile",
+			env:         map[string]string{"MEGAPORT_SHARED_CREDENTIALS_FILE": file, "MEGAPORT_PROFILE": "customer-a", "MEGAPORT_ACCESS_KEY": "env-access", "MEGAPORT_SECRET_KEY": "env-secret"},
+			wantAccess:  "env-access",
+			wantSecret:  "env-secret",
+			wantEnv:     "production",
+			wantAccount: "11111111-2222-3333-4444-555555555555",
+			wantSource:  "environment variables",
+		},
+		{
+			name: "configuration overrides environment variables",
+			config: megaportProviderModel{
+				AccessKey:   types.StringValue("config-access"),
+				SecretKey:   types.StringValue("config-secret"),
+				Environment: types.StringValue("staging"),
+			},
+			env:         map[string]string{"MEGAPORT_SHARED_CREDENTIALS_FILE": file, "MEGAPORT_PROFILE": "customer-a", "MEGAPORT_ACCESS_KEY": "env-access", "MEGAPORT_SECRET_KEY": "env-secret"},
+			wantAccess:  "config-access",
+			wantSecret:  "config-secret",
+			wantEnv:     "staging",
+			wantAccount: "11111111-2222-3333-4444-555555555555",
+			wantSource:  "provider configuration",
+		},
+		{
+			name: "keys are never mixed with the profile",
+			config: megaportProviderModel{
+				AccessKey: types.StringValue("config-access"),
+			},
+			env:        map[string]string{"MEGAPORT_SHARED_CREDENTIALS_FILE": file},
+			wantAccess: "config-access",
+			wantSecret: "",
+			wantSource: "provider configuration",
+		},
+		{
+			name:       "missing default file is ignored",
+			env:        map[string]string{"HOME": t.TempDir()},
+			wantSource: "none",
+		},
+		{
+			name:       "default file is not read when both keys are set",
+			env:        map[string]string{"HOME": malformedHome, "MEGAPORT_ACCESS_KEY": "env-access", "MEGAPORT_SECRET_KEY": "env-secret"},
+			wantAccess: "env-access",
+			wantSecret: "env-secret",
+			wantSource: "environment variables",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, err := resolveProviderSettings(tt.config, testEnv(tt.env))
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantAccess, s.accessKey)
+			assert.Equal(t, tt.wantSecret, s.secretKey)
+			assert.Equal(t, tt.wantEnv, s.environment)
+			assert.Equal(t, tt.wantAccount, s.managedAccountUID)
+			assert.Contains(t, s.credentialsSource, tt.wantSource)
+		})
+	}
+}
+
+func TestResolveProviderSettings_Errors(t *testing.T) {
+	file := writeCredentialsFile(t, testCredentialsFile)
+
+	tests := []struct {
+		name    string
+		config  megaportProviderModel
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			name:    "explicit profile not in file",
+			config:  megaportProviderModel{Profile: types.StringValue("customer-b"), SharedCredentialsFile: types.StringValue(file)},
+			wantErr: `profile "customer-b" was not found`,
+		},
+		{
+			name:    "explicit profile with missing file",
+			env:     map[string]string{"MEGAPORT_PROFILE": "customer-a", "MEGAPORT_SHARED_CREDENTIALS_FILE": filepath.Join(t.TempDir(), "missing")},
+			wantErr: "could not read shared credentials file",
+		},
+		{
+			name:    "explicit missing file with keys set",
+			env:     map[string]string{"MEGAPORT_SHARED_CREDENTIALS_FILE": filepath.Join(t.TempDir(), "missing"), "MEGAPORT_ACCESS_KEY": "env-access", "MEGAPORT_SECRET_KEY": "env-secret"},
+			wantErr: "could not read shared credentials file",
+		},
+		{
+			name:    "malformed file",
+			env:     map[string]string{"MEGAPORT_SHARED_CREDENTIALS_FILE": writeCredentialsFile(t, "[default]\nregion = x\n")},
+			wantErr: `unknown key "region"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := resolveProviderSettings(tt.config, testEnv(tt.env))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestResolveProviderSettings_MalformedDefaultFile(t *testing.T) {
+	env := map[string]string{"HOME": writeDefaultCredentialsFile(t, "[default]\nregion = x\n")}
+	s, _, err := resolveProviderSettings(megaportProviderModel{}, testEnv(env))
+	require.NoError(t, err)
+	assert.Equal(t, "none", s.credentialsSource)
+	require.Error(t, s.ignoredLoadErr)
+	assert.Contains(t, s.ignoredLoadErr.Error(), `unknown key "region"`)
+}
+
+func TestShadowedProfileSettings(t *testing.T) {
+	file := writeCredentialsFile(t, testCredentialsFile)
+
+	s, profile, err := resolveProviderSettings(megaportProviderModel{
+		Profile:               types.StringValue("customer-a"),
+		SharedCredentialsFile: types.StringValue(file),
+		Environment:           types.StringValue("staging"),
+	}, testEnv(map[string]string{"MEGAPORT_ACCESS_KEY": "env-access", "MEGAPORT_SECRET_KEY": "env-secret"}))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"access_key and secret_key (environment variables MEGAPORT_ACCESS_KEY and MEGAPORT_SECRET_KEY)",
+		"environment (provider configuration)",
+	}, s.shadowedProfileSettings(profile))
+}
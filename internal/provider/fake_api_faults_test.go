@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFault scripts a misbehaviour of the fake API, so tests can reproduce
+// the failures seen against the real API: slow responses, error codes,
+// partial or inconsistent reads and products that never finish provisioning.
+//
+// A fault applies to requests matching Method and Path. It is added to the
+// fake API with inject, and can be combined with readLag for eventual
+// consistency after updates.
+type fakeFault struct {
+	// Method is the HTTP method to match. Empty matches any method.
+	Method string
+	// Path is a path.Match pattern matched against the request path, for
+	// example "/v2/product/*".
+	Path string
+	// Skip lets this many matching requests through untouched before the
+	// fault starts applying.
+	Skip int
+	// Times limits the fault to this many requests once it starts applying.
+	// Zero applies it to every later match.
+	Times int
+
+	// Delay is waited before the request is handled, or until the client
+	// gives up on the request.
+	Delay time.Duration
+	// Status fails the request with this HTTP status and Message instead of
+	// handling it.
+	Status  int
+	Message string
+	// Mutate edits the data of a successful response before it is sent. For
+	// list responses it is called for each element.
+	Mutate func(data map[string]any)
+
+	matched int
+	hits    int
+}
+
+// inject adds faults to the fake API. Faults are checked in the order they
+// were injected, and every matching fault applies.
+func (f *fakeMegaportAPI) inject(faults ...*fakeFault) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = append(f.faults, faults...)
+}
+
+// hits returns the number of requests a fault has been applied to.
+func (f *fakeMegaportAPI) hits(fault *fakeFault) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fault.hits
+}
+
+// setReadLag sets readLag while requests may be in flight.
+func (f *fakeMegaportAPI) setReadLag(reads int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.readLag = reads
+}
+
+// matchFaults returns the faults that apply to r, counting the hit against
+// each.
+func (f *fakeMegaportAPI) matchFaults(r *http.Request) []*fakeFault {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var active []*fakeFault
+	for _, fault := range f.faults {
+		if fault.Method != "" && fault.Method != r.Method {
+			continue
+		}
+		if ok, _ := path.Match(fault.Path, r.URL.Path); !ok {
+			continue
+		}
+		fault.matched++
+		if fault.matched <= fault.Skip {
+			continue
+		}
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			continue
+		}
+		fault.hits++
+		active = append(active, fault)
+	}
+	return active
+}
+
+// injectFaults applies the injected faults to requests before, or responses
+// after, they are handled by next.
+func (f *fakeMegaportAPI) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		faults := f.matchFaults(r)
+		var mutations []func(map[string]any)
+		for _, fault := range faults {
+			if fault.Delay > 0 {
+				select {
+				case <-time.After(fault.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			if fault.Status != 0 {
+				writeFakeError(w, fault.Status, fault.Message)
+				return
+			}
+			if fault.Mutate != nil {
+				mutations = append(mutations, fault.Mutate)
+			}
+		}
+		if len(mutations) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		body := rec.Body.Bytes()
+		var resp map[string]any
+		if rec.Code == http.StatusOK && json.Unmarshal(body, &resp) == nil {
+			mutateFakeData(resp["data"], mutations)
+			var buf bytes.Buffer
+			_ = json.NewEncoder(&buf).Encode(resp)
+			body = buf.Bytes()
+		}
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(body)
+	})
+}
+
+func mutateFakeData(data any, mutations []func(map[string]any)) {
+	switch d := data.(type) {
+	case map[string]any:
+		for _, mutate := range mutations {
+			mutate(d)
+		}
+	case []any:
+		for _, item := range d {
+			mutateFakeData(item, mutations)
+		}
+	}
+}
+
+// withoutFields returns a Mutate function that blanks fields the way the API
+// does when it omits them: strings become empty and anything else is removed.
+func withoutFields(fields ...string) func(map[string]any) {
+	return func(data map[string]any) {
+		for _, field := range fields {
+			if _, ok := data[field].(string); ok {
+				data[field] = ""
+			} else {
+				delete(data, field)
+			}
+		}
+	}
+}
+
+// stuckIn returns a Mutate function that reports a product as still being in
+// status, however far it has really progressed.
+func stuckIn(status string) func(map[string]any) {
+	return func(data map[string]any) {
+		data["provisioningStatus"] = status
+	}
+}
+
+// ── Seeding and direct API access ──────────────────────────────────────────
+
+// seed stores a product directly in the fake API as if it had been ordered and
+// had finished provisioning, so tests can start from an existing service
+// without paying for the order. It returns the product's UID.
+func (f *fakeMegaportAPI) seed(order func() *fakeProduct) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := order()
+	p.pendingReads = 0
+	f.advance(p)
+	f.advance(p)
+	return p.uid()
+}
+
+// seedPort seeds a LIVE 1 Gbps port in the red diversity zone.
+func (f *fakeMegaportAPI) seedPort(name string) string {
+	return f.seed(func() *fakeProduct {
+		return f.buyPorts(map[string]any{
+			"productName": name,
+			"portSpeed":   1000,
+			"locationId":  fakeLocationID,
+			"term":        12,
+			"config":      map[string]any{"diversityZone": "red"},
+		})[0]
+	})
+}
+
+// seedMCR seeds a LIVE 1 Gbps MCR with ASN 133937.
+func (f *fakeMegaportAPI) seedMCR(name string) string {
+	return f.seed(func() *fakeProduct {
+		return f.buyMCR(map[string]any{
+			"productName": name,
+			"portSpeed":   1000,
+			"locationId":  fakeLocationID,
+			"term":        12,
+		})
+	})
+}
+
+// seedVXC seeds a LIVE VXC between two ports, with VLAN 100 on the A-End and
+// 200 on the B-End.
+func (f *fakeMegaportAPI) seedVXC(name string) string {
+	aEnd, bEnd := f.seedPort(name+" A-End"), f.seedPort(name+" B-End")
+	return f.seed(func() *fakeProduct {
+		return f.buyVXC(map[string]any{
+			"productName": name,
+			"rateLimit":   100,
+			"term":        12,
+			"aEnd":        map[string]any{"productUid": aEnd, "vlan": 100},
+			"bEnd":        map[string]any{"productUid": bEnd, "vlan": 200},
+		})
+	})
+}
+
+// seedPartnerPort seeds a LIVE port standing in for a cloud partner's port.
+// It is listed in the marketplace as a 1 Gbps port in the red zone that
+// accepts VXCs, and LookupPartnerPorts finds it for connectType and
+// pairingKey.
+func (f *fakeMegaportAPI) seedPartnerPort(connectType, pairingKey string) string {
+	name := connectType + " Partner Port"
+	uid := f.seedPort(name)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.partnerPorts = append(f.partnerPorts, &megaport.PartnerMegaport{
+		ConnectType:   connectType,
+		ProductUID:    uid,
+		ProductName:   name,
+		CompanyName:   connectType,
+		DiversityZone: "red",
+		LocationId:    fakeLocationID,
+		Speed:         1000,
+		Rank:          1,
+		VXCPermitted:  true,
+	})
+	f.partnerKeys[strings.ToLower(connectType)+"/"+pairingKey] = uid
+	return uid
+}
+
+// setPartnerPort changes how the partner port uid is listed in the
+// marketplace.
+func (f *fakeMegaportAPI) setPartnerPort(uid string, change func(port *megaport.PartnerMegaport)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, port := range f.partnerPorts {
+		if port.ProductUID == uid {
+			change(port)
+		}
+	}
+}
+
+// seedNATGateway seeds a NAT gateway that has been ordered but is still
+// DEPLOYABLE.
+func (f *fakeMegaportAPI) seedNATGateway(name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, uid := f.newUID()
+	data := natGatewayData(map[string]any{
+		"productName": name,
+		"locationId":  fakeLocationID,
+		"speed":       1000,
+		"term":        12,
+	})
+	data["productUid"] = uid
+	data["productType"] = "NAT_GATEWAY"
+	data["provisioningStatus"] = fakeStatusDeployable
+	f.products[uid] = &fakeProduct{data: data, pendingReads: f.provisioningReads}
+	return uid
+}
+
+// seededPortConfig returns configuration that imports a port created with
+// seedPort and matches it exactly, so applying it makes no changes.
+func seededPortConfig(uid, name string) string {
+	return fakeProviderConfig + fmt.Sprintf(`
+	import {
+		to = megaport_port.port
+		id = %q
+	}
+	resource "megaport_port" "port" {
+		product_name           = %q
+		port_speed             = 1000
+		location_id            = %d
+		contract_term_months   = 12
+		marketplace_visibility = false
+		diversity_zone         = "red"
+	}`, uid, name, fakeLocationID)
+}
+
+// update changes a product the way an accepted update request would, so that
+// readLag applies to it.
+func (f *fakeMegaportAPI) update(uid string, change func(data map[string]any)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p := f.products[uid]
+	f.beginUpdate(p)
+	change(p.data)
+}
+
+// client returns an authorized megaportgo client for the fake API that sends
+// requests through the provider's retry and rate limiting transports, with
+// backoff shortened so retries do not slow tests down.
+func (f *fakeMegaportAPI) client(t *testing.T) *megaport.Client {
+	t.Helper()
+	client, err := megaport.New(newAPIHTTPClient(testRetryPolicy, newRateLimiter(1000, 1000)),
+		megaport.WithBaseURL(f.URL),
+		megaport.WithTokenURL(f.URL+"/oauth2/token"),
+		megaport.WithCredentials(fakeAccessKey, fakeSecretKey),
+	)
+	require.NoError(t, err)
+	_, err = client.Authorize(context.Background())
+	require.NoError(t, err)
+	return client
+}
@@ -0,0 +1,1643 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	megaport "github.com/megaport/megaportgo"
+)
+
+const (
+	fakeAccessKey   = "fake-access-key"
+	fakeSecretKey   = "fake-secret-key"
+	fakeAccessToken = "fake-access-token"
+	fakeCompanyUID  = "c0ffee00-0000-4000-8000-000000000001"
+	fakeCompanyName = "Fake Company"
+
+	// fakeStatusDeployable is the state of a product that has been ordered
+	// but not yet configured.
+	fakeStatusDeployable = "DEPLOYABLE"
+
+	// fakeLocationID is the location seeded into the fake API that unit tests
+	// order products in.
+	fakeLocationID = 1
+)
+
+// fakeProviderConfig configures the provider with credentials the fake API
+// accepts. The fake API's URL is supplied separately, see
+// fakeMegaportAPI.providerFactories.
+var fakeProviderConfig = fmt.Sprintf(`
+provider "megaport" {
+  environment           = "staging"
+  access_key            = %q
+  secret_key            = %q
+  accept_purchase_terms = true
+}
+`, fakeAccessKey, fakeSecretKey)
+
+// fakeMegaportAPI is an in-process stand-in for the Megaport API, so that
+// resource lifecycles can be exercised by unit tests without credentials or
+// network access.
+//
+// Products are stored as JSON objects in the shape the API returns them, so
+// tests can inspect or tamper with any field. Ordered products start out
+// DEPLOYABLE and progress to CONFIGURED and then LIVE as they are read, the way
+// provisioning is observed against the real API.
+type fakeMegaportAPI struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	nextID      int
+	products    map[string]*fakeProduct
+	serviceKeys map[string]map[string]any
+	// partnerPorts are the cloud partner ports listed in the marketplace, and
+	// partnerKeys maps a partner and pairing key, as "partner/key", to the
+	// partner port it is for.
+	partnerPorts []*megaport.PartnerMegaport
+	partnerKeys  map[string]string
+	locations    []*megaport.LocationV3
+	natSessions  []*megaport.NATGatewaySession
+
+	// provisioningReads is the number of reads an ordered product stays
+	// DEPLOYABLE for before it starts moving towards LIVE.
+	provisioningReads int
+
+	// readLag is the number of reads after an update that still return the
+	// product as it was before the update, simulating eventual consistency.
+	readLag int
+
+	// faults are scripted misbehaviours applied to matching requests, see
+	// inject.
+	faults []*fakeFault
+}
+
+// fakeProduct is a product held by the fake API.
+type fakeProduct struct {
+	data map[string]any
+	tags []megaport.ResourceTag
+
+	// pendingReads counts down the reads left before the product leaves
+	// DEPLOYABLE.
+	pendingReads int
+
+	// stale is the product as it was before its last update, returned by
+	// reads for staleReads more reads.
+	stale      map[string]any
+	staleReads int
+
+	prefixLists      map[int]map[string]any
+	nextPrefixListID int
+
+	// bgpPeers are the BGP connections a VXC was ordered or last updated with,
+	// keyed by the product at the end they were configured on.
+	bgpPeers map[string][]map[string]any
+
+	// updates are the bodies of the PUTs a VXC received, in order.
+	updates []map[string]any
+}
+
+func (p *fakeProduct) uid() string         { return fakeString(p.data["productUid"]) }
+func (p *fakeProduct) productType() string { return fakeString(p.data["productType"]) }
+func (p *fakeProduct) status() string      { return fakeString(p.data["provisioningStatus"]) }
+
+// terminated reports whether the product has been cancelled or decommissioned.
+func (p *fakeProduct) terminated() bool {
+	switch p.status() {
+	case megaport.STATUS_DECOMMISSIONED, megaport.STATUS_CANCELLED:
+		return true
+	}
+	return false
+}
+
+// newFakeMegaportAPI starts a fake API seeded with one location and the NAT
+// gateway session matrix. It is shut down when the test finishes.
+func newFakeMegaportAPI(t *testing.T) *fakeMegaportAPI {
+	t.Helper()
+
+	mveCores := 8
+	zone := &megaport.LocationV3DiversityZone{
+		McrSpeedMbps:        []int{1000, 2500, 5000, 10000},
+		MegaportSpeedMbps:   []int{1000, 10000, 100000},
+		MveMaxCpuCoreCount:  &mveCores,
+		MveAvailable:        true,
+		NATGatewaySpeedMbps: []int{1000, 2500},
+	}
+	f := &fakeMegaportAPI{
+		products:    map[string]*fakeProduct{},
+		serviceKeys: map[string]map[string]any{},
+		partnerKeys: map[string]string{},
+		locations: []*megaport.LocationV3{{
+			ID:     fakeLocationID,
+			Name:   "Fake Data Centre",
+			Metro:  "Sydney",
+			Market: "AU",
+			Status: "Active",
+			Address: megaport.LocationV3Address{
+				Street:   "1 Fake Street",
+				City:     "Sydney",
+				State:    "NSW",
+				Postcode: "2000",
+				Country:  "Australia",
+			},
+			DiversityZones: &megaport.LocationV3DiversityZones{Red: zone, Blue: zone},
+		}},
+		natSessions: []*megaport.NATGatewaySession{
+			{SpeedMbps: 1000, SessionCount: []int{100000, 200000}},
+			{SpeedMbps: 2500, SessionCount: []int{200000, 400000}},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", f.handleToken)
+	mux.HandleFunc("GET /v3/locations", f.handleListLocations)
+
+	mux.HandleFunc("POST /v3/networkdesign/validate", f.handleValidateOrder)
+	mux.HandleFunc("POST /v3/networkdesign/buy", f.handleBuyDesign)
+	mux.HandleFunc("POST /v4/networkdesign/buy", f.handleBuyOrder)
+
+	mux.HandleFunc("GET /v2/products", f.handleListProducts)
+	mux.HandleFunc("GET /v2/product/{uid}", f.handleGetProduct)
+	mux.HandleFunc("PUT /v2/product/{type}/{uid}", f.handleModifyProduct)
+	mux.HandleFunc("GET /v2/product/{uid}/loa", f.handleGetLOA)
+	mux.HandleFunc("GET /v2/product/{uid}/tags", f.handleGetTags)
+	mux.HandleFunc("PUT /v2/product/{uid}/tags", f.handleUpdateTags)
+	mux.HandleFunc("PUT /v3/product/vxc/{uid}", f.handleUpdateVXC)
+	mux.HandleFunc("POST /v3/product/{uid}/action/{action}", f.handleProductAction)
+
+	mux.HandleFunc("GET /v2/product/mcr2/{uid}/prefixLists", f.handleListPrefixLists)
+	mux.HandleFunc("POST /v2/product/mcr2/{uid}/prefixList", f.handleCreatePrefixList)
+	mux.HandleFunc("GET /v2/product/mcr2/{uid}/prefixList/{id}", f.handleGetPrefixList)
+	mux.HandleFunc("PUT /v2/product/mcr2/{uid}/prefixList/{id}", f.handleUpdatePrefixList)
+	mux.HandleFunc("DELETE /v2/product/mcr2/{uid}/prefixList/{id}", f.handleDeletePrefixList)
+	mux.HandleFunc("GET /v2/product/mcr2/{uid}/lookingGlass/bgpSessions", f.handleListBGPSessions)
+
+	mux.HandleFunc("POST /v3/products/nat_gateways", f.handleCreateNATGateway)
+	mux.HandleFunc("GET /v3/products/nat_gateways/sessions", f.handleListNATGatewaySessions)
+	mux.HandleFunc("GET /v3/products/nat_gateways/{uid}", f.handleGetNATGateway)
+	mux.HandleFunc("PUT /v3/products/nat_gateways/{uid}", f.handleUpdateNATGateway)
+	mux.HandleFunc("DELETE /v3/products/nat_gateways/{uid}", f.handleDeleteNATGateway)
+
+	mux.HandleFunc("GET /v2/dropdowns/partner/megaports", f.handleListPartnerPorts)
+	mux.HandleFunc("GET /v2/secure/{partner}/{key}", f.handleLookupPartnerPorts)
+
+	mux.HandleFunc("POST /v2/service/key", f.handleCreateServiceKey)
+	mux.HandleFunc("PUT /v2/service/key", f.handleUpdateServiceKey)
+	mux.HandleFunc("GET /v2/service/key", f.handleGetServiceKey)
+	// megaportgo joins "key?key=..." onto the URL path, escaping the "?", so
+	// the key arrives as part of the final path segment.
+	mux.HandleFunc("GET /v2/service/{segment}", f.handleGetServiceKey)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeFakeError(w, http.StatusNotImplemented, fmt.Sprintf("fake Megaport API does not implement %s %s", r.Method, r.URL.Path))
+	})
+
+	f.Server = httptest.NewServer(f.injectFaults(f.authenticate(mux)))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// providerFactories returns provider factories for a provider that talks to
+// the fake API.
+func (f *fakeMegaportAPI) providerFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"megaport": providerserver.NewProtocol6WithError(&megaportProvider{
+			version: "test",
+			clientOpts: []megaport.ClientOpt{
+				megaport.WithBaseURL(f.URL),
+				megaport.WithTokenURL(f.URL + "/oauth2/token"),
+			},
+			pollInterval: 10 * time.Millisecond,
+		}),
+	}
+}
+
+// checkDestroy verifies that every product ordered from the fake API has been
+// terminated and every service key deactivated.
+func (f *fakeMegaportAPI) checkDestroy(*terraform.State) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for uid, p := range f.products {
+		if !p.terminated() {
+			return fmt.Errorf("%s %s is still %s", p.productType(), uid, p.status())
+		}
+	}
+	for key, sk := range f.serviceKeys {
+		if sk["active"] == true {
+			return fmt.Errorf("service key %s is still active", key)
+		}
+	}
+	return nil
+}
+
+// product returns a copy of the API representation of a product, for use in
+// test assertions.
+func (f *fakeMegaportAPI) product(uid string) (map[string]any, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.products[uid]
+	if !ok {
+		return nil, false
+	}
+	return fakeClone(p.data), true
+}
+
+// importStateAttr returns an ImportStateIdFunc that imports a resource by the
+// value of one of its attributes.
+func importStateAttr(resourceName, attr string) resource.ImportStateIdFunc {
+	return func(state *terraform.State) (string, error) {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		return rs.Primary.Attributes[attr], nil
+	}
+}
+
+// ── Request handling helpers ───────────────────────────────────────────────
+
+// authenticate rejects requests without the access token issued by
+// handleToken.
+func (f *fakeMegaportAPI) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth2/token" && r.Header.Get("Authorization") != "Bearer "+fakeAccessToken {
+			writeFakeError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeFakeData writes a successful API response wrapping data.
+func writeFakeData(w http.ResponseWriter, data any) {
+	writeFakeJSON(w, http.StatusOK, map[string]any{
+		"message": "Success",
+		"terms":   "This data is subject to the Acceptable Use Policy https://www.megaport.com/legal/acceptable-use-policy",
+		"data":    data,
+	})
+}
+
+// writeFakeError writes an error in the format megaport.CheckResponse parses.
+func writeFakeError(w http.ResponseWriter, status int, msg string) {
+	writeFakeJSON(w, status, map[string]any{
+		"message":  msg,
+		"data":     msg,
+		"trace_id": "fake-trace-id",
+	})
+}
+
+func writeFakeNotFound(w http.ResponseWriter, uid string) {
+	writeFakeError(w, http.StatusBadRequest, "Could not find a service with UID "+uid)
+}
+
+// decodeFakeBody decodes a JSON request body, writing a 400 response on
+// failure.
+func decodeFakeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeFakeError(w, http.StatusBadRequest, "Could not parse request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func fakeString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func fakeInt(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	case int64:
+		return int(n)
+	}
+	return 0
+}
+
+func fakeMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// fakeClone deep copies a JSON value.
+func fakeClone(m map[string]any) map[string]any {
+	b, _ := json.Marshal(m)
+	var out map[string]any
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// fakeToMap converts a typed API struct to its JSON object representation.
+func fakeToMap(v any) map[string]any {
+	b, _ := json.Marshal(v)
+	var out map[string]any
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+func fakeTags(v any) []megaport.ResourceTag {
+	var tags []megaport.ResourceTag
+	b, _ := json.Marshal(v)
+	_ = json.Unmarshal(b, &tags)
+	return tags
+}
+
+// fakeMillis returns t as epoch milliseconds, the way the API encodes dates.
+func fakeMillis(t time.Time) int64 { return t.UnixMilli() }
+
+// newUID returns a new product ID and UID. Callers must hold f.mu.
+func (f *fakeMegaportAPI) newUID() (int, string) {
+	f.nextID++
+	return 100000 + f.nextID, fakeUID(f.nextID)
+}
+
+// fakeUID returns the UID of the nth product created in a fake API, for
+// configurations that need to name a product before it exists.
+func fakeUID(n int) string {
+	return fmt.Sprintf("fa4e0000-0000-4000-8000-%012d", n)
+}
+
+// location returns a seeded location. Callers must hold f.mu.
+func (f *fakeMegaportAPI) location(id int) *megaport.LocationV3 {
+	for _, l := range f.locations {
+		if l.ID == id {
+			return l
+		}
+	}
+	return nil
+}
+
+// live returns a product that has not been terminated. Callers must hold f.mu.
+func (f *fakeMegaportAPI) live(uid string) (*fakeProduct, bool) {
+	p, ok := f.products[uid]
+	if !ok || p.terminated() {
+		return nil, false
+	}
+	return p, true
+}
+
+// advance moves a product one step through provisioning. Callers must hold
+// f.mu.
+func (f *fakeMegaportAPI) advance(p *fakeProduct) {
+	switch p.status() {
+	case fakeStatusDeployable:
+		if p.pendingReads > 0 {
+			p.pendingReads--
+			return
+		}
+		p.data["provisioningStatus"] = megaport.SERVICE_CONFIGURED
+	case megaport.SERVICE_CONFIGURED:
+		p.data["provisioningStatus"] = megaport.SERVICE_LIVE
+		if p.productType() == "NAT_GATEWAY" {
+			return
+		}
+		now := time.Now()
+		p.data["liveDate"] = fakeMillis(now)
+		p.data["contractStartDate"] = fakeMillis(now)
+		p.data["contractEndDate"] = fakeMillis(now.AddDate(0, max(fakeInt(p.data["contractTermMonths"]), 1), 0))
+	}
+}
+
+// read advances a product and returns the data a read should observe, which
+// lags behind recent updates while readLag is set. Callers must hold f.mu.
+func (f *fakeMegaportAPI) read(p *fakeProduct) map[string]any {
+	f.advance(p)
+	if p.staleReads > 0 {
+		p.staleReads--
+		return p.stale
+	}
+	return p.data
+}
+
+// beginUpdate snapshots a product before it is updated, so that reads keep
+// returning the old data for readLag reads. Callers must hold f.mu.
+func (f *fakeMegaportAPI) beginUpdate(p *fakeProduct) {
+	if f.readLag > 0 {
+		p.stale = fakeClone(p.data)
+		p.staleReads = f.readLag
+	}
+}
+
+// add stores a newly ordered product. Callers must hold f.mu.
+func (f *fakeMegaportAPI) add(data map[string]any, tags []megaport.ResourceTag) *fakeProduct {
+	data["provisioningStatus"] = fakeStatusDeployable
+	data["createDate"] = fakeMillis(time.Now())
+	data["createdBy"] = "fake@example.com"
+	data["companyUid"] = fakeCompanyUID
+	data["companyName"] = fakeCompanyName
+	if data["attributeTags"] == nil {
+		data["attributeTags"] = map[string]any{}
+	}
+	p := &fakeProduct{data: data, tags: tags, pendingReads: f.provisioningReads}
+	f.products[p.uid()] = p
+	return p
+}
+
+// ── Authentication and locations ───────────────────────────────────────────
+
+func (f *fakeMegaportAPI) handleToken(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != fakeAccessKey || pass != fakeSecretKey {
+		writeFakeJSON(w, http.StatusUnauthorized, map[string]any{"error": "invalid_client"})
+		return
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]any{
+		"access_token": fakeAccessToken,
+		"expires_in":   3600,
+	})
+}
+
+func (f *fakeMegaportAPI) handleListLocations(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writeFakeData(w, f.locations)
+}
+
+// ── Ordering ───────────────────────────────────────────────────────────────
+
+// handleValidateOrder validates a network design. NAT gateway designs are
+// identified by the UID of a gateway created with handleCreateNATGateway.
+func (f *fakeMegaportAPI) handleValidateOrder(w http.ResponseWriter, r *http.Request) {
+	var items []map[string]any
+	if !decodeFakeBody(w, r, &items) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var results []map[string]any
+	for _, item := range items {
+		if gw, ok := f.natGatewayDesign(item); ok {
+			results = append(results, map[string]any{
+				"productUid":  gw.uid(),
+				"productType": "NAT_GATEWAY",
+				"string":      "Sydney",
+				"price":       map[string]any{"currency": "AUD", "monthlyRate": 100},
+			})
+			continue
+		}
+		if msg := f.validateItem(item); msg != "" {
+			writeFakeError(w, http.StatusBadRequest, msg)
+			return
+		}
+	}
+	if results == nil {
+		results = []map[string]any{}
+	}
+	writeFakeData(w, results)
+}
+
+// natGatewayDesign returns the NAT gateway a design item refers to. Callers
+// must hold f.mu.
+func (f *fakeMegaportAPI) natGatewayDesign(item map[string]any) (*fakeProduct, bool) {
+	if len(item) != 1 {
+		return nil, false
+	}
+	p, ok := f.live(fakeString(item["productUid"]))
+	if !ok || p.productType() != "NAT_GATEWAY" {
+		return nil, false
+	}
+	return p, true
+}
+
+// validateItem returns why an order item would be rejected, or "" if it is
+// valid. Callers must hold f.mu.
+func (f *fakeMegaportAPI) validateItem(item map[string]any) string {
+	if uid := fakeString(item["productUid"]); uid != "" {
+		if _, ok := f.live(uid); !ok {
+			return "Could not find a service with UID " + uid
+		}
+		vxcs, _ := item["associatedVxcs"].([]any)
+		for _, vxc := range vxcs {
+			for _, end := range []string{"aEnd", "bEnd"} {
+				endUID := fakeString(fakeMap(fakeMap(vxc)[end])["productUid"])
+				if endUID == "" {
+					continue
+				}
+				if _, ok := f.live(endUID); !ok {
+					return "Could not find a service with UID " + endUID
+				}
+			}
+		}
+		return ""
+	}
+	if f.location(fakeInt(item["locationId"])) == nil {
+		return fmt.Sprintf("Location %v is not valid", item["locationId"])
+	}
+	switch pt := fakeString(item["productType"]); pt {
+	case "MEGAPORT", "MCR2":
+	case "MVE":
+		vendorConfig := fakeMap(item["vendorConfig"])
+		if strings.EqualFold(fakeString(vendorConfig["vendor"]), "aruba") && fakeString(vendorConfig["accountKey"]) == "" {
+			return "accountKey is required for Aruba MVEs"
+		}
+	default:
+		return fmt.Sprintf("Product type %q is not valid", pt)
+	}
+	return ""
+}
+
+// handleBuyOrder places a v4 network design order for ports, MCRs, MVEs, VXCs
+// and IXs.
+func (f *fakeMegaportAPI) handleBuyOrder(w http.ResponseWriter, r *http.Request) {
+	var order struct {
+		NetworkDesign []map[string]any `json:"networkDesign"`
+	}
+	if !decodeFakeBody(w, r, &order) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, item := range order.NetworkDesign {
+		if item["associatedVxcs"] == nil {
+			item["associatedVxcs"] = []any{}
+		}
+		if msg := f.validateItem(item); msg != "" {
+			writeFakeError(w, http.StatusBadRequest, msg)
+			return
+		}
+	}
+
+	results := []map[string]any{}
+	for _, item := range order.NetworkDesign {
+		var created []*fakeProduct
+		switch {
+		case len(item["associatedVxcs"].([]any)) > 0:
+			for _, vxc := range item["associatedVxcs"].([]any) {
+				created = append(created, f.buyVXC(fakeMap(vxc)))
+			}
+		case item["associatedIxs"] != nil:
+			for _, ix := range item["associatedIxs"].([]any) {
+				created = append(created, f.buyIX(fakeString(item["productUid"]), fakeMap(ix)))
+			}
+		case item["productType"] == "MEGAPORT":
+			created = f.buyPorts(item)
+		case item["productType"] == "MCR2":
+			created = append(created, f.buyMCR(item))
+		case item["productType"] == "MVE":
+			created = append(created, f.buyMVE(item))
+		}
+		for _, p := range created {
+			results = append(results, map[string]any{
+				"technicalServiceUid":     p.uid(),
+				"vxcJTechnicalServiceUid": p.uid(),
+			})
+		}
+	}
+	writeFakeData(w, results)
+}
+
+// buyPorts creates a port, or lagPortCount ports sharing an aggregation ID
+// for a LAG. Callers must hold f.mu.
+func (f *fakeMegaportAPI) buyPorts(item map[string]any) []*fakeProduct {
+	count := max(fakeInt(item["lagPortCount"]), 1)
+	aggregationID := 0
+	if fakeInt(item["lagPortCount"]) > 0 {
+		aggregationID = 5000 + f.nextID
+	}
+	var ports []*fakeProduct
+	for i := range count {
+		id, uid := f.newUID()
+		data := fakeToMap(megaport.Port{
+			ID:                    id,
+			UID:                   uid,
+			Name:                  fakeString(item["productName"]),
+			Type:                  "MEGAPORT",
+			PortSpeed:             fakeInt(item["portSpeed"]),
+			Market:                f.location(fakeInt(item["locationId"])).Market,
+			LocationID:            fakeInt(item["locationId"]),
+			UsageAlgorithm:        "POST_PAID_HOURLY_SPEED_LONG_TERM_MEGAPORT",
+			MarketplaceVisibility: item["marketplaceVisibility"] == true,
+			VXCPermitted:          true,
+			LAGPrimary:            aggregationID != 0 && i == 0,
+			AggregationID:         aggregationID,
+			CostCentre:            fakeString(item["costCentre"]),
+			ContractTermMonths:    fakeInt(item["term"]),
+			Cancelable:            true,
+			DiversityZone:         fakeString(fakeMap(item["config"])["diversityZone"]),
+			VXCResources: megaport.PortResources{Interface: megaport.PortInterface{
+				Demarcation:  "Fake Data Centre, Level 1, Rack 1",
+				Media:        "LR4",
+				PortSpeed:    fakeInt(item["portSpeed"]),
+				ResourceName: "interface",
+				ResourceType: "interface",
+				Up:           1,
+			}},
+		})
+		if data["diversityZone"] == "" {
+			data["diversityZone"] = "red"
+		}
+		ports = append(ports, f.add(data, fakeTags(item["resourceTags"])))
+	}
+	return ports
+}
+
+// buyMCR creates an MCR. Callers must hold f.mu.
+func (f *fakeMegaportAPI) buyMCR(item map[string]any) *fakeProduct {
+	id, uid := f.newUID()
+	config := fakeMap(item["config"])
+	asn := fakeInt(config["mcrAsn"])
+	if asn == 0 {
+		asn = 133937
+	}
+	visibility, ok := item["marketplaceVisibility"].(bool)
+	if !ok {
+		visibility = true
+	}
+	data := fakeToMap(megaport.MCR{
+		ID:                    id,
+		UID:                   uid,
+		Name:                  fakeString(item["productName"]),
+		Type:                  "MCR2",
+		CostCentre:            fakeString(item["costCentre"]),
+		PortSpeed:             fakeInt(item["portSpeed"]),
+		Market:                f.location(fakeInt(item["locationId"])).Market,
+		LocationID:            fakeInt(item["locationId"]),
+		MarketplaceVisibility: visibility,
+		VXCPermitted:          true,
+		ContractTermMonths:    fakeInt(item["term"]),
+		Virtual:               true,
+		Cancelable:            true,
+		DiversityZone:         fakeString(config["diversityZone"]),
+		Resources: megaport.MCRResources{VirtualRouter: megaport.MCRVirtualRouter{
+			ID:           id,
+			ASN:          asn,
+			Name:         fakeString(item["productName"]),
+			ResourceName: "vrouter",
+			ResourceType: "virtual_router",
+			Speed:        fakeInt(item["portSpeed"]),
+		}},
+	})
+	if data["diversityZone"] == "" {
+		data["diversityZone"] = "blue"
+	}
+	return f.add(data, fakeTags(item["resourceTags"]))
+}
+
+// buyMVE creates an MVE. Callers must hold f.mu.
+func (f *fakeMegaportAPI) buyMVE(item map[string]any) *fakeProduct {
+	id, uid := f.newUID()
+	vendorConfig := fakeMap(item["vendorConfig"])
+	vnics := []*megaport.MVENetworkInterface{}
+	for i, v := range item["vnics"].([]any) {
+		vnic := fakeMap(v)
+		vlan := fakeInt(vnic["vlan"])
+		if vlan == 0 {
+			vlan = 2000 + i
+		}
+		vnics = append(vnics, &megaport.MVENetworkInterface{Description: fakeString(vnic["description"]), VLAN: vlan})
+	}
+	data := fakeToMap(megaport.MVE{
+		ID:                 id,
+		UID:                uid,
+		Name:               fakeString(item["productName"]),
+		Type:               "MVE",
+		Market:             f.location(fakeInt(item["locationId"])).Market,
+		LocationID:         fakeInt(item["locationId"]),
+		VXCPermitted:       true,
+		ContractTermMonths: fakeInt(item["term"]),
+		CostCentre:         fakeString(item["costCentre"]),
+		Virtual:            true,
+		Cancelable:         true,
+		Vendor:             strings.ToUpper(fakeString(vendorConfig["vendor"])),
+		Size:               strings.ToUpper(fakeString(vendorConfig["productSize"])),
+		DiversityZone:      fakeString(fakeMap(item["config"])["diversityZone"]),
+		NetworkInterfaces:  vnics,
+	})
+	if data["diversityZone"] == "" {
+		data["diversityZone"] = "red"
+	}
+	return f.add(data, fakeTags(item["resourceTags"]))
+}
+
+// vxcEnd builds the API representation of one end of a VXC attached to
+// product. Callers must hold f.mu.
+func (f *fakeMegaportAPI) vxcEnd(product *fakeProduct, vlan, innerVLAN, vnicIndex int) map[string]any {
+	locationID := fakeInt(product.data["locationId"])
+	var locationName string
+	if l := f.location(locationID); l != nil {
+		locationName = l.Name
+	}
+	return fakeToMap(megaport.VXCEndConfiguration{
+		OwnerUID:              fakeCompanyUID,
+		UID:                   product.uid(),
+		Name:                  fakeString(product.data["productName"]),
+		LocationID:            locationID,
+		Location:              locationName,
+		VLAN:                  vlan,
+		InnerVLAN:             innerVLAN,
+		NetworkInterfaceIndex: vnicIndex,
+	})
+}
+
+// assignVLAN returns vlan, or an unused VLAN on productUID when vlan is 0 and
+// the end is not untagged. Callers must hold f.mu.
+func (f *fakeMegaportAPI) assignVLAN(productUID string, vlan int) int {
+	if vlan != 0 {
+		return vlan
+	}
+	used := map[int]bool{}
+	for _, p := range f.products {
+		if p.productType() != "VXC" || p.terminated() {
+			continue
+		}
+		for _, end := range []string{"aEnd", "bEnd"} {
+			e := fakeMap(p.data[end])
+			if fakeString(e["productUid"]) == productUID {
+				used[fakeInt(e["vlan"])] = true
+			}
+		}
+	}
+	vlan = 100
+	for used[vlan] {
+		vlan++
+	}
+	return vlan
+}
+
+// buyVXC creates a VXC. Callers must hold f.mu.
+func (f *fakeMegaportAPI) buyVXC(vxc map[string]any) *fakeProduct {
+	id, uid := f.newUID()
+	data := fakeToMap(megaport.VXC{
+		ID:                 id,
+		UID:                uid,
+		ServiceID:          id,
+		Name:               fakeString(vxc["productName"]),
+		Type:               "VXC",
+		RateLimit:          fakeInt(vxc["rateLimit"]),
+		DistanceBand:       "METRO",
+		UsageAlgorithm:     "POST_PAID_HOURLY_SPEED_METRO_VXC",
+		Shutdown:           vxc["shutdown"] == true,
+		ContractTermMonths: fakeInt(vxc["term"]),
+		CostCentre:         fakeString(vxc["costCentre"]),
+		Cancelable:         true,
+	})
+	for _, end := range []string{"aEnd", "bEnd"} {
+		order := fakeMap(vxc[end])
+		endUID := fakeString(order["productUid"])
+		product := f.products[endUID]
+		vlan := fakeInt(order["vlan"])
+		if vlan != -1 {
+			vlan = f.assignVLAN(endUID, vlan)
+		}
+		data[end] = f.vxcEnd(product, vlan, fakeInt(order["innerVlan"]), fakeInt(order["vNicIndex"]))
+	}
+	if conn := cspConnection(fakeMap(fakeMap(vxc["bEnd"])["partnerConfig"]), data); conn != nil {
+		data["resources"] = map[string]any{"csp_connection": conn}
+	}
+	p := f.add(data, fakeTags(vxc["resourceTags"]))
+	p.bgpPeers = map[string][]map[string]any{}
+	for _, end := range []string{"aEnd", "bEnd"} {
+		order := fakeMap(vxc[end])
+		interfaces, _ := fakeMap(order["partnerConfig"])["interfaces"].([]any)
+		for _, iface := range interfaces {
+			connections, _ := fakeMap(iface)["bgpConnections"].([]any)
+			for _, c := range connections {
+				uid := fakeString(order["productUid"])
+				p.bgpPeers[uid] = append(p.bgpPeers[uid], fakeMap(c))
+			}
+		}
+	}
+	return p
+}
+
+// cspConnection returns the CSP connection the API reports for a VXC ordered
+// with partnerConfig on its B-End, for partners that megaportgo has no type
+// for. It echoes the partner configuration without its pairing key.
+func cspConnection(partnerConfig map[string]any, vxc map[string]any) map[string]any {
+	switch fakeString(partnerConfig["connectType"]) {
+	case "", "AWS", "AWSHC", "AZURE", "GOOGLE", "ORACLE", "IBM", "VROUTER", "TRANSIT":
+		return nil
+	}
+	conn := map[string]any{}
+	for k, v := range partnerConfig {
+		if !strings.HasSuffix(strings.ToLower(k), "key") {
+			conn[k] = v
+		}
+	}
+	conn["resource_name"] = "b_csp_connection"
+	conn["resource_type"] = "csp_connection"
+	conn["vlan"] = fakeMap(vxc["bEnd"])["vlan"]
+	conn["bandwidth"] = vxc["rateLimit"]
+	conn["bandwidths"] = []any{vxc["rateLimit"]}
+	return conn
+}
+
+// buyIX creates an IX attached to portUID. Callers must hold f.mu.
+func (f *fakeMegaportAPI) buyIX(portUID string, ix map[string]any) *fakeProduct {
+	id, uid := f.newUID()
+	port := f.products[portUID]
+	vlan := f.assignVLAN(portUID, fakeInt(ix["vlan"]))
+	data := fakeToMap(megaport.IX{
+		ProductID:          id,
+		ProductUID:         uid,
+		LocationID:         fakeInt(port.data["locationId"]),
+		Term:               12,
+		ProductName:        fakeString(ix["productName"]),
+		RateLimit:          fakeInt(ix["rateLimit"]),
+		PromoCode:          fakeString(ix["promoCode"]),
+		VLAN:               vlan,
+		MACAddress:         fakeString(ix["macAddress"]),
+		ASN:                fakeInt(ix["asn"]),
+		NetworkServiceType: fakeString(ix["networkServiceType"]),
+		PublicGraph:        true,
+		UsageAlgorithm:     "POST_PAID_HOURLY_SPEED_IX",
+		Resources: megaport.IXResources{
+			VPLSInterface: megaport.IXVPLSInterface{
+				MACAddress:    fakeString(ix["macAddress"]),
+				RateLimitMbps: fakeInt(ix["rateLimit"]),
+				ResourceName:  "vpls_interface",
+				ResourceType:  "vpls_interface",
+				VLAN:          vlan,
+			},
+		},
+	})
+	data["productType"] = "IX"
+	data["aEnd"] = map[string]any{"productUid": portUID}
+	return f.add(data, nil)
+}
+
+// handleBuyDesign buys NAT gateway designs, the only products ordered through
+// the v3 buy endpoint.
+func (f *fakeMegaportAPI) handleBuyDesign(w http.ResponseWriter, r *http.Request) {
+	var items []map[string]any
+	if !decodeFakeBody(w, r, &items) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := []map[string]any{}
+	for _, item := range items {
+		gw, ok := f.natGatewayDesign(item)
+		if !ok || gw.status() != megaport.STATUS_DESIGN {
+			writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("%v is not a NAT gateway design", item["productUid"]))
+			return
+		}
+		gw.data["provisioningStatus"] = fakeStatusDeployable
+		gw.data["contractEndDate"] = time.Now().AddDate(0, fakeInt(gw.data["term"]), 0).UTC().Format(time.RFC3339)
+		gw.pendingReads = f.provisioningReads
+		results = append(results, map[string]any{
+			"uid":                gw.uid(),
+			"name":               gw.data["productName"],
+			"serviceName":        gw.data["productName"],
+			"productType":        "NAT_GATEWAY",
+			"provisioningStatus": gw.status(),
+			"rateLimit":          gw.data["speed"],
+			"aLocationId":        gw.data["locationId"],
+			"contractTermMonths": gw.data["term"],
+			"createDate":         fakeMillis(time.Now()),
+		})
+	}
+	writeFakeData(w, results)
+}
+
+// ── Products ───────────────────────────────────────────────────────────────
+
+// handleListProducts lists the ports, MCRs and MVEs that have not been
+// terminated.
+func (f *fakeMegaportAPI) handleListProducts(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	products := []map[string]any{}
+	for _, p := range f.products {
+		if p.terminated() {
+			continue
+		}
+		switch p.productType() {
+		case "MEGAPORT", "MCR2", "MVE":
+			products = append(products, p.data)
+		}
+	}
+	slices.SortFunc(products, func(a, b map[string]any) int {
+		return fakeInt(a["productId"]) - fakeInt(b["productId"])
+	})
+	writeFakeData(w, products)
+}
+
+// handleGetProduct returns a product, moving it one step through provisioning
+// first.
+func (f *fakeMegaportAPI) handleGetProduct(w http.ResponseWriter, r *http.Request) {
+	uid := r.PathValue("uid")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.products[uid]
+	if !ok || p.productType() == "NAT_GATEWAY" {
+		writeFakeNotFound(w, uid)
+		return
+	}
+	writeFakeData(w, f.read(p))
+}
+
+// handleModifyProduct updates a port, MCR or MVE.
+func (f *fakeMegaportAPI) handleModifyProduct(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("type") == "ix" {
+		f.handleUpdateIX(w, r)
+		return
+	}
+	uid := r.PathValue("uid")
+	var update map[string]any
+	if !decodeFakeBody(w, r, &update) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.live(uid)
+	if !ok {
+		writeFakeNotFound(w, uid)
+		return
+	}
+	if !strings.EqualFold(r.PathValue("type"), p.productType()) {
+		writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Product %s is not of type %s", uid, r.PathValue("type")))
+		return
+	}
+	f.beginUpdate(p)
+
+	if name := fakeString(update["name"]); name != "" {
+		p.data["productName"] = name
+	}
+	if costCentre, ok := update["costCentre"]; ok {
+		p.data["costCentre"] = costCentre
+	}
+	if visibility, ok := update["marketplaceVisibility"].(bool); ok {
+		p.data["marketplaceVisibility"] = visibility
+	}
+	if term := fakeInt(update["term"]); term != 0 {
+		p.data["contractTermMonths"] = term
+	}
+	if asn := fakeInt(update["asn"]); asn != 0 {
+		fakeMap(fakeMap(p.data["resources"])["virtual_router"])["mcrAsn"] = asn
+	}
+	if vnics, ok := update["vnics"].([]any); ok {
+		existing, _ := p.data["vnics"].([]any)
+		for i, v := range vnics {
+			if i < len(existing) {
+				fakeMap(existing[i])["description"] = fakeMap(v)["description"]
+			}
+		}
+	}
+	writeFakeData(w, p.data)
+}
+
+// handleUpdateIX applies an IX update, including moving it to another port.
+func (f *fakeMegaportAPI) handleUpdateIX(w http.ResponseWriter, r *http.Request) {
+	uid := r.PathValue("uid")
+	var update map[string]any
+	if !decodeFakeBody(w, r, &update) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.live(uid)
+	if !ok || p.productType() != "IX" {
+		writeFakeNotFound(w, uid)
+		return
+	}
+	f.beginUpdate(p)
+	if target := fakeString(update["aEndProductUid"]); target != "" {
+		port, ok := f.live(target)
+		if !ok {
+			writeFakeNotFound(w, target)
+			return
+		}
+		p.data["aEnd"] = map[string]any{"productUid": target}
+		p.data["locationId"] = port.data["locationId"]
+	}
+	vpls := fakeMap(fakeMap(p.data["resources"])["vpls_interface"])
+	if name := fakeString(update["name"]); name != "" {
+		p.data["productName"] = name
+	}
+	if v, ok := update["rateLimit"]; ok {
+		p.data["rateLimit"] = fakeInt(v)
+		vpls["rate_limit_mbps"] = fakeInt(v)
+	}
+	if v, ok := update["vlan"]; ok {
+		p.data["vlan"] = fakeInt(v)
+		vpls["vlan"] = fakeInt(v)
+	}
+	if v := fakeString(update["macAddress"]); v != "" {
+		p.data["macAddress"] = v
+		vpls["mac_address"] = v
+	}
+	if v, ok := update["asn"]; ok {
+		p.data["asn"] = fakeInt(v)
+	}
+	if v, ok := update["publicGraph"].(bool); ok {
+		p.data["publicGraph"] = v
+	}
+	if v, ok := update["shutdown"].(bool); ok {
+		vpls["shutdown"] = v
+	}
+	writeFakeData(w, p.data)
+}
+
+// handleGetLOA returns the LOA PDF of a port.
+func (f *fakeMegaportAPI) handleGetLOA(w http.ResponseWriter, r *http.Request) {
+	uid := r.PathValue("uid")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.live(uid)
+	if !ok {
+		writeFakeNotFound(w, uid)
+		return
+	}
+	if p.productType() != "MEGAPORT" {
+		writeFakeError(w, http.StatusBadRequest, "LOAs are only available for ports")
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	_, _ = w.Write([]byte(fakeLOA(uid)))
+}
+
+// fakeLOA returns the content of the LOA the fake API serves for a port.
+func fakeLOA(uid string) string {
+	return "%PDF-1.4\n% Fake LOA for port " + uid + "\n%%EOF\n"
+}
+
+func (f *fakeMegaportAPI) handleGetTags(w http.ResponseWriter, r *http.Request) {
+	uid := r.PathValue("uid")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.products[uid]
+	if !ok {
+		writeFakeNotFound(w, uid)
+		return
+	}
+	tags := p.tags
+	if tags == nil {
+		tags = []megaport.ResourceTag{}
+	}
+	writeFakeData(w, map[string]any{"resourceTags": tags})
+}
+
+func (f *fakeMegaportAPI) handleUpdateTags(w http.ResponseWriter, r *http.Request) {
+	uid := r.PathValue("uid")
+	var req struct {
+		ResourceTags []megaport.ResourceTag `json:"resourceTags"`
+	}
+	if !decodeFakeBody(w, r, &req) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.live(uid)
+	if !ok {
+		writeFakeNotFound(w, uid)
+		return
+	}
+	p.tags = req.ResourceTags
+	writeFakeData(w, nil)
+}
+
+// handleUpdateVXC applies a VXC update, including moving either end to
+// another product.
+func (f *fakeMegaportAPI) handleUpdateVXC(w http.ResponseWriter, r *http.Request) {
+	uid := r.PathValue("uid")
+	var update map[string]any
+	if !decodeFakeBody(w, r, &update) {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.live(uid)
+	if !ok || p.productType() != "VXC" {
+		writeFakeNotFound(w, uid)
+		return
+	}
+	f.beginUpdate(p)
+	p.updates = append(p.updates, update)
+
+	for _, end := range []struct{ key, prefix, vnic string }{{"aEnd", "aEnd", "aVnicIndex"}, {"bEnd", "bEnd", "bVnicIndex"}} {
+		if target := fakeString(update[end.prefix+"ProductUid"]); target != "" && target != fakeString(fakeMap(p.data[end.key])["productUid"]) {
+			product, ok := f.live(target)
+			if !ok {
+				writeFakeNotFound(w, target)
+				return
+			}
+			old := fakeMap(p.data[end.key])
+			p.data[end.key] = f.vxcEnd(product, fakeInt(old["vlan"]), fakeInt(old["innerVlan"]), fakeInt(old["vNicIndex"]))
+		}
+	}
+	for _, end := range []struct{ key, prefix, vnic string }{{"aEnd", "aEnd", "aVnicIndex"}, {"bEnd", "bEnd", "bVnicIndex"}} {
+		e := fakeMap(p.data[end.key])
+		if v, ok := update[end.prefix+"Vlan"]; ok {
+			e["vlan"] = f.assignVLAN(fakeString(e["productUid"]), fakeInt(v))
+		}
+		if v, ok := update[end.prefix+"InnerVlan"]; ok {
+			e["innerVlan"] = fakeInt(v)
+		}
+		if v, ok := update[end.vnic]; ok {
+			e["vNicIndex"] = fakeInt(v)
+		}
+	}
+	if name := fakeString(update["name"]); name != "" {
+		p.data["productName"] = name
+	}
+	if v, ok := update["rateLimit"]; ok {
+		p.data["rateLimit"] = fakeInt(v)
+	}
+	if v, ok := update["costCentre"]; ok {
+		p.data["costCentre"] = v
+	}
+	if v, ok := update["shutdown"].(bool); ok {
+		p.data["shutdown"] = v
+	}
+	if v, ok := update["term"]; ok {
+		p.data["contractTermMonths"] = fakeInt(v)
+	}
+	for _, end := range []struct{ key, config string }{{"aEnd", "aEndConfig"}, {"bEnd", "bEndConfig"}} {
+		interfaces, ok := fakeMap(update[end.config])["interfaces"].([]any)
+		if !ok {
+			continue
+		}
+		if p.bgpPeers == nil {
+			p.bgpPeers = map[string][]map[string]any{}
+		}
+		endUID := fakeString(fakeMap(p.data[end.key])["productUid"])
+		p.bgpPeers[endUID] = nil
+		for _, iface := range interfaces {
+			connections, _ := fakeMap(iface)["bgpConnections"].([]any)
+			for _, c := range connections {
+				p.bgpPeers[endUID] = append(p.bgpPeers[endUID], fakeMap(c))
+			}
+		}
+	}
+	writeFakeData(w, p.data)
+}
+
+// handleProductAction cancels a product. With safeDelete, products that still
+// have VXCs or IXs attached are refused, as the API does.
+func (f *fakeMegaportAPI) handleProductAction(w http.ResponseWriter, r *http.Request) {
+	uid := r.PathValue("uid")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.live(uid)
+	if !ok {
+		writeFakeNotFound(w, uid)
+		return
+	}
+
+	var status string
+	switch action := r.PathValue("action"); action {
+	case "CANCEL_NOW":
+		status = megaport.STATUS_DECOMMISSIONED
+	case "CANCEL":
+		status = megaport.STATUS_CANCELLED
+	default:
+		writeFakeError(w, http.StatusBadRequest, "Unsupported action "+action)
+		return
+	}
+	if r.URL.Query().Get("safeDelete") == "true" {
+		if attached := f.attachedServices(uid); len(attached) > 0 {
+			writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Product %s has attached services: %s", uid, strings.Join(attached, ", ")))
+			return
+		}
+	}
+
+	cancel := []*fakeProduct{p}
+	if p.data["lagPrimary"] == true {
+		for _, member := range f.products {
+			if member != p && fakeInt(member.data["aggregationId"]) == fakeInt(p.data["aggregationId"]) {
+				cancel = append(cancel, member)
+			}
+		}
+	}
+	for _, c := range cancel {
+		c.data["provisioningStatus"] = status
+		c.data["terminateDate"] = fakeMillis(time.Now())
+	}
+	writeFakeData(w, nil)
+}
+
+// attachedServices lists the UIDs of VXCs and IXs attached to a product.
+// Callers must hold f.mu.
+func (f *fakeMegaportAPI) attachedServices(uid string) []string {
+	var attached []string
+	for _, p := range f.products {
+		if p.terminated() {
+			continue
+		}
+		switch p.productType() {
+		case "VXC", "IX":
+			for _, end := range []string{"aEnd", "bEnd"} {
+				if fakeString(fakeMap(p.data[end])["productUid"]) == uid {
+					attached = append(attached, p.uid())
+					break
+				}
+			}
+		}
+	}
+	slices.Sort(attached)
+	return attached
+}
+
+// ── MCR prefix filter lists ────────────────────────────────────────────────
+
+// mcr returns a live MCR. Callers must hold f.mu.
+func (f *fakeMegaportAPI) mcr(w http.ResponseWriter, uid string) (*fakeProduct, bool) {
+	p, ok := f.live(uid)
+	if !ok || p.productType() != "MCR2" {
+		writeFakeNotFound(w, uid)
+		return nil, false
+	}
+	if p.prefixLists == nil {
+		p.prefixLists = map[int]map[string]any{}
+	}
+	return p, true
+}
+
+// prefixListBody decodes a prefix filter list, which is sent with integer ge
+// and le values but returned with string ones.
+func prefixListBody(w http.ResponseWriter, r *http.Request) (map[string]any, bool) {
+	var list megaport.MCRPrefixFilterList
+	if !decodeFakeBody(w, r, &list) {
+		return nil, false
+	}
+	out := megaport.APIMCRPrefixFilterList{
+		Description:   list.Description,
+		AddressFamily: list.AddressFamily,
+		Entries:       []*megaport.APIMCRPrefixFilterListEntry{},
+	}
+	for _, e := range list.Entries {
+		entry := &megaport.APIMCRPrefixFilterListEntry{Action: e.Action, Prefix: e.Prefix}
+		if e.Ge != 0 {
+			entry.Ge = strconv.Itoa(e.Ge)
+		}
+		if e.Le != 0 {
+			entry.Le = strconv.Itoa(e.Le)
+		}
+		out.Entries = append(out.Entries, entry)
+	}
+	return fakeToMap(out), true
+}
+
+func (f *fakeMegaportAPI) handleListPrefixLists(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.mcr(w, r.PathValue("uid"))
+	if !ok {
+		return
+	}
+	lists := []map[string]any{}
+	for _, l := range p.prefixLists {
+		lists = append(lists, map[string]any{
+			"id":            l["id"],
+			"description":   l["description"],
+			"addressFamily": l["addressFamily"],
+		})
+	}
+	slices.SortFunc(lists, func(a, b map[string]any) int { return fakeInt(a["id"]) - fakeInt(b["id"]) })
+	writeFakeData(w, lists)
+}
+
+func (f *fakeMegaportAPI) handleCreatePrefixList(w http.ResponseWriter, r *http.Request) {
+	list, ok := prefixListBody(w, r)
+	if !ok {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.mcr(w, r.PathValue("uid"))
+	if !ok {
+		return
+	}
+	p.nextPrefixListID++
+	list["id"] = p.nextPrefixListID
+	p.prefixLists[p.nextPrefixListID] = list
+	writeFakeData(w, list)
+}
+
+// prefixList returns a prefix filter list of a live MCR. Callers must hold
+// f.mu.
+func (f *fakeMegaportAPI) prefixList(w http.ResponseWriter, r *http.Request) (*fakeProduct, int, bool) {
+	p, ok := f.mcr(w, r.PathValue("uid"))
+	if !ok {
+		return nil, 0, false
+	}
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if _, exists := p.prefixLists[id]; err != nil || !exists {
+		writeFakeError(w, http.StatusNotFound, "Prefix filter list "+r.PathValue("id")+" not found")
+		return nil, 0, false
+	}
+	return p, id, true
+}
+
+func (f *fakeMegaportAPI) handleGetPrefixList(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, id, ok := f.prefixList(w, r); ok {
+		writeFakeData(w, p.prefixLists[id])
+	}
+}
+
+func (f *fakeMegaportAPI) handleUpdatePrefixList(w http.ResponseWriter, r *http.Request) {
+	list, ok := prefixListBody(w, r)
+	if !ok {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, id, ok := f.prefixList(w, r); ok {
+		list["id"] = id
+		p.prefixLists[id] = list
+		writeFakeData(w, list)
+	}
+}
+
+func (f *fakeMegaportAPI) handleDeletePrefixList(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, id, ok := f.prefixList(w, r); ok {
+		delete(p.prefixLists, id)
+		writeFakeData(w, nil)
+	}
+}
+
+// ── MCR looking glass ──────────────────────────────────────────────────────
+
+// handleListBGPSessions lists a session for each BGP connection VXCs to the
+// MCR were ordered with. Sessions are always up unless a fault says otherwise.
+func (f *fakeMegaportAPI) handleListBGPSessions(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	mcr, ok := f.mcr(w, r.PathValue("uid"))
+	if !ok {
+		return
+	}
+	sessions := []*megaport.LookingGlassBGPSession{}
+	for _, p := range f.products {
+		if p.productType() != "VXC" || p.terminated() {
+			continue
+		}
+		for i, c := range p.bgpPeers[mcr.uid()] {
+			sessions = append(sessions, &megaport.LookingGlassBGPSession{
+				SessionID:       fmt.Sprintf("%s-%d", p.uid(), i),
+				NeighborAddress: fakeString(c["peerIpAddress"]),
+				NeighborASN:     fakeInt(c["peerAsn"]),
+				LocalASN:        fakeInt(c["localAsn"]),
+				Status:          megaport.BGPSessionStatusUp,
+				Uptime:          megaport.PtrTo(3600),
+				LastStateChange: megaport.PtrTo(3600),
+				PrefixesIn:      megaport.PtrTo(1),
+				PrefixesOut:     megaport.PtrTo(1),
+				Description:     fakeString(c["description"]),
+				VXCID:           fakeInt(p.data["productId"]),
+				VXCName:         fakeString(p.data["productName"]),
+			})
+		}
+	}
+	writeFakeData(w, sessions)
+}
+
+// ── NAT gateways ───────────────────────────────────────────────────────────
+
+// natGateway returns a NAT gateway, writing a 404 if there is none. Callers
+// must hold f.mu.
+func (f *fakeMegaportAPI) natGateway(w http.ResponseWriter, uid string) (*fakeProduct, bool) {
+	p, ok := f.products[uid]
+	if !ok || p.productType() != "NAT_GATEWAY" {
+		writeFakeError(w, http.StatusNotFound, "NAT gateway "+uid+" not found")
+		return nil, false
+	}
+	return p, true
+}
+
+// natGatewayData builds the API representation of a NAT gateway from a create
+// or update request.
+func natGatewayData(req map[string]any) map[string]any {
+	data := fakeToMap(megaport.NATGateway{})
+	for _, key := range []string{"autoRenewTerm", "config", "locationId", "productName", "promoCode", "resourceTags", "serviceLevelReference", "speed", "term"} {
+		if v, ok := req[key]; ok {
+			data[key] = v
+		}
+	}
+	if data["resourceTags"] == nil {
+		data["resourceTags"] = []any{}
+	}
+	return data
+}
+
+func (f *fakeMegaportAPI) handleCreateNATGateway(w http.ResponseWriter, r *http.Request) {
+	var req map[string]any
+	if !decodeFakeBody(w, r, &req) {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.location(fakeInt(req["locationId"])) == nil {
+		writeFakeError(w, http.StatusBadRequest, fmt.Sprintf("Location %v is not valid", req["locationId"]))
+		return
+	}
+	_, uid := f.newUID()
+	data := natGatewayData(req)
+	data["productUid"] = uid
+	data["productType"] = "NAT_GATEWAY"
+	data["provisioningStatus"] = megaport.STATUS_DESIGN
+	data["createDate"] = time.Now().UTC().Format(time.RFC3339)
+	data["createdBy"] = "fake@example.com"
+	p := &fakeProduct{data: data}
+	f.products[uid] = p
+	writeFakeData(w, data)
+}
+
+func (f *fakeMegaportAPI) handleListNATGatewaySessions(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writeFakeData(w, f.natSessions)
+}
+
+func (f *fakeMegaportAPI) handleGetNATGateway(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p, ok := f.natGateway(w, r.PathValue("uid")); ok {
+		writeFakeData(w, f.read(p))
+	}
+}
+
+func (f *fakeMegaportAPI) handleUpdateNATGateway(w http.ResponseWriter, r *http.Request) {
+	var req map[string]any
+	if !decodeFakeBody(w, r, &req) {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.natGateway(w, r.PathValue("uid"))
+	if !ok {
+		return
+	}
+	if p.terminated() {
+		writeFakeError(w, http.StatusBadRequest, "NAT gateway "+p.uid()+" has been terminated")
+		return
+	}
+	f.beginUpdate(p)
+	for k, v := range natGatewayData(req) {
+		if _, sent := req[k]; sent || k == "resourceTags" {
+			p.data[k] = v
+		}
+	}
+	writeFakeData(w, p.data)
+}
+
+// handleDeleteNATGateway deletes a NAT gateway that is still a design. Ordered
+// gateways are cancelled through handleProductAction instead.
+func (f *fakeMegaportAPI) handleDeleteNATGateway(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	p, ok := f.natGateway(w, r.PathValue("uid"))
+	if !ok {
+		return
+	}
+	if p.status() != megaport.STATUS_DESIGN {
+		writeFakeError(w, http.StatusBadRequest, "Only NAT gateway designs can be deleted")
+		return
+	}
+	delete(f.products, p.uid())
+	writeFakeData(w, nil)
+}
+
+// ── Service keys ───────────────────────────────────────────────────────────
+
+// serviceKeyRequest is the body of service key create and update requests.
+type serviceKeyRequest struct {
+	Key         string                  `json:"key"`
+	ProductUID  string                  `json:"productUid"`
+	SingleUse   bool                    `json:"singleUse"`
+	MaxSpeed    int                     `json:"maxSpeed"`
+	Active      bool                    `json:"active"`
+	PreApproved bool                    `json:"preApproved"`
+	Description string                  `json:"description"`
+	VLAN        int                     `json:"vlan"`
+	ValidFor    *megaport.OrderValidFor `json:"validFor"`
+}
+
+func (f *fakeMegaportAPI) handleCreateServiceKey(w http.ResponseWriter, r *http.Request) {
+	var req serviceKeyRequest
+	if !decodeFakeBody(w, r, &req) {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	port, ok := f.live(req.ProductUID)
+	if !ok {
+		writeFakeNotFound(w, req.ProductUID)
+		return
+	}
+	f.nextID++
+	key := fmt.Sprintf("5e41ce00-0000-4000-8000-%012d", f.nextID)
+	sk := map[string]any{
+		"key":         key,
+		"createDate":  fakeMillis(time.Now()),
+		"companyId":   1,
+		"companyUid":  fakeCompanyUID,
+		"companyName": fakeCompanyName,
+		"description": req.Description,
+		"productId":   port.data["productId"],
+		"productUid":  port.uid(),
+		"productName": port.data["productName"],
+		"vlan":        req.VLAN,
+		"maxSpeed":    req.MaxSpeed,
+		"preApproved": req.PreApproved,
+		"singleUse":   req.SingleUse,
+		"active":      req.Active,
+		"expired":     false,
+		"valid":       true,
+	}
+	if req.ValidFor != nil {
+		sk["validFor"] = map[string]any{"start": req.ValidFor.Start, "end": req.ValidFor.End}
+	}
+	f.serviceKeys[key] = sk
+	writeFakeData(w, map[string]any{"key": key})
+}
+
+func (f *fakeMegaportAPI) handleUpdateServiceKey(w http.ResponseWriter, r *http.Request) {
+	var req serviceKeyRequest
+	if !decodeFakeBody(w, r, &req) {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sk, ok := f.serviceKeys[req.Key]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Service key "+req.Key+" not found")
+		return
+	}
+	sk["singleUse"] = req.SingleUse
+	sk["active"] = req.Active
+	if req.ValidFor != nil {
+		sk["validFor"] = map[string]any{"start": req.ValidFor.Start, "end": req.ValidFor.End}
+	}
+	writeFakeData(w, nil)
+}
+
+func (f *fakeMegaportAPI) handleGetServiceKey(w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if segment := r.PathValue("segment"); segment != "" {
+		var ok bool
+		if key, ok = strings.CutPrefix(segment, "key?key="); !ok {
+			writeFakeError(w, http.StatusNotImplemented, "fake Megaport API does not implement GET "+r.URL.Path)
+			return
+		}
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	sk, ok := f.serviceKeys[key]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Service key "+key+" not found")
+		return
+	}
+	writeFakeData(w, sk)
+}
+
+// ── Partner ports ──────────────────────────────────────────────────────────
+
+func (f *fakeMegaportAPI) handleListPartnerPorts(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	writeFakeData(w, f.partnerPorts)
+}
+
+// handleLookupPartnerPorts returns the partner port a pairing key was issued
+// for, see seedPartnerPort.
+func (f *fakeMegaportAPI) handleLookupPartnerPorts(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := r.PathValue("partner") + "/" + r.PathValue("key")
+	p, ok := f.products[f.partnerKeys[key]]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "Pairing key "+r.PathValue("key")+" not found")
+		return
+	}
+	writeFakeData(w, megaport.PartnerLookup{
+		Megaports: []megaport.PartnerLookupItem{{
+			ProductUID: p.uid(),
+			Name:       fakeString(p.data["productName"]),
+			PortSpeed:  fakeInt(p.data["portSpeed"]),
+			LocationID: fakeInt(p.data["locationId"]),
+			Type:       "primary",
+		}},
+	})
+}
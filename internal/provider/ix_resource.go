@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,6 +21,7 @@ var (
 	_ resource.Resource                = &ixResource{}
 	_ resource.ResourceWithConfigure   = &ixResource{}
 	_ resource.ResourceWithImportState = &ixResource{}
+	_ resource.ResourceWithIdentity    = &ixResource{}
 
 	interfaceAttrTypes = map[string]attr.Type{
 		"demarcation":   types.StringType,
@@ -100,6 +102,8 @@ type ixResourceModel struct {
 	UsageAlgorithm      types.String `tfsdk:"usage_algorithm"`
 
 	Resources types.Object `tfsdk:"resources"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type ixResourcesModel struct {
@@ -289,7 +293,9 @@ func NewIXResource() resource.Resource {
 
 // ixResource is the resource implementation.
 type ixResource struct {
-	client *megaport.Client
+	client       *megaport.Client
+	waitTime     time.Duration
+	pollInterval time.Duration
 }
 
 // Metadata returns the resource type name.
@@ -298,7 +304,7 @@ func (r *ixResource) Metadata(_ context.Context, req resource.MetadataRequest, r
 }
 
 // Schema defines the schema for the resource.
-func (r *ixResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ixResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Megaport Internet Exchange (IX).",
 		Attributes: map[string]schema.Attribute{
@@ -547,6 +553,9 @@ func (r *ixResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -560,6 +569,12 @@ func (r *ixResource) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Generate API request body from plan
 	buyReq := &megaport.BuyIXRequest{
 		ProductUID:         plan.RequestedProductUID.ValueString(),
@@ -571,8 +586,6 @@ func (r *ixResource) Create(ctx context.Context, req resource.CreateRequest, res
 		VLAN:               int(plan.VLAN.ValueInt64()),
 		Shutdown:           plan.Shutdown.ValueBool(),
 		PromoCode:          plan.PromoCode.ValueString(),
-		WaitForProvision:   true,
-		WaitForTime:        waitForTime,
 	}
 
 	// Create the IX
@@ -585,6 +598,15 @@ func (r *ixResource) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	// Wait for the IX to be provisioned
+	if err := waitForProductReady(ctx, "IX", []string{ixResp.TechnicalServiceUID}, createTimeout, r.pollInterval, ixStatus(r.client)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating IX",
+			"Could not create IX, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
 	// Get the created IX
 	ix, err := r.client.IXService.GetIX(ctx, ixResp.TechnicalServiceUID)
 	if err != nil {
@@ -601,6 +623,7 @@ func (r *ixResource) Create(ctx context.Context, req resource.CreateRequest, res
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.ProductUID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -616,9 +639,25 @@ func (r *ixResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed IX value from API
 	ix, err := r.client.IXService.GetIX(ctx, state.ProductUID.ValueString())
 	if err != nil {
+		// A timed out read says nothing about whether the IX still exists.
+		if ctx.Err() != nil {
+			resp.Diagnostics.AddError(
+				"Error reading IX",
+				"Could not read IX with ID "+state.ProductUID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
 		// IX has been deleted or is not found
 		resp.State.RemoveResource(ctx)
 		return
@@ -630,6 +669,7 @@ func (r *ixResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.ProductUID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -646,12 +686,15 @@ func (r *ixResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
-	// Create update request with only fields that have changed
-	updateReq := &megaport.UpdateIXRequest{
-		WaitForUpdate: true,
-		WaitForTime:   waitForTime,
+	updateTimeout, diags := plan.Timeouts.Update(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
+	// Create update request with only fields that have changed
+	updateReq := &megaport.UpdateIXRequest{}
+
 	if !plan.ProductName.Equal(state.ProductName) {
 		name := plan.ProductName.ValueString()
 		updateReq.Name = &name
@@ -685,6 +728,9 @@ func (r *ixResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		updateReq.ASN != nil ||
 		updateReq.Shutdown != nil {
 		_, err := r.client.IXService.UpdateIX(ctx, state.ProductUID.ValueString(), updateReq)
+		if err == nil {
+			err = waitForProductReady(ctx, "IX", []string{state.ProductUID.ValueString()}, updateTimeout, r.pollInterval, ixStatus(r.client))
+		}
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error updating IX",
@@ -707,10 +753,12 @@ func (r *ixResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	// Update the state with the IX info
 	state.fromAPI(ctx, updatedIX)
 	state.PromoCode = plan.PromoCode
+	state.Timeouts = plan.Timeouts
 
 	// Persist the new state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.ProductUID)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -723,11 +771,17 @@ func (r *ixResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete the IX
-	err := retryTransientDelete(ctx, 3, func() error {
-		return r.client.IXService.DeleteIX(ctx, state.ProductUID.ValueString(), &megaport.DeleteIXRequest{
-			DeleteNow: true,
-		})
+	err := r.client.IXService.DeleteIX(ctx, state.ProductUID.ValueString(), &megaport.DeleteIXRequest{
+		DeleteNow: true,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -743,7 +797,12 @@ func (r *ixResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 
 func (r *ixResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("product_uid"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("product_uid"), path.Root("product_uid"), req, resp)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *ixResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = productIdentitySchema()
 }
 
 // Configure adds the provider configured client to the resource.
@@ -765,4 +824,6 @@ func (r *ixResource) Configure(_ context.Context, req resource.ConfigureRequest,
 	client := data.client
 
 	r.client = client
+	r.waitTime = data.waitTime
+	r.pollInterval = data.pollInterval
 }
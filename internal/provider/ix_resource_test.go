@@ -171,3 +171,63 @@ resource "megaport_ix" "test_ix" {
 		},
 	})
 }
+
+func TestUnitMegaportIX_Basic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	config := func(name string, rateLimit, vlan int) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_port" "port" {
+			product_name           = "Unit Test Port"
+			location_id            = %d
+			port_speed             = 1000
+			marketplace_visibility = false
+			contract_term_months   = 1
+		}
+		resource "megaport_ix" "ix" {
+			product_name          = %q
+			requested_product_uid = megaport_port.port.product_uid
+			network_service_type  = "Sydney IX"
+			asn                   = 12345
+			mac_address           = "00:11:22:33:44:55"
+			rate_limit            = %d
+			vlan                  = %d
+			shutdown              = false
+		}`, fakeLocationID, name, rateLimit, vlan)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Unit Test IX", 500, 2001),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_ix.ix", "product_name", "Unit Test IX"),
+					resource.TestCheckResourceAttr("megaport_ix.ix", "network_service_type", "Sydney IX"),
+					resource.TestCheckResourceAttr("megaport_ix.ix", "rate_limit", "500"),
+					resource.TestCheckResourceAttr("megaport_ix.ix", "vlan", "2001"),
+					resource.TestCheckResourceAttrSet("megaport_ix.ix", "product_uid"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_ix.ix",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "product_uid",
+				ImportStateIdFunc:                    importStateAttr("megaport_ix.ix", "product_uid"),
+				ImportStateVerifyIgnore:              []string{"last_updated", "requested_product_uid", "shutdown", "mac_address", "contract_start_date", "contract_end_date", "live_date", "resources", "provisioning_status"},
+			},
+			{
+				Config: config("Unit Test IX Renamed", 750, 2002),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_ix.ix", "product_name", "Unit Test IX Renamed"),
+					resource.TestCheckResourceAttr("megaport_ix.ix", "rate_limit", "750"),
+					resource.TestCheckResourceAttr("megaport_ix.ix", "vlan", "2002"),
+				),
+			},
+		},
+	})
+}
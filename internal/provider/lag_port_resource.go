@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -29,6 +30,8 @@ var (
 	_ resource.Resource                = &lagPortResource{}
 	_ resource.ResourceWithConfigure   = &lagPortResource{}
 	_ resource.ResourceWithImportState = &lagPortResource{}
+	_ resource.ResourceWithIdentity    = &lagPortResource{}
+	_ resource.ResourceWithModifyPlan  = &lagPortResource{}
 )
 
 // lagPortResourceModel maps the resource schema data.
@@ -66,6 +69,8 @@ type lagPortResourceModel struct {
 
 	Resources    types.Object `tfsdk:"resources"`
 	ResourceTags types.Map    `tfsdk:"resource_tags"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (orm *lagPortResourceModel) fromAPIPort(ctx context.Context, p *megaport.Port, tags map[string]string) diag.Diagnostics {
@@ -145,7 +150,9 @@ func NewLagPortResource() resource.Resource {
 
 // lagPortResource is the resource implementation.
 type lagPortResource struct {
-	client *megaport.Client
+	client       *megaport.Client
+	waitTime     time.Duration
+	pollInterval time.Duration
 }
 
 // Metadata returns the resource type name.
@@ -154,7 +161,7 @@ func (r *lagPortResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *lagPortResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *lagPortResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Link Aggregation Group (LAG) Port Resource for the Megaport Terraform Provider. This can be used to create, modify, and delete Megaport LAG Ports. A LAG bundles physical ports to create a single data path, where the traffic load is distributed among the ports to increase overall connection reliability.",
 		Attributes: map[string]schema.Attribute{
@@ -317,7 +324,7 @@ func (r *lagPortResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"lag_count": schema.Int64Attribute{
-				Description: "The number of LAG ports. Valid values are between 1 and 8.",
+				Description: "The number of LAG ports. Valid values are between 1 and 8. Changing it replaces the LAG and the VXCs attached to it, as ports cannot be ordered into or removed from an existing LAG.",
 				Required:    true,
 				Validators: []validator.Int64{
 					int64validator.Between(1, 8),
@@ -361,6 +368,9 @@ func (r *lagPortResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -374,6 +384,12 @@ func (r *lagPortResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	buyPortReq := &megaport.BuyPortRequest{
 		Name:                  plan.Name.ValueString(),
 		Term:                  int(plan.ContractTermMonths.ValueInt64()),
@@ -384,8 +400,6 @@ func (r *lagPortResource) Create(ctx context.Context, req resource.CreateRequest
 		DiversityZone:         plan.DiversityZone.ValueString(),
 		CostCentre:            plan.CostCentre.ValueString(),
 		PromoCode:             plan.PromoCode.ValueString(),
-		WaitForProvision:      true,
-		WaitForTime:           waitForTime,
 	}
 
 	if !plan.ResourceTags.IsNull() {
@@ -415,6 +429,15 @@ func (r *lagPortResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	// Wait for every port of the LAG to be provisioned
+	if err := waitForProductReady(ctx, "Port", createdPort.TechnicalServiceUIDs, createTimeout, r.pollInterval, portStatus(r.client)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating Port",
+			"Could not create port with name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
 	if len(createdPort.TechnicalServiceUIDs) < 1 {
 		resp.Diagnostics.AddError(
 			"Unexpected number of ports created",
@@ -460,6 +483,7 @@ func (r *lagPortResource) Create(ctx context.Context, req resource.CreateRequest
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -475,6 +499,14 @@ func (r *lagPortResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed port value from API
 	port, err := r.client.PortService.GetPort(ctx, state.UID.ValueString())
 	if err != nil {
@@ -514,26 +546,17 @@ func (r *lagPortResource) Read(ctx context.Context, req resource.ReadRequest, re
 	resp.Diagnostics.Append(apiDiags...)
 
 	// Populate the LAG port UIDs
-	if len(port.LagPortUIDs) > 0 {
-		lagPortUIDsList := []attr.Value{}
-		for _, uid := range port.LagPortUIDs {
-			lagPortUIDsList = append(lagPortUIDsList, types.StringValue(uid))
-		}
-
-		lagPortUIDs, diags := types.ListValue(types.StringType, lagPortUIDsList)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		state.LagPortUIDs = lagPortUIDs
-	} else {
-		state.LagPortUIDs = types.ListNull(types.StringType)
+	lagPortUIDs, diags := lagPortUIDsFromAPI(port)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	state.LagPortUIDs = lagPortUIDs
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -549,6 +572,12 @@ func (r *lagPortResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Check on changes
 	var name, costCentre string
 	var marketplaceVisibility bool
@@ -577,9 +606,10 @@ func (r *lagPortResource) Update(ctx context.Context, req resource.UpdateRequest
 		MarketplaceVisibility: &marketplaceVisibility,
 		CostCentre:            costCentre,
 		ContractTermMonths:    contractTermMonths,
-		WaitForUpdate:         true,
-		WaitForTime:           waitForTime,
 	})
+	if err == nil {
+		err = waitForProductReady(ctx, "Port", []string{plan.UID.ValueString()}, updateTimeout, r.pollInterval, portStatus(r.client))
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -625,15 +655,20 @@ func (r *lagPortResource) Update(ctx context.Context, req resource.UpdateRequest
 
 	// Update the state
 	resp.Diagnostics.Append(state.fromAPIPort(ctx, port, tags)...)
+	lagPortUIDs, diags := lagPortUIDsFromAPI(port)
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.LagPortUIDs = lagPortUIDs
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	state.PromoCode = plan.PromoCode
+	state.Timeouts = plan.Timeouts
 
 	// Set state to fully populated data
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -649,16 +684,21 @@ func (r *lagPortResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order. LAG ports only support immediate cancellation
 	// (CANCEL_NOW); delayed cancellation was removed in megaportgo and the
 	// API now rejects DeleteNow=false for LAG ports.
-	err := retryTransientDelete(ctx, 3, func() error {
-		_, deleteErr := r.client.PortService.DeletePort(ctx, &megaport.DeletePortRequest{
-			PortID:     state.UID.ValueString(),
-			DeleteNow:  true,
-			SafeDelete: true,
-		})
-		return deleteErr
+	_, err := r.client.PortService.DeletePort(ctx, &megaport.DeletePortRequest{
+		PortID:     state.UID.ValueString(),
+		DeleteNow:  true,
+		SafeDelete: true,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -686,11 +726,18 @@ func (r *lagPortResource) Configure(_ context.Context, req resource.ConfigureReq
 	}
 
 	r.client = data.client
+	r.waitTime = data.waitTime
+	r.pollInterval = data.pollInterval
 }
 
 func (r *lagPortResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("product_uid"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("product_uid"), path.Root("product_uid"), req, resp)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *lagPortResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = productIdentitySchema()
 }
 
 func (r *lagPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -714,10 +761,12 @@ func (r *lagPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 	}
 
 	// Only check if we have both state and plan
-	if !state.UID.IsNull() && !plan.LagCount.IsNull() {
+	if !state.UID.IsNull() && !plan.LagCount.IsNull() && !plan.LagCount.IsUnknown() {
 		plannedLagCount := int(plan.LagCount.ValueInt64())
 
-		// We have LagPortUIDs from the API - compare actual count
+		// We have LagPortUIDs from the API - compare actual count. Ports can't
+		// be ordered into or removed from an existing LAG, so a different
+		// count needs a new LAG.
 		if !state.LagPortUIDs.IsNull() {
 			actualLagCount := len(state.LagPortUIDs.Elements())
 
@@ -728,3 +777,16 @@ func (r *lagPortResource) ModifyPlan(ctx context.Context, req resource.ModifyPla
 		}
 	}
 }
+
+// lagPortUIDsFromAPI returns the UIDs of the ports in a LAG, or null if the
+// port is not part of one.
+func lagPortUIDsFromAPI(port *megaport.Port) (types.List, diag.Diagnostics) {
+	if len(port.LagPortUIDs) == 0 {
+		return types.ListNull(types.StringType), nil
+	}
+	lagPortUIDsList := []attr.Value{}
+	for _, uid := range port.LagPortUIDs {
+		lagPortUIDsList = append(lagPortUIDsList, types.StringValue(uid))
+	}
+	return types.ListValue(types.StringType, lagPortUIDsList)
+}
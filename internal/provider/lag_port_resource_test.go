@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -263,3 +264,134 @@ func TestAccMegaportLAGPort_ContractTermUpdate(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitMegaportLAGPort_Basic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				resource "megaport_lag_port" "lag_port" {
+					product_name           = "Unit Test LAG"
+					cost_centre            = "Unit Test"
+					port_speed             = 10000
+					location_id            = %d
+					contract_term_months   = 12
+					marketplace_visibility = true
+					lag_count              = 2
+				}`, fakeLocationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "product_name", "Unit Test LAG"),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "lag_count", "2"),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "lag_port_uids.#", "2"),
+					resource.TestCheckResourceAttrSet("megaport_lag_port.lag_port", "product_uid"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_lag_port.lag_port",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "product_uid",
+				ImportStateIdFunc:                    importStateAttr("megaport_lag_port.lag_port", "product_uid"),
+				ImportStateVerifyIgnore:              []string{"last_updated", "lag_count", "lag_port_uids", "contract_start_date", "contract_end_date", "live_date", "resources", "provisioning_status"},
+			},
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				resource "megaport_lag_port" "lag_port" {
+					product_name           = "Unit Test LAG Renamed"
+					cost_centre            = "Unit Test Updated"
+					port_speed             = 10000
+					location_id            = %d
+					contract_term_months   = 12
+					marketplace_visibility = false
+					lag_count              = 2
+				}`, fakeLocationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "product_name", "Unit Test LAG Renamed"),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "cost_centre", "Unit Test Updated"),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "marketplace_visibility", "false"),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "lag_port_uids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMegaportLAGPort_Members(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	lagConfig := func(lagCount int) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_lag_port" "lag_port" {
+			product_name           = "Unit Test LAG"
+			port_speed             = 10000
+			location_id            = %d
+			contract_term_months   = 12
+			marketplace_visibility = false
+			lag_count              = %d
+		}`, fakeLocationID, lagCount)
+	}
+	cancelled := func(uid string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			api.mu.Lock()
+			defer api.mu.Unlock()
+			if p := api.products[uid]; p == nil || !p.terminated() {
+				return fmt.Errorf("LAG %s is still in service", uid)
+			}
+			return nil
+		}
+	}
+	// Each LAG is followed by its members in the fake API's UIDs: the first
+	// LAG is fakeUID(1) with members up to fakeUID(3), the second fakeUID(4)
+	// with one member, and the third fakeUID(6).
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: lagConfig(3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "product_uid", fakeUID(1)),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "lag_port_uids.#", "3"),
+				),
+			},
+			// Ports cannot be removed from or ordered into an existing LAG, so
+			// changing lag_count in either direction replaces it.
+			{
+				Config: lagConfig(2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("megaport_lag_port.lag_port", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "product_uid", fakeUID(4)),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "lag_port_uids.#", "2"),
+					cancelled(fakeUID(1)),
+				),
+			},
+			{
+				Config: lagConfig(3),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("megaport_lag_port.lag_port", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "product_uid", fakeUID(6)),
+					resource.TestCheckResourceAttr("megaport_lag_port.lag_port", "lag_port_uids.#", "3"),
+					cancelled(fakeUID(4)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listableResource is a managed resource that also implements list and identity.
+type listableResource interface {
+	resource.ResourceWithIdentity
+	list.ListResource
+}
+
+// runList calls List on r with the given filter values and collects the
+// streamed results.
+func runList(t *testing.T, r listableResource, filters map[string]tftypes.Value, includeResource bool, limit int64) []list.ListResult {
+	t.Helper()
+	ctx := context.Background()
+
+	configSchema := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configSchema)
+	require.False(t, configSchema.Diagnostics.HasError())
+
+	configType := configSchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	configValues := map[string]tftypes.Value{}
+	for name, typ := range configType.AttributeTypes {
+		if v, ok := filters[name]; ok {
+			configValues[name] = v
+		} else {
+			configValues[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+
+	resourceSchema := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, resourceSchema)
+	require.False(t, resourceSchema.Diagnostics.HasError())
+
+	identitySchema := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identitySchema)
+	require.False(t, identitySchema.Diagnostics.HasError())
+
+	req := list.ListRequest{
+		Config:                 tfsdk.Config{Schema: configSchema.Schema, Raw: tftypes.NewValue(configType, configValues)},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         resourceSchema.Schema,
+		ResourceIdentitySchema: identitySchema.IdentitySchema,
+	}
+	stream := &list.ListResultsStream{}
+	r.List(ctx, req, stream)
+	require.NotNil(t, stream.Results)
+
+	results := []list.ListResult{}
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	return results
+}
+
+// listResultUIDs returns the identity product UID of each result.
+func listResultUIDs(t *testing.T, results []list.ListResult) []string {
+	t.Helper()
+	uids := []string{}
+	for _, result := range results {
+		require.False(t, result.Diagnostics.HasError(), "unexpected diagnostics: %v", result.Diagnostics)
+		var identity productIdentityModel
+		require.False(t, result.Identity.Get(context.Background(), &identity).HasError())
+		uids = append(uids, identity.ProductUID.ValueString())
+	}
+	return uids
+}
+
+func TestPortListResource_List(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/products" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"ok","data":[
+			{"productUid":"port-1","productName":"Sydney Primary","productType":"MEGAPORT","provisioningStatus":"LIVE","locationId":3},
+			{"productUid":"port-2","productName":"Sydney Secondary","productType":"MEGAPORT","provisioningStatus":"LIVE","locationId":4},
+			{"productUid":"port-3","productName":"Old Port","productType":"MEGAPORT","provisioningStatus":"DECOMMISSIONED","locationId":3},
+			{"productUid":"lag-1","productName":"LAG","productType":"MEGAPORT","provisioningStatus":"LIVE","locationId":3,"lagPrimary":true,"lagId":10},
+			{"productUid":"lag-2","productName":"LAG member","productType":"MEGAPORT","provisioningStatus":"LIVE","locationId":3,"lagId":10},
+			{"productUid":"mcr-1","productName":"Router","productType":"MCR2","provisioningStatus":"LIVE","locationId":3}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := megaport.New(nil,
+		megaport.WithBaseURL(server.URL),
+		megaport.WithAccessToken("test-token", time.Now().Add(time.Hour)),
+	)
+	require.NoError(t, err)
+	r := &portResource{client: client}
+
+	t.Run("all", func(t *testing.T) {
+		results := runList(t, r, nil, false, 0)
+		assert.Equal(t, []string{"port-1", "port-2"}, listResultUIDs(t, results))
+		assert.Equal(t, "Sydney Primary", results[0].DisplayName)
+	})
+
+	t.Run("location filter", func(t *testing.T) {
+		results := runList(t, r, map[string]tftypes.Value{
+			"location_id": tftypes.NewValue(tftypes.Number, 4),
+		}, false, 0)
+		assert.Equal(t, []string{"port-2"}, listResultUIDs(t, results))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		results := runList(t, r, nil, false, 1)
+		assert.Equal(t, []string{"port-1"}, listResultUIDs(t, results))
+	})
+}
+
+func TestMCRListResource_List(t *testing.T) {
+	mock := &MockMCRService{
+		ListMCRsResult: []*megaport.MCR{
+			{UID: "mcr-1", Name: "Prod Router", ProvisioningStatus: "LIVE", LocationID: 3, PortSpeed: 1000},
+			{UID: "mcr-2", Name: "Dev Router", ProvisioningStatus: "LIVE", LocationID: 3, PortSpeed: 1000},
+			{UID: "mcr-3", Name: "Prod Router (old)", ProvisioningStatus: "DECOMMISSIONED", LocationID: 3},
+		},
+		ListMCRResourceTagsResult: map[string]string{"env": "prod"},
+	}
+	r := &mcrResource{client: &megaport.Client{MCRService: mock}}
+
+	results := runList(t, r, map[string]tftypes.Value{
+		"name_contains": tftypes.NewValue(tftypes.String, "prod"),
+	}, true, 0)
+	require.Equal(t, []string{"mcr-1"}, listResultUIDs(t, results))
+
+	ctx := context.Background()
+	var name types.String
+	require.False(t, results[0].Resource.GetAttribute(ctx, path.Root("product_name"), &name).HasError())
+	assert.Equal(t, "Prod Router", name.ValueString())
+
+	var tags types.Map
+	require.False(t, results[0].Resource.GetAttribute(ctx, path.Root("resource_tags"), &tags).HasError())
+	assert.Equal(t, `{"env":"prod"}`, tags.String())
+
+	// Generated configuration should not use the deprecated inline lists.
+	var prefixFilterLists types.List
+	require.False(t, results[0].Resource.GetAttribute(ctx, path.Root("prefix_filter_lists"), &prefixFilterLists).HasError())
+	assert.False(t, prefixFilterLists.IsNull())
+	assert.Empty(t, prefixFilterLists.Elements())
+}
+
+func TestMVEListResource_List(t *testing.T) {
+	mock := &MockMVEService{
+		ListMVEsResult: []*megaport.MVE{
+			{UID: "mve-1", Name: "Edge A", ProvisioningStatus: "LIVE", LocationID: 3, Vendor: "aruba"},
+			{UID: "mve-2", Name: "Edge B", ProvisioningStatus: "CONFIGURED", LocationID: 5, Vendor: "cisco"},
+		},
+		ListMVEResourceTagsErr: errors.New("tags unavailable"),
+	}
+	r := &mveResource{client: &megaport.Client{MVEService: mock}}
+
+	t.Run("identities only", func(t *testing.T) {
+		results := runList(t, r, nil, false, 0)
+		assert.Equal(t, []string{"mve-1", "mve-2"}, listResultUIDs(t, results))
+		assert.True(t, results[0].Resource.Raw.IsNull(), "resource should be left unset when not requested")
+	})
+
+	t.Run("tag errors are warnings", func(t *testing.T) {
+		results := runList(t, r, map[string]tftypes.Value{
+			"location_id": tftypes.NewValue(tftypes.Number, 5),
+		}, true, 0)
+		require.Equal(t, []string{"mve-2"}, listResultUIDs(t, results))
+		require.Len(t, results[0].Diagnostics.Warnings(), 1)
+		assert.Equal(t, "Error reading tags for MVE", results[0].Diagnostics.Warnings()[0].Summary())
+
+		var vendor types.String
+		require.False(t, results[0].Resource.GetAttribute(context.Background(), path.Root("vendor"), &vendor).HasError())
+		assert.Equal(t, "cisco", vendor.ValueString())
+	})
+}
+
+func TestVXCListResource_List(t *testing.T) {
+	mock := &MockVXCService{
+		ListVXCsResult: []*megaport.VXC{
+			{
+				UID: "vxc-1", Name: "Prod VXC", ProvisioningStatus: "LIVE", RateLimit: 500,
+				AEndConfiguration: megaport.VXCEndConfiguration{UID: "port-1", VLAN: 100},
+				BEndConfiguration: megaport.VXCEndConfiguration{UID: "port-2", VLAN: 200},
+			},
+			{UID: "vxc-2", Name: "Cancelled VXC", ProvisioningStatus: "CANCELLED"},
+		},
+	}
+	r := &vxcResource{client: &megaport.Client{VXCService: mock}}
+
+	results := runList(t, r, nil, true, 0)
+	require.Equal(t, []string{"vxc-1"}, listResultUIDs(t, results))
+	assert.Equal(t, "Prod VXC", results[0].DisplayName)
+
+	var rateLimit types.Int64
+	require.False(t, results[0].Resource.GetAttribute(context.Background(), path.Root("rate_limit"), &rateLimit).HasError())
+	assert.Equal(t, int64(500), rateLimit.ValueInt64())
+}
+
+func TestVXCListResource_ListError(t *testing.T) {
+	mock := &MockVXCService{ListVXCsErr: errors.New("api unavailable")}
+	r := &vxcResource{client: &megaport.Client{VXCService: mock}}
+
+	results := runList(t, r, nil, false, 0)
+	require.Len(t, results, 1)
+	require.True(t, results[0].Diagnostics.HasError())
+	assert.Equal(t, "Error listing VXCs", results[0].Diagnostics.Errors()[0].Summary())
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// productListModel maps the list configuration shared by the port, MCR and
+// MVE list resources.
+type productListModel struct {
+	NameContains types.String `tfsdk:"name_contains"`
+	LocationID   types.Int64  `tfsdk:"location_id"`
+}
+
+// productListSchemaAttributes returns the filter attributes shared by the
+// port, MCR and MVE list resources.
+func productListSchemaAttributes(product string) map[string]listschema.Attribute {
+	return map[string]listschema.Attribute{
+		"name_contains": listschema.StringAttribute{
+			Description: "Only list " + product + "s whose name contains this value (case-insensitive).",
+			Optional:    true,
+		},
+		"location_id": listschema.Int64Attribute{
+			Description: "Only list " + product + "s at this location ID.",
+			Optional:    true,
+		},
+	}
+}
+
+// matches reports whether a product passes the configured filters.
+func (m productListModel) matches(name string, locationID int) bool {
+	if !nameContains(name, m.NameContains) {
+		return false
+	}
+	if !m.LocationID.IsNull() && int64(locationID) != m.LocationID.ValueInt64() {
+		return false
+	}
+	return true
+}
+
+// nameContains reports whether name contains the filter value, ignoring case.
+// A null filter matches every name.
+func nameContains(name string, filter types.String) bool {
+	if filter.IsNull() {
+		return true
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(filter.ValueString()))
+}
+
+// isInactiveProduct reports whether a product has been cancelled or
+// decommissioned and so should not be offered for import.
+func isInactiveProduct(provisioningStatus string) bool {
+	return provisioningStatus == megaport.STATUS_DECOMMISSIONED || provisioningStatus == megaport.STATUS_CANCELLED
+}
+
+// listLimitReached reports whether count results satisfy the request limit.
+func listLimitReached(req list.ListRequest, count int64) bool {
+	return req.Limit > 0 && count >= req.Limit
+}
+
+// newListResultModel populates target with a null value for every attribute of
+// the resource schema, as import does before the first read, so the resource's
+// fromAPI helpers can fill it in for config generation.
+func newListResultModel(ctx context.Context, req list.ListRequest, target any) diag.Diagnostics {
+	objType, ok := req.ResourceSchema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		var diags diag.Diagnostics
+		diags.AddError(
+			"Unexpected Resource Schema Type",
+			"The resource schema is not an object. Please report this issue to the provider developers.",
+		)
+		return diags
+	}
+
+	attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, typ := range objType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(typ, nil)
+	}
+
+	state := tfsdk.State{
+		Schema: req.ResourceSchema,
+		Raw:    tftypes.NewValue(objType, attrs),
+	}
+	return state.Get(ctx, target)
+}
@@ -25,6 +25,7 @@ var (
 	_ resource.Resource                = &mcrIpsecAddonResource{}
 	_ resource.ResourceWithConfigure   = &mcrIpsecAddonResource{}
 	_ resource.ResourceWithImportState = &mcrIpsecAddonResource{}
+	_ resource.ResourceWithIdentity    = &mcrIpsecAddonResource{}
 )
 
 // NewMCRIpsecAddonResource is a helper function to simplify the provider implementation.
@@ -34,7 +35,8 @@ func NewMCRIpsecAddonResource() resource.Resource {
 
 // mcrIpsecAddonResource defines the resource implementation.
 type mcrIpsecAddonResource struct {
-	client *megaport.Client
+	client   *megaport.Client
+	waitTime time.Duration
 }
 
 // mcrIpsecAddonResourceModel maps the resource schema data.
@@ -95,6 +97,7 @@ func (r *mcrIpsecAddonResource) Configure(_ context.Context, req resource.Config
 	}
 
 	r.client = client.client
+	r.waitTime = client.waitTime
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -121,7 +124,7 @@ func (r *mcrIpsecAddonResource) Create(ctx context.Context, req resource.CreateR
 			TunnelCount: tunnelCount,
 		},
 		WaitForProvision: true,
-		WaitForTime:      waitForTime,
+		WaitForTime:      r.waitTime,
 	}
 
 	if err := r.client.MCRService.UpdateMCRWithAddOn(ctx, mcrID, addOnReq); err != nil {
@@ -156,6 +159,7 @@ func (r *mcrIpsecAddonResource) Create(ctx context.Context, req resource.CreateR
 	plan.TunnelCount = types.Int64Value(int64(addOn.TunnelCount))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, plan.identity())...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -199,6 +203,7 @@ func (r *mcrIpsecAddonResource) Read(ctx context.Context, req resource.ReadReque
 	state.TunnelCount = types.Int64Value(int64(addOn.TunnelCount))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -231,7 +236,7 @@ func (r *mcrIpsecAddonResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	// Wait for the MCR to reach a ready state
-	if err := r.client.MCRService.WaitForMCRReady(ctx, mcrID, waitForTime); err != nil {
+	if err := r.client.MCRService.WaitForMCRReady(ctx, mcrID, r.waitTime); err != nil {
 		resp.Diagnostics.AddError(
 			"Error waiting for MCR to be ready",
 			fmt.Sprintf("MCR %s did not reach a ready state after updating IPSec add-on: %s", mcrID, err.Error()),
@@ -261,6 +266,7 @@ func (r *mcrIpsecAddonResource) Update(ctx context.Context, req resource.UpdateR
 	state.TunnelCount = types.Int64Value(int64(addOn.TunnelCount))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -299,7 +305,7 @@ func (r *mcrIpsecAddonResource) Delete(ctx context.Context, req resource.DeleteR
 
 	// Wait for the MCR to return to a ready state so follow-on operations
 	// (e.g., deleting the MCR itself in the same destroy) don't race.
-	if err := r.client.MCRService.WaitForMCRReady(ctx, mcrID, waitForTime); err != nil {
+	if err := r.client.MCRService.WaitForMCRReady(ctx, mcrID, r.waitTime); err != nil {
 		if errors.Is(err, megaport.ErrMCRNotFound) || errors.Is(err, megaport.ErrMCRDecommissioned) {
 			return
 		}
@@ -312,9 +318,9 @@ func (r *mcrIpsecAddonResource) Delete(ctx context.Context, req resource.DeleteR
 
 // deleteAddOnAwaitingTunnels disables the add-on (tunnel count 0), retrying
 // while the API still reports tunnels configured on the MCR. It gives up after
-// waitForTime and returns the last error for the caller to surface.
+// the provider wait_time and returns the last error for the caller to surface.
 func (r *mcrIpsecAddonResource) deleteAddOnAwaitingTunnels(ctx context.Context, mcrID, addOnUID string) error {
-	deadline := time.Now().Add(waitForTime)
+	deadline := time.Now().Add(r.waitTime)
 	for {
 		err := r.client.MCRService.UpdateMCRIPsecAddOn(ctx, mcrID, addOnUID, 0)
 		if err == nil || !isIPsecTunnelsConfiguredError(err) || !time.Now().Before(deadline) {
@@ -347,14 +353,26 @@ func isIPsecTunnelsConfiguredError(err error) bool {
 
 // ImportState imports the resource state.
 func (r *mcrIpsecAddonResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Parse the import ID (format: mcr_uid:add_on_uid)
-	mcrUID, addOnUID, err := parseImportIDStrings(req.ID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			fmt.Sprintf("Error parsing import ID: %s\n\nExpected format: mcr_uid:add_on_uid\nExample: 12345678-1234-1234-1234-123456789012:abcdef12-3456-7890-abcd-ef1234567890", err.Error()),
-		)
-		return
+	var mcrUID, addOnUID string
+	if req.ID == "" {
+		// Import by identity
+		var identity mcrIPsecAddOnIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		mcrUID, addOnUID = identity.MCRUID.ValueString(), identity.AddOnUID.ValueString()
+	} else {
+		// Parse the import ID (format: mcr_uid:add_on_uid)
+		var err error
+		mcrUID, addOnUID, err = parseImportIDStrings(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				fmt.Sprintf("Error parsing import ID: %s\n\nExpected format: mcr_uid:add_on_uid\nExample: 12345678-1234-1234-1234-123456789012:abcdef12-3456-7890-abcd-ef1234567890", err.Error()),
+			)
+			return
+		}
 	}
 
 	// Set the parsed values in the state
@@ -393,6 +411,16 @@ func (r *mcrIpsecAddonResource) ImportState(ctx context.Context, req resource.Im
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tunnel_count"), int64(addOn.TunnelCount))...)
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *mcrIpsecAddonResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = mcrIPsecAddOnIdentitySchema()
+}
+
+// identity returns the resource identity of the add-on.
+func (m *mcrIpsecAddonResourceModel) identity() mcrIPsecAddOnIdentityModel {
+	return mcrIPsecAddOnIdentityModel{MCRUID: m.MCRID, AddOnUID: m.AddOnUID}
+}
+
 // findIPsecAddOn finds an IPSec add-on in the MCR's add-ons list.
 // If addOnUID is empty, it returns the first IPSec add-on found.
 // If addOnUID is provided, it returns the add-on with that specific UID.
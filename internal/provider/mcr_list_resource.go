@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &mcrResource{}
+	_ list.ListResourceWithConfigure = &mcrResource{}
+)
+
+// NewMCRListResource is a helper function to simplify the provider implementation.
+func NewMCRListResource() list.ListResource {
+	return &mcrResource{}
+}
+
+// ListResourceConfigSchema defines the filters accepted by `terraform query`.
+func (r *mcrResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the active MCRs in the account so they can be imported. Generated configuration leaves prefix filter lists to `megaport_mcr_prefix_filter_list`.",
+		Attributes:  productListSchemaAttributes("MCR"),
+	}
+}
+
+// List streams the active MCRs in the account.
+func (r *mcrResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config productListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	mcrs, err := r.client.MCRService.ListMCRs(ctx, &megaport.ListMCRsRequest{
+		IncludeInactive: false,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error listing MCRs",
+			"Could not list MCRs: "+err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, mcr := range mcrs {
+			if listLimitReached(req, count) {
+				return
+			}
+			if isInactiveProduct(mcr.ProvisioningStatus) || !config.matches(mcr.Name, mcr.LocationID) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = mcr.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, productIdentityModel{ProductUID: types.StringValue(mcr.UID)})...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				var state mcrResourceModel
+				result.Diagnostics.Append(newListResultModel(ctx, req, &state)...)
+
+				tags, err := r.client.MCRService.ListMCRResourceTags(ctx, mcr.UID)
+				if err != nil {
+					result.Diagnostics.AddWarning(
+						"Error reading resource tags",
+						fmt.Sprintf("Could not read resource tags for MCR with ID %s: %s", mcr.UID, err.Error()),
+					)
+				}
+
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(state.fromAPIMCR(ctx, mcr, tags)...)
+					// An empty list leaves the MCR's prefix filter lists to the
+					// standalone resource rather than the deprecated inline form.
+					state.PrefixFilterLists = types.ListValueMust(types.ObjectType{}.WithAttributeTypes(mcrPrefixFilterListModelAttributes), nil)
+					result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+				}
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
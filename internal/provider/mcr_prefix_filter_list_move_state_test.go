@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const megaportProviderAddress = "registry.terraform.io/megaport/megaport"
+
+// moveToPrefixFilterList runs the MoveResourceState RPC for a move into
+// megaport_mcr_prefix_filter_list from the given source.
+func moveToPrefixFilterList(t *testing.T, sourceType, rawJSON string) *tfprotov6.MoveResourceStateResponse {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	require.NoError(t, err)
+
+	resp, err := server.MoveResourceState(context.Background(), &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: megaportProviderAddress,
+		SourceTypeName:        sourceType,
+		SourceSchemaVersion:   1,
+		SourceState:           &tfprotov6.RawState{JSON: []byte(rawJSON)},
+		TargetTypeName:        "megaport_mcr_prefix_filter_list",
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func diagnosticSummaries(diags []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity) []string {
+	summaries := []string{}
+	for _, d := range diags {
+		if d.Severity == severity {
+			summaries = append(summaries, d.Summary)
+		}
+	}
+	return summaries
+}
+
+func TestMCRPrefixFilterListMoveState_SingleInlineList(t *testing.T) {
+	resp := moveToPrefixFilterList(t, "megaport_mcr", `{
+		"product_uid": "11111111-1111-1111-1111-111111111111",
+		"product_name": "Test MCR",
+		"prefix_filter_lists": [
+			{
+				"id": 123,
+				"description": "Inbound",
+				"address_family": "IPv4",
+				"entries": [
+					{"action": "permit", "prefix": "10.0.0.0/8", "ge": 16, "le": 24},
+					{"action": "deny", "prefix": "192.168.0.0/16", "ge": null, "le": null}
+				]
+			}
+		]
+	}`)
+	assert.Empty(t, diagnosticSummaries(resp.Diagnostics, tfprotov6.DiagnosticSeverityError))
+	assert.Equal(t, []string{"MCR removed from state"}, diagnosticSummaries(resp.Diagnostics, tfprotov6.DiagnosticSeverityWarning))
+	require.NotNil(t, resp.TargetState)
+
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewMCRPrefixFilterListResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	raw, err := resp.TargetState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	require.NoError(t, err)
+
+	var state mcrPrefixFilterListResourceModel
+	require.False(t, tfsdk.State{Schema: schemaResp.Schema, Raw: raw}.Get(ctx, &state).HasError())
+
+	assert.Equal(t, int64(123), state.ID.ValueInt64())
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", state.MCRID.ValueString())
+	assert.Equal(t, "Inbound", state.Description.ValueString())
+	assert.Equal(t, "IPv4", state.AddressFamily.ValueString())
+	assert.False(t, state.LastUpdated.IsNull())
+
+	entries := []*mcrPrefixFilterListEntryResourceModel{}
+	require.False(t, state.Entries.ElementsAs(ctx, &entries, false).HasError())
+	require.Len(t, entries, 2)
+	assert.Equal(t, "permit", entries[0].Action.ValueString())
+	assert.Equal(t, "10.0.0.0/8", entries[0].Prefix.ValueString())
+	assert.Equal(t, int64(16), entries[0].Ge.ValueInt64())
+	assert.Equal(t, int64(24), entries[0].Le.ValueInt64())
+	assert.Equal(t, "deny", entries[1].Action.ValueString())
+	assert.True(t, entries[1].Ge.IsNull())
+}
+
+func TestMCRPrefixFilterListMoveState_MultipleInlineLists(t *testing.T) {
+	resp := moveToPrefixFilterList(t, "megaport_mcr", `{
+		"product_uid": "11111111-1111-1111-1111-111111111111",
+		"prefix_filter_lists": [
+			{"id": 123, "description": "Inbound", "address_family": "IPv4", "entries": []},
+			{"id": 456, "description": "Outbound", "address_family": "IPv4", "entries": []}
+		]
+	}`)
+	require.Equal(t, []string{"Multiple inline prefix filter lists"}, diagnosticSummaries(resp.Diagnostics, tfprotov6.DiagnosticSeverityError))
+	assert.Contains(t, resp.Diagnostics[0].Detail, "11111111-1111-1111-1111-111111111111:123, 11111111-1111-1111-1111-111111111111:456")
+	assert.Nil(t, resp.TargetState)
+}
+
+func TestMCRPrefixFilterListMoveState_NoInlineLists(t *testing.T) {
+	for name, lists := range map[string]string{"null": "null", "empty": "[]"} {
+		t.Run(name, func(t *testing.T) {
+			resp := moveToPrefixFilterList(t, "megaport_mcr", `{
+				"product_uid": "11111111-1111-1111-1111-111111111111",
+				"prefix_filter_lists": `+lists+`
+			}`)
+			assert.Equal(t, []string{"No inline prefix filter list to move"}, diagnosticSummaries(resp.Diagnostics, tfprotov6.DiagnosticSeverityError))
+		})
+	}
+}
+
+func TestMCRPrefixFilterListMoveState_UnsupportedSource(t *testing.T) {
+	resp := moveToPrefixFilterList(t, "megaport_port", `{"product_uid": "11111111-1111-1111-1111-111111111111"}`)
+	assert.Equal(t, []string{"Unable to Move Resource State"}, diagnosticSummaries(resp.Diagnostics, tfprotov6.DiagnosticSeverityError))
+}
+
+func TestMCRPrefixFilterListMoveState_ReadsMCRSchema(t *testing.T) {
+	movers := (&mcrPrefixFilterListResource{}).MoveState(context.Background())
+	require.Len(t, movers, 1)
+	require.NotNil(t, movers[0].SourceSchema)
+
+	_, diags := movers[0].SourceSchema.AttributeAtPath(context.Background(), path.Root("prefix_filter_lists"))
+	assert.False(t, diags.HasError())
+}
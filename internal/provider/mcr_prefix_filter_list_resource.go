@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -20,6 +21,8 @@ var (
 	_ resource.Resource                = &mcrPrefixFilterListResource{}
 	_ resource.ResourceWithConfigure   = &mcrPrefixFilterListResource{}
 	_ resource.ResourceWithImportState = &mcrPrefixFilterListResource{}
+	_ resource.ResourceWithMoveState   = &mcrPrefixFilterListResource{}
+	_ resource.ResourceWithIdentity    = &mcrPrefixFilterListResource{}
 )
 
 // NewMCRPrefixFilterListResource is a helper function to simplify the provider implementation.
@@ -129,6 +132,7 @@ func (r *mcrPrefixFilterListResource) Create(ctx context.Context, req resource.C
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -181,6 +185,7 @@ func (r *mcrPrefixFilterListResource) Read(ctx context.Context, req resource.Rea
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -247,6 +252,7 @@ func (r *mcrPrefixFilterListResource) Update(ctx context.Context, req resource.U
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.identity())...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -296,14 +302,27 @@ func (r *mcrPrefixFilterListResource) Delete(ctx context.Context, req resource.D
 
 // ImportState imports the resource state.
 func (r *mcrPrefixFilterListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Parse the import ID (format: mcr_uid:prefix_list_id)
-	mcrUID, prefixListID, err := parseImportID(req.ID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			fmt.Sprintf("Error parsing import ID: %s\n\nExpected format: mcr_uid:prefix_list_id\nExample: 12345678-1234-1234-1234-123456789012:123", err.Error()),
-		)
-		return
+	var mcrUID string
+	var prefixListID int64
+	if req.ID == "" {
+		// Import by identity
+		var identity mcrPrefixFilterListIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		mcrUID, prefixListID = identity.MCRUID.ValueString(), identity.PrefixListID.ValueInt64()
+	} else {
+		// Parse the import ID (format: mcr_uid:prefix_list_id)
+		var err error
+		mcrUID, prefixListID, err = parseImportID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid import ID",
+				fmt.Sprintf("Error parsing import ID: %s\n\nExpected format: mcr_uid:prefix_list_id\nExample: 12345678-1234-1234-1234-123456789012:123", err.Error()),
+			)
+			return
+		}
 	}
 
 	// Set the parsed values in the state
@@ -347,6 +366,112 @@ func (r *mcrPrefixFilterListResource) ImportState(ctx context.Context, req resou
 
 	// Save the imported state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, state.identity())...)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *mcrPrefixFilterListResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = mcrPrefixFilterListIdentitySchema()
+}
+
+// MoveState allows an inline prefix filter list held in megaport_mcr state to be
+// moved into a standalone megaport_mcr_prefix_filter_list with a moved block.
+func (r *mcrPrefixFilterListResource) MoveState(ctx context.Context) []resource.StateMover {
+	mcrSchema := &resource.SchemaResponse{}
+	NewMCRResource().Schema(ctx, resource.SchemaRequest{}, mcrSchema)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &mcrSchema.Schema,
+			StateMover:   r.moveStateFromMCR,
+		},
+	}
+}
+
+// moveStateFromMCR builds the prefix filter list state from the inline
+// prefix_filter_lists of a megaport_mcr. A moved block maps one source to one
+// target, so only an MCR holding a single inline list can be moved; lists on
+// MCRs with several must be imported instead.
+func (r *mcrPrefixFilterListResource) moveStateFromMCR(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "megaport_mcr" || !strings.HasSuffix(req.SourceProviderAddress, "megaport/megaport") {
+		return
+	}
+
+	if req.SourceState == nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			"Could not read the megaport_mcr source state. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	var mcrUID types.String
+	var inlineLists types.List
+	resp.Diagnostics.Append(req.SourceState.GetAttribute(ctx, path.Root("product_uid"), &mcrUID)...)
+	resp.Diagnostics.Append(req.SourceState.GetAttribute(ctx, path.Root("prefix_filter_lists"), &inlineLists)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inlineModels := []*mcrPrefixFilterListModel{}
+	if !inlineLists.IsNull() && !inlineLists.IsUnknown() {
+		resp.Diagnostics.Append(inlineLists.ElementsAs(ctx, &inlineModels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	switch len(inlineModels) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"No inline prefix filter list to move",
+			fmt.Sprintf("MCR %s has no inline prefix_filter_lists in state, so there is nothing to move into megaport_mcr_prefix_filter_list.", mcrUID.ValueString()),
+		)
+		return
+	case 1:
+	default:
+		importIDs := make([]string, 0, len(inlineModels))
+		for _, l := range inlineModels {
+			importIDs = append(importIDs, generateImportID(mcrUID.ValueString(), l.ID.ValueInt64()))
+		}
+		resp.Diagnostics.AddError(
+			"Multiple inline prefix filter lists",
+			fmt.Sprintf("MCR %s has %d inline prefix filter lists, but a moved block can only move one of them. "+
+				"Adopt each list with an import block instead, using these import IDs: %s",
+				mcrUID.ValueString(), len(inlineModels), strings.Join(importIDs, ", ")),
+		)
+		return
+	}
+
+	inline := inlineModels[0]
+	importID := generateImportID(mcrUID.ValueString(), inline.ID.ValueInt64())
+	tflog.Debug(ctx, "Moving inline prefix filter list from megaport_mcr", map[string]interface{}{
+		"import_id": importID,
+	})
+
+	// The inline and standalone entry objects share the same attributes, so the
+	// entries carry over as-is; the next refresh normalises ge/le as usual.
+	state := mcrPrefixFilterListResourceModel{
+		ID:            inline.ID,
+		MCRID:         mcrUID,
+		Description:   inline.Description,
+		AddressFamily: inline.AddressFamily,
+		Entries:       inline.Entries,
+		LastUpdated:   types.StringValue(time.Now().Format(time.RFC850)),
+	}
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.TargetIdentity, state.identity())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"MCR removed from state",
+		fmt.Sprintf("Prefix filter list %s was moved out of megaport_mcr, which is no longer tracked at its previous address. "+
+			"Adopt the MCR again with an import block using ID %s and set `lifecycle { ignore_changes = [prefix_filter_lists] }` on it.",
+			importID, mcrUID.ValueString()),
+	)
 }
 
 // validatePrefixListEntry validates a single prefix list entry
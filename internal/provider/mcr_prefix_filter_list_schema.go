@@ -28,7 +28,9 @@ func mcrPrefixFilterListResourceSchema() schema.Schema {
 	return schema.Schema{
 		Description: "MCR Prefix Filter List Resource for the Megaport Terraform Provider. " +
 			"This resource manages individual prefix filter lists for MCR instances, " +
-			"providing better resource management compared to inline prefix_filter_lists.",
+			"providing better resource management compared to inline prefix_filter_lists. " +
+			"An inline list can be moved from a `megaport_mcr` with a `moved` block only when the MCR holds exactly one inline list, " +
+			"and the MCR must then be imported again; lists on MCRs with several inline lists must be imported instead.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Numeric ID of the prefix filter list.",
@@ -61,6 +61,11 @@ func (m *mcrPrefixFilterListResourceModel) planToAPI(ctx context.Context) (*mega
 	return apiList, diags
 }
 
+// identity returns the resource identity of the prefix filter list.
+func (m *mcrPrefixFilterListResourceModel) identity() mcrPrefixFilterListIdentityModel {
+	return mcrPrefixFilterListIdentityModel{MCRUID: m.MCRID, PrefixListID: m.ID}
+}
+
 // fromAPI converts the API response to the Terraform model
 // This version does NOT apply exact match normalization - returns raw API values
 // Use this for import scenarios where we don't have prior configuration to compare
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -34,6 +35,8 @@ var (
 	_ resource.Resource                = &mcrResource{}
 	_ resource.ResourceWithConfigure   = &mcrResource{}
 	_ resource.ResourceWithImportState = &mcrResource{}
+	_ resource.ResourceWithIdentity    = &mcrResource{}
+	_ resource.ResourceWithModifyPlan  = &mcrResource{}
 
 	mcrPrefixFilterListModelAttributes = map[string]attr.Type{
 		"id":             types.Int64Type,
@@ -94,6 +97,8 @@ type mcrResourceModel struct {
 	PrefixFilterLists types.List `tfsdk:"prefix_filter_lists"`
 
 	ResourceTags types.Map `tfsdk:"resource_tags"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // mcrPrefixFilterListModel represents the prefix filter list associated with the MCR
@@ -112,41 +117,6 @@ type mcrPrefixListEntryModel struct {
 	Le     types.Int64  `tfsdk:"le"`
 }
 
-type RateLimiter struct {
-	rateLimitCh chan struct{}
-	burstSize   int
-	refillSpeed time.Duration
-}
-
-func NewRateLimiter(burstSize int, refillSpeed time.Duration) *RateLimiter {
-	rl := &RateLimiter{
-		rateLimitCh: make(chan struct{}, burstSize),
-		burstSize:   burstSize,
-		refillSpeed: refillSpeed,
-	}
-
-	// Fill initial tokens
-	for i := 0; i < burstSize; i++ {
-		rl.rateLimitCh <- struct{}{}
-	}
-
-	// Refill tokens at a fixed interval
-	go func() {
-		ticker := time.NewTicker(refillSpeed)
-		defer ticker.Stop()
-		for range ticker.C {
-			select {
-			case rl.rateLimitCh <- struct{}{}:
-				// Token added
-			default:
-				// Bucket full, skip
-			}
-		}
-	}()
-
-	return rl
-}
-
 // fromAPIMCR maps the API MCR response to the resource schema.
 func (orm *mcrResourceModel) fromAPIMCR(ctx context.Context, m *megaport.MCR, tags map[string]string) diag.Diagnostics {
 	apiDiags := diag.Diagnostics{}
@@ -218,6 +188,8 @@ func (orm *mcrResourceModel) fromAPIMCR(ctx context.Context, m *megaport.MCR, ta
 		tags, tagDiags := types.MapValue(types.StringType, attributeTags)
 		apiDiags = append(apiDiags, tagDiags...)
 		orm.AttributeTags = tags
+	} else {
+		orm.AttributeTags = types.MapNull(types.StringType)
 	}
 
 	if len(tags) > 0 {
@@ -354,7 +326,9 @@ func (r *mcrResource) validatePrefixFilterListUsage(ctx context.Context, mcrUID
 
 // mcrResource is the resource implementation.
 type mcrResource struct {
-	client *megaport.Client
+	client       *megaport.Client
+	waitTime     time.Duration
+	pollInterval time.Duration
 }
 
 // Metadata returns the resource type name.
@@ -363,7 +337,7 @@ func (r *mcrResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *mcrResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *mcrResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Megaport Cloud Router (MCR) Resource for the Megaport Terraform Provider. This can be used to create, modify, and delete Megaport MCRs. The MCR is a managed virtual router service that establishes Layer 3 connectivity on the worldwide Megaport software-defined network (SDN). MCR instances are preconfigured in data centers in key global routing zones. An MCR enables data transfer between multi-cloud or hybrid cloud networks, network service providers, and cloud service providers.",
 		Attributes: map[string]schema.Attribute{
@@ -431,7 +405,7 @@ func (r *mcrResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				},
 			},
 			"port_speed": schema.Int64Attribute{
-				Description: "Bandwidth speed of the product. The MCR can scale from 1 Gbps to 100 Gbps. The rate limit is an aggregate capacity that determines the speed for all connections through the MCR. MCR bandwidth is shared between all the Cloud Service Provider (CSP) connections added to it. The rate limit is fixed for the life of the service. MCR2 supports seven speeds: 1000, 2500, 5000, 10000, 25000, 50000, and 100000 MBPS",
+				Description: "Bandwidth speed of the product. The MCR can scale from 1 Gbps to 100 Gbps. The rate limit is an aggregate capacity that determines the speed for all connections through the MCR. MCR bandwidth is shared between all the Cloud Service Provider (CSP) connections added to it. The rate limit is fixed for the life of the service. MCR2 supports seven speeds: 1000, 2500, 5000, 10000, 25000, 50000, and 100000 MBPS. Changing it replaces the MCR, and the new speed is checked against the capacity of the MCR's location at plan time.",
 				Required:    true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
@@ -661,6 +635,9 @@ func (r *mcrResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -674,15 +651,19 @@ func (r *mcrResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	buyReq := &megaport.BuyMCRRequest{
-		Name:             plan.Name.ValueString(),
-		Term:             int(plan.ContractTermMonths.ValueInt64()),
-		PortSpeed:        int(plan.PortSpeed.ValueInt64()),
-		LocationID:       int(plan.LocationID.ValueInt64()),
-		CostCentre:       plan.CostCentre.ValueString(),
-		PromoCode:        plan.PromoCode.ValueString(),
-		WaitForProvision: true,
-		WaitForTime:      waitForTime,
+		Name:       plan.Name.ValueString(),
+		Term:       int(plan.ContractTermMonths.ValueInt64()),
+		PortSpeed:  int(plan.PortSpeed.ValueInt64()),
+		LocationID: int(plan.LocationID.ValueInt64()),
+		CostCentre: plan.CostCentre.ValueString(),
+		PromoCode:  plan.PromoCode.ValueString(),
 	}
 
 	if !plan.ASN.IsUnknown() {
@@ -722,6 +703,15 @@ func (r *mcrResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	createdID := createdMCR.TechnicalServiceUID
 
+	// Wait for the MCR to be provisioned
+	if err := waitForProductReady(ctx, "MCR", []string{createdID}, createTimeout, r.pollInterval, mcrStatus(r.client)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating mcr",
+			"Could not mcr with name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
 	// get the created MCR
 	mcr, err := r.client.MCRService.GetMCR(ctx, createdID)
 	if err != nil {
@@ -795,6 +785,7 @@ func (r *mcrResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -810,6 +801,14 @@ func (r *mcrResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed mcr value from API
 	mcr, err := r.client.MCRService.GetMCR(ctx, state.UID.ValueString())
 	if err != nil {
@@ -873,15 +872,8 @@ func (r *mcrResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		mux := sync.Mutex{}
 		errs := []error{}
 
-		// Create a RateLimiter with a burst size of 10 and a refill speed of 100 milliseconds
-		rateLimiter := NewRateLimiter(10, 1000*time.Millisecond)
-
 		for _, l := range prefixFilterLists {
 			wg.Go(func() {
-				// Get a token from the rate limiter to apply rate limiting
-
-				<-rateLimiter.rateLimitCh
-
 				detailedList, err := r.client.MCRService.GetMCRPrefixFilterList(ctx, state.UID.ValueString(), l.Id)
 				if err != nil {
 					mux.Lock()
@@ -940,6 +932,7 @@ func (r *mcrResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -955,6 +948,12 @@ func (r *mcrResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Check on changes
 	var name, costCentre string
 	var marketplaceVisibility bool
@@ -992,9 +991,10 @@ func (r *mcrResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		ContractTermMonths:    contractTermMonths,
 		CostCentre:            costCentre,
 		MCRAsn:                mcrAsn,
-		WaitForUpdate:         true,
-		WaitForTime:           waitForTime,
 	})
+	if err == nil {
+		err = waitForProductReady(ctx, "MCR", []string{plan.UID.ValueString()}, updateTimeout, r.pollInterval, mcrStatus(r.client))
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -1070,13 +1070,8 @@ func (r *mcrResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	mux := sync.Mutex{}
 	errs := []error{}
 
-	rateLimiter := NewRateLimiter(10, 1000*time.Millisecond)
-
 	for _, planModel := range planPrefixFilterLists {
 		wg.Go(func() {
-			// Get a token from the rate limiter to apply rate limiting
-			<-rateLimiter.rateLimitCh
-
 			// Check if the prefix filter list exists in the state
 			if statePrefixFilterList, ok := statePrefixFilterListMap[planModel.ID.ValueInt64()]; ok {
 				// Check if there are any changes to the prefix filter list, if so, update.
@@ -1124,12 +1119,8 @@ func (r *mcrResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// map is also empty, so every state list is deleted — this handles explicit
 	// clear (prefix_filter_lists = []). For standalone-resource users,
 	// statePrefixFilterLists is already empty so the loop is a no-op.
-	deleteRateLimiter := NewRateLimiter(10, 1000*time.Millisecond)
-
 	for _, stateModel := range statePrefixFilterLists {
 		wg.Go(func() {
-			<-deleteRateLimiter.rateLimitCh
-
 			// If the prefix filter list does not exist in the plan, delete it.
 			if _, ok := planPrefixFilterListMap[stateModel.ID.ValueInt64()]; !ok {
 				_, deleteErr := r.client.MCRService.DeleteMCRPrefixFilterList(ctx, state.UID.ValueString(), int(stateModel.ID.ValueInt64()))
@@ -1180,8 +1171,6 @@ func (r *mcrResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 		for _, l := range prefixFilterLists {
 			wg.Go(func() {
-				<-rateLimiter.rateLimitCh
-
 				detailedList, err := r.client.MCRService.GetMCRPrefixFilterList(ctx, state.UID.ValueString(), l.Id)
 				if err != nil {
 					mux.Lock()
@@ -1245,14 +1234,36 @@ func (r *mcrResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// Update the state with the new values
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	state.PromoCode = plan.PromoCode
+	state.Timeouts = plan.Timeouts
 
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// ModifyPlan checks a port_speed change against the MCR speeds offered at the
+// MCR's location, so that a replacement the location cannot take is reported
+// by plan.
+func (r *mcrResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan, state mcrResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.PortSpeed.IsUnknown() || plan.PortSpeed.Equal(state.PortSpeed) || !plan.LocationID.Equal(state.LocationID) {
+		return
+	}
+	resp.Diagnostics.Append(validateSpeedAtLocation(ctx, r.client, "MCR", int(state.LocationID.ValueInt64()), state.DiversityZone.ValueString(), int(plan.PortSpeed.ValueInt64()),
+		func(zone *megaport.LocationV3DiversityZone) []int { return zone.McrSpeedMbps })...)
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *mcrResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	// Retrieve values from state
@@ -1263,14 +1274,19 @@ func (r *mcrResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
-	err := retryTransientDelete(ctx, 3, func() error {
-		_, deleteErr := r.client.MCRService.DeleteMCR(ctx, &megaport.DeleteMCRRequest{
-			MCRID:      state.UID.ValueString(),
-			DeleteNow:  true,
-			SafeDelete: true,
-		})
-		return deleteErr
+	_, err := r.client.MCRService.DeleteMCR(ctx, &megaport.DeleteMCRRequest{
+		MCRID:      state.UID.ValueString(),
+		DeleteNow:  true,
+		SafeDelete: true,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -1300,9 +1316,16 @@ func (r *mcrResource) Configure(_ context.Context, req resource.ConfigureRequest
 	client := data.client
 
 	r.client = client
+	r.waitTime = data.waitTime
+	r.pollInterval = data.pollInterval
 }
 
 func (r *mcrResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("product_uid"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("product_uid"), path.Root("product_uid"), req, resp)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *mcrResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = productIdentitySchema()
 }
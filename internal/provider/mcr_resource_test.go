@@ -1,14 +1,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
-	"sync"
-	"sync/atomic"
+	"net/http"
+	"regexp"
 	"testing"
-	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccMegaportMCR_Basic(t *testing.T) {
@@ -600,91 +605,6 @@ func TestAccMegaportMCRCustomASN_Basic(t *testing.T) {
 	})
 }
 
-func TestRateLimiter_SingleToken(t *testing.T) {
-	rl := NewRateLimiter(5, 100*time.Millisecond)
-
-	select {
-	case <-rl.rateLimitCh:
-		// Successfully got token
-	default:
-		t.Error("Failed to get first token")
-	}
-}
-
-func TestRateLimiter_BurstLimit(t *testing.T) {
-	rl := NewRateLimiter(5, 100*time.Millisecond)
-
-	// Should get 5 tokens
-	for i := 0; i < 5; i++ {
-		select {
-		case <-rl.rateLimitCh:
-			// Successfully got token
-		default:
-			t.Errorf("Failed to get token %d within burst limit", i+1)
-		}
-	}
-
-	// Should fail to get 6th token
-	select {
-	case <-rl.rateLimitCh:
-		t.Error("Got token beyond burst limit")
-	default:
-		// Expected failure to get token
-	}
-}
-
-func TestRateLimiter_Refill(t *testing.T) {
-	rl := NewRateLimiter(5, 100*time.Millisecond)
-
-	// Use all tokens
-	for i := 0; i < 5; i++ {
-		select {
-		case <-rl.rateLimitCh:
-			// Token consumed
-		default:
-			t.Errorf("Failed to get token %d from initial burst", i)
-		}
-	}
-
-	// Wait for refill
-	time.Sleep(150 * time.Millisecond)
-
-	// Should get a token after refill
-	select {
-	case <-rl.rateLimitCh:
-		// Successfully got token after refill
-	default:
-		t.Error("Failed to get token after refill")
-	}
-}
-
-func TestRateLimiter_Concurrent(t *testing.T) {
-	rl := NewRateLimiter(10, 100*time.Millisecond)
-	var wg sync.WaitGroup
-	successCount := int32(0)
-
-	// Launch 20 goroutines
-	for i := 0; i < 20; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			select {
-			case <-rl.rateLimitCh:
-				atomic.AddInt32(&successCount, 1)
-			default:
-				// Failed to get token
-			}
-		}()
-	}
-
-	wg.Wait()
-
-	// Should have exactly 10 successes (burst limit)
-	if atomic.LoadInt32(&successCount) != 10 {
-		t.Errorf("Expected 10 successful token acquisitions, got %d", successCount)
-	}
-}
-
 // TestAccMegaportMCR_UpdateASN exercises ESD-1094: changing the BGP ASN on
 // an existing MCR must update the resource in place rather than forcing a
 // destroy-and-recreate. The test asserts the product_uid is preserved
@@ -774,23 +694,180 @@ func TestAccMegaportMCR_UpdateASN(t *testing.T) {
 	})
 }
 
-func TestRateLimiter_RateOverTime(t *testing.T) {
-	rl := NewRateLimiter(5, 100*time.Millisecond)
-	start := time.Now()
-	count := 0
+func TestUnitMegaportMCR_Basic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				resource "megaport_mcr" "mcr" {
+					product_name         = "Unit Test MCR"
+					port_speed           = 1000
+					location_id          = %d
+					contract_term_months = 12
+					cost_centre          = "Unit Test"
+					asn                  = 64555
+					resource_tags = {
+						"key1" = "value1"
+					}
+					prefix_filter_lists = [{
+						description    = "unit-test-list"
+						address_family = "IPv4"
+						entries = [
+							{
+								action = "permit"
+								prefix = "10.0.1.0/24"
+								ge     = 25
+								le     = 32
+							},
+							{
+								action = "deny"
+								prefix = "10.0.2.0/24"
+								ge     = 24
+								le     = 24
+							}
+						]
+					}]
+				}`, fakeLocationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "product_name", "Unit Test MCR"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "asn", "64555"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "resource_tags.key1", "value1"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "prefix_filter_lists.#", "1"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "prefix_filter_lists.0.entries.0.ge", "25"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "prefix_filter_lists.0.entries.1.action", "deny"),
+					resource.TestCheckResourceAttrSet("megaport_mcr.mcr", "product_uid"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_mcr.mcr",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "product_uid",
+				ImportStateIdFunc:                    importStateAttr("megaport_mcr.mcr", "product_uid"),
+				ImportStateVerifyIgnore:              []string{"last_updated", "contract_start_date", "contract_end_date", "live_date", "provisioning_status"},
+			},
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				resource "megaport_mcr" "mcr" {
+					product_name         = "Unit Test MCR Renamed"
+					port_speed           = 1000
+					location_id          = %d
+					contract_term_months = 12
+					cost_centre          = "Unit Test Updated"
+					asn                  = 64556
+					resource_tags = {
+						"key1" = "value1-updated"
+					}
+					prefix_filter_lists = [{
+						description    = "unit-test-list"
+						address_family = "IPv4"
+						entries = [
+							{
+								action = "permit"
+								prefix = "10.0.3.0/24"
+								ge     = 26
+								le     = 32
+							}
+						]
+					}]
+				}`, fakeLocationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "product_name", "Unit Test MCR Renamed"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "cost_centre", "Unit Test Updated"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "asn", "64556"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "prefix_filter_lists.0.entries.#", "1"),
+					resource.TestCheckResourceAttr("megaport_mcr.mcr", "prefix_filter_lists.0.entries.0.prefix", "10.0.3.0/24"),
+				),
+			},
+		},
+	})
+}
 
-	for time.Since(start) < 450*time.Millisecond {
-		select {
-		case <-rl.rateLimitCh:
-			count++
-		default:
-			// No token available
-		}
-		time.Sleep(10 * time.Millisecond)
+// TestUnitMegaportMCR_MissingAttributeTags creates an MCR with an API that
+// leaves attributeTags out of product reads. attribute_tags, unknown when the
+// MCR is planned, must be resolved to null by the read after create.
+func TestUnitMegaportMCR_MissingAttributeTags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
 	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	api.inject(&fakeFault{Method: http.MethodGet, Path: "/v2/product/*", Mutate: func(data map[string]any) {
+		delete(data, "attributeTags")
+	}})
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				resource "megaport_mcr" "mcr" {
+					product_name         = "No Attribute Tags MCR"
+					port_speed           = 1000
+					location_id          = %d
+					contract_term_months = 12
+				}`, fakeLocationID),
+				Check: resource.TestCheckNoResourceAttr("megaport_mcr.mcr", "attribute_tags"),
+			},
+		},
+	})
+}
+
+// An MCR read without attributeTags must still resolve attribute_tags, which
+// is unknown after create until the first read fills it in.
+func TestMCRFromAPIMissingAttributeTags(t *testing.T) {
+	orm := &mcrResourceModel{AttributeTags: types.MapUnknown(types.StringType)}
+	diags := orm.fromAPIMCR(context.Background(), &megaport.MCR{UID: "test-uid"}, nil)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, orm.AttributeTags.IsNull())
+}
 
-	expected := 9
-	if count != expected {
-		t.Errorf("Expected %d tokens over time period, got %d", expected, count)
+// TestUnitMegaportMCR_SpeedChange replaces an MCR with a faster one, once plan
+// has rejected a speed its location lacks.
+func TestUnitMegaportMCR_SpeedChange(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	config := func(speed int) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_mcr" "mcr" {
+			product_name         = "Speed Change MCR"
+			port_speed           = %d
+			location_id          = %d
+			contract_term_months = 12
+		}`, speed, fakeLocationID)
 	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(1000),
+				Check:  resource.TestCheckResourceAttr("megaport_mcr.mcr", "port_speed", "1000"),
+			},
+			{
+				Config:      config(25000),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`MCR\s+speed\s+not\s+available`),
+			},
+			{
+				Config: config(5000),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("megaport_mcr.mcr", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("megaport_mcr.mcr", "port_speed", "5000"),
+			},
+		},
+	})
 }
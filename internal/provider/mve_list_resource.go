@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &mveResource{}
+	_ list.ListResourceWithConfigure = &mveResource{}
+)
+
+// NewMVEListResource is a helper function to simplify the provider implementation.
+func NewMVEListResource() list.ListResource {
+	return &mveResource{}
+}
+
+// ListResourceConfigSchema defines the filters accepted by `terraform query`.
+func (r *mveResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the active MVEs in the account so they can be imported. Vendor configuration is not returned by the API and must be added to generated configuration by hand.",
+		Attributes:  productListSchemaAttributes("MVE"),
+	}
+}
+
+// List streams the active MVEs in the account.
+func (r *mveResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config productListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	mves, err := r.client.MVEService.ListMVEs(ctx, &megaport.ListMVEsRequest{
+		IncludeInactive: false,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error listing MVEs",
+			"Could not list MVEs: "+err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, mve := range mves {
+			if listLimitReached(req, count) {
+				return
+			}
+			if isInactiveProduct(mve.ProvisioningStatus) || !config.matches(mve.Name, mve.LocationID) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = mve.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, productIdentityModel{ProductUID: types.StringValue(mve.UID)})...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				var state mveResourceModel
+				result.Diagnostics.Append(newListResultModel(ctx, req, &state)...)
+
+				tags, err := r.client.MVEService.ListMVEResourceTags(ctx, mve.UID)
+				if err != nil {
+					result.Diagnostics.AddWarning(
+						"Error reading tags for MVE",
+						fmt.Sprintf("Could not read tags for MVE with ID %s: %s", mve.UID, err.Error()),
+					)
+				}
+
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(state.fromAPIMVE(ctx, mve, tags)...)
+					result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+				}
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
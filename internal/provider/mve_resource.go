@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -28,9 +29,11 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &mveResource{}
-	_ resource.ResourceWithConfigure   = &mveResource{}
-	_ resource.ResourceWithImportState = &mveResource{}
+	_ resource.Resource                 = &mveResource{}
+	_ resource.ResourceWithConfigure    = &mveResource{}
+	_ resource.ResourceWithImportState  = &mveResource{}
+	_ resource.ResourceWithIdentity     = &mveResource{}
+	_ resource.ResourceWithUpgradeState = &mveResource{}
 
 	vnicAttrs = map[string]attr.Type{
 		"description": types.StringType,
@@ -82,6 +85,8 @@ type mveResourceModel struct {
 	AttributeTags     types.Map  `tfsdk:"attribute_tags"`
 
 	ResourceTags types.Map `tfsdk:"resource_tags"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // mveNetworkInterfaceModel represents a vNIC.
@@ -125,8 +130,29 @@ type vendorConfigModel struct {
 	FMCNatID           types.String `tfsdk:"fmc_nat_id"`
 	IONKey             types.String `tfsdk:"ion_key"`
 	SecretKey          types.String `tfsdk:"secret_key"`
+	SecretsVersion     types.Int64  `tfsdk:"secrets_version"`
+}
+
+// withSecretsFrom copies the write-only secrets of config onto v. Write-only
+// attributes are always null in the plan, so the model built from the plan
+// needs them from the configuration before it is sent to the API.
+func (v *vendorConfigModel) withSecretsFrom(config vendorConfigModel) {
+	v.AccountKey = config.AccountKey
+	v.LicenseData = config.LicenseData
+	v.AdminPasswordHash = config.AdminPasswordHash
+	v.AdminPassword = config.AdminPassword
+	v.LocalAuth = config.LocalAuth
+	v.RemoteAuth = config.RemoteAuth
+	v.VcoActivationCode = config.VcoActivationCode
+	v.Token = config.Token
+	v.FMCRegistrationKey = config.FMCRegistrationKey
+	v.IONKey = config.IONKey
+	v.SecretKey = config.SecretKey
 }
 
+// fromAPIMVE maps an MVE from the API onto the resource model. It never sets
+// vendor_config: the API does not return it, and the secrets in it are
+// write-only, so vendor_config is carried over from the plan or prior state.
 func (orm *mveResourceModel) fromAPIMVE(ctx context.Context, p *megaport.MVE, tags map[string]string) diag.Diagnostics {
 	apiDiags := diag.Diagnostics{}
 	orm.ID = types.Int64Value(int64(p.ID))
@@ -340,7 +366,9 @@ func NewMVEResource() resource.Resource {
 
 // mveResource is the resource implementation.
 type mveResource struct {
-	client *megaport.Client
+	client       *megaport.Client
+	waitTime     time.Duration
+	pollInterval time.Duration
 }
 
 // Metadata returns the resource type name.
@@ -349,8 +377,9 @@ func (r *mveResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *mveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *mveResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Megaport Virtual Edge (MVE) Resource for Megaport Terraform provider. This resource allows you to create, modify, and delete Megaport MVEs. Megaport Virtual Edge (MVE) is an on-demand, vendor-neutral Network Function Virtualization (NFV) platform that provides virtual infrastructure for network services at the edge of Megaport’s global software-defined network (SDN). Network technologies such as SD-WAN and NGFW are hosted directly on Megaport’s global network via Megaport Virtual Edge. Use the `megaport_mve_sizes` data source to query available MVE sizes and the `megaport_mve_images` data source to query available MVE images.",
 		Attributes: map[string]schema.Attribute{
 			"last_updated": schema.StringAttribute{
@@ -594,7 +623,7 @@ func (r *mveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 				},
 			},
 			"vendor_config": schema.SingleNestedAttribute{
-				Description: "The vendor configuration of the MVE. Vendor-specific information required to bootstrap the MVE. These values will be different for each vendor, and can include vendor name, size of VM, license/activation code, software version, and SSH keys. This field cannot be changed after the MVE is created and if it is modified, the MVE will be deleted and re-created. Imported MVEs do not have this field populated by the API, so the initially provided configuration will be ignored as it can't be verified to be correct. If the user wants to change the configuration after importing the resource, they can then do so by changing the field after importing the resource and running terraform apply.",
+				Description: "The vendor configuration of the MVE. Vendor-specific information required to bootstrap the MVE. These values will be different for each vendor, and can include vendor name, size of VM, license/activation code, software version, and SSH keys. This field cannot be changed after the MVE is created and if it is modified, the MVE will be deleted and re-created. The secrets in the vendor config are [write-only arguments](https://developer.hashicorp.com/terraform/language/v1.11.x/resources/ephemeral/write-only), which need Terraform 1.11 or later and are never saved to the plan or state; change `secrets_version` to rotate them. Imported MVEs do not have this field populated by the API, so the initially provided configuration will be ignored as it can't be verified to be correct. If the user wants to change the configuration after importing the resource, they can then do so by changing the field after importing the resource and running terraform apply.",
 				Required:    true,
 				PlanModifiers: []planmodifier.Object{
 					objectplanmodifier.UseStateForUnknown(),
@@ -621,9 +650,10 @@ func (r *mveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						Optional:    true,
 					},
 					"account_key": schema.StringAttribute{
-						Description: "The account key for the vendor config. Enter the Account Key from Aruba Orchestrator. The key is linked to the Account Name. Required for Aruba MVE.",
+						Description: "The account key for the vendor config. Enter the Account Key from Aruba Orchestrator. The key is linked to the Account Name. Required for Aruba MVE. Write-only: the key is sent when the MVE is ordered and is never saved to state.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"admin_ssh_public_key": schema.StringAttribute{
 						Description: "The admin SSH public key for the vendor config. Not required by any vendor currently supported by this provider; Cisco, Fortinet, and VMware use `ssh_public_key` instead.",
@@ -638,14 +668,16 @@ func (r *mveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						Optional:    true,
 					},
 					"license_data": schema.StringAttribute{
-						Description: "The license data for the vendor config. Required for Fortinet and Palo Alto MVEs.",
+						Description: "The license data for the vendor config. Required for Fortinet and Palo Alto MVEs. Write-only, so the license is not kept in state; set `secrets_version` to a new value to provision the MVE again with new license data.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"admin_password_hash": schema.StringAttribute{
-						Description: "The sha256crypt-formatted admin password hash for the vendor config. Required for Palo Alto VM-Series MVE; not used by any other vendor. Must match the format `$5$<salt>$<hash>` (e.g. `$5$2833ea35$Pdyc6dKE8N/UBRge3QWDJJyotG3I59pxLJWVmcSQDdC`). On Linux/macOS you can generate this with `mkpasswd -m sha-256 'your_password'`. This value is only consumed when the MVE is provisioned to seed the initial admin account; after deployment, manage the password via the Palo Alto management interface (the provider does not read this value back from the API).",
+						Description: "The sha256crypt-formatted admin password hash for the vendor config. Required for Palo Alto VM-Series MVE; not used by any other vendor. Must match the format `$5$<salt>$<hash>` (e.g. `$5$2833ea35$Pdyc6dKE8N/UBRge3QWDJJyotG3I59pxLJWVmcSQDdC`). On Linux/macOS you can generate this with `mkpasswd -m sha-256 'your_password'`. This value is only consumed when the MVE is provisioned to seed the initial admin account; after deployment, manage the password via the Palo Alto management interface. Write-only: the hash is not persisted in the plan or state.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"admin_password": schema.StringAttribute{
 						Description: "Plain-text admin password for the vendor config. Required for Cisco FTDv (Firewall) MVE only; Palo Alto MVE uses `admin_password_hash` instead. Must be 9–100 characters and may not contain `\"`, carriage return, or line feed. This value is only consumed when the MVE is provisioned to seed the initial admin account; after deployment, manage the password via the vendor's management interface. Declared as a [write-only argument](https://developer.hashicorp.com/terraform/language/v1.11.x/resources/ephemeral/write-only) (Terraform 1.11+) so the password is not persisted in the Terraform plan or state.",
@@ -666,14 +698,16 @@ func (r *mveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						Optional:    true,
 					},
 					"local_auth": schema.StringAttribute{
-						Description: "The local auth for the vendor config. Enter the Local Auth string as configured in your Versa Director. Required for Versa MVE.",
+						Description: "The local auth for the vendor config. Enter the Local Auth string as configured in your Versa Director. Required for Versa MVE. Write-only.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"remote_auth": schema.StringAttribute{
-						Description: "The remote auth for the vendor config. Enter the Remote Auth string as configured in your Versa Director. Required for Versa MVE.",
+						Description: "The remote auth for the vendor config. Enter the Remote Auth string as configured in your Versa Director. Required for Versa MVE. Write-only.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"serial_number": schema.StringAttribute{
 						Description: "The serial number for the vendor config. Enter the serial number that you specified when creating the device in Versa Director. Required for Versa MVE.",
@@ -688,41 +722,53 @@ func (r *mveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						Optional:    true,
 					},
 					"vco_activation_code": schema.StringAttribute{
-						Description: "The VCO activation code for the vendor config. This is provided by Orchestrator after creating the edge device. Required for VMware MVE.",
+						Description: "The VCO activation code for the vendor config. This is provided by Orchestrator after creating the edge device. Required for VMware MVE. Write-only: the code is only used to activate the edge when the MVE is provisioned and is not stored in state.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"fmc_ip_address": schema.StringAttribute{
 						Description: "The FMC IP address for the vendor config. An IPv4 address, IPv6 address, or FQDN of the Firewall Management Center. Required for Cisco FTDv (Firewall) MVE when `manage_locally` is false.",
 						Optional:    true,
 					},
 					"fmc_registration_key": schema.StringAttribute{
-						Description: "The FMC registration key for the vendor config. Required for Cisco FTDv (Firewall) MVE when `manage_locally` is false.",
+						Description: "The FMC registration key for the vendor config. Required for Cisco FTDv (Firewall) MVE when `manage_locally` is false. Write-only; the key is only used to register the MVE with the Firewall Management Center when it is provisioned.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"fmc_nat_id": schema.StringAttribute{
 						Description: "The FMC NAT ID for the vendor config. Optional for Cisco FTDv (Firewall) MVE when `manage_locally` is false; not applicable when it is true.",
 						Optional:    true,
 					},
 					"token": schema.StringAttribute{
-						Description: "The token for the vendor config. Required for Meraki MVE.",
+						Description: "The token for the vendor config. Required for Meraki MVE. Write-only.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"ion_key": schema.StringAttribute{
-						Description: "The vION key for the vendor config. Required for Prisma MVE.",
+						Description: "The vION key for the vendor config. Required for Prisma MVE. Write-only.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
 					},
 					"secret_key": schema.StringAttribute{
-						Description: "The secret key for the vendor config. Required for Prisma MVE.",
+						Description: "The secret key for the vendor config. Required for Prisma MVE. Write-only, like `ion_key`.",
 						Sensitive:   true,
 						Optional:    true,
+						WriteOnly:   true,
+					},
+					"secrets_version": schema.Int64Attribute{
+						Description: "A version number for the write-only secrets of the vendor config: `account_key`, `admin_password`, `admin_password_hash`, `license_data`, `local_auth`, `remote_auth`, `vco_activation_code`, `fmc_registration_key`, `token`, `ion_key` and `secret_key`. Terraform cannot see changes to write-only values, so the MVE is not re-created when one of them changes. To rotate them, change this value along with the secrets; like any other vendor config change, that replaces the MVE.",
+						Optional:    true,
 					},
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -736,6 +782,12 @@ func (r *mveResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	mveReq := &megaport.BuyMVERequest{
 		LocationID:    int(plan.LocationID.ValueInt64()),
 		Name:          plan.Name.ValueString(),
@@ -743,9 +795,6 @@ func (r *mveResource) Create(ctx context.Context, req resource.CreateRequest, re
 		PromoCode:     plan.PromoCode.ValueString(),
 		CostCentre:    plan.CostCentre.ValueString(),
 		DiversityZone: plan.DiversityZone.ValueString(),
-
-		WaitForProvision: true,
-		WaitForTime:      waitForTime,
 	}
 
 	if !plan.ResourceTags.IsNull() {
@@ -765,15 +814,15 @@ func (r *mveResource) Create(ctx context.Context, req resource.CreateRequest, re
 	vcModel := &vendorConfigModel{}
 	vcDiags := plan.VendorConfig.As(ctx, vcModel, basetypes.ObjectAsOptions{})
 	resp.Diagnostics = append(resp.Diagnostics, vcDiags...)
-	// admin_password is a write-only attribute, so it is null in req.Plan.
-	// Pull it from req.Config and apply it to the vendor model before mapping
-	// to the API request.
-	var configAdminPassword types.String
-	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vendor_config").AtName("admin_password"), &configAdminPassword)...)
+	// The vendor config secrets are write-only attributes, so they are null in
+	// req.Plan. Pull them from req.Config and apply them to the vendor model
+	// before mapping to the API request.
+	var configVendorConfig vendorConfigModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("vendor_config"), &configVendorConfig)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	vcModel.AdminPassword = configAdminPassword
+	vcModel.withSecretsFrom(configVendorConfig)
 	vendorConfig, apiVCDiags := toAPIVendorConfig(vcModel)
 	resp.Diagnostics = append(resp.Diagnostics, apiVCDiags...)
 	if resp.Diagnostics.HasError() {
@@ -812,6 +861,15 @@ func (r *mveResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	createdID := createdMVE.TechnicalServiceUID
 
+	// Wait for the MVE to be provisioned
+	if err := waitForProductReady(ctx, "MVE", []string{createdID}, createTimeout, r.pollInterval, mveStatus(r.client)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading MVE",
+			"Could not create MVE with name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
 	// get the created MVE
 	mve, err := r.client.MVEService.GetMVE(ctx, createdID)
 	if err != nil {
@@ -839,6 +897,7 @@ func (r *mveResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -854,6 +913,14 @@ func (r *mveResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed MVE value from API
 	mve, err := r.client.MVEService.GetMVE(ctx, state.UID.ValueString())
 	if err != nil {
@@ -895,6 +962,7 @@ func (r *mveResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -916,6 +984,12 @@ func (r *mveResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Check on changes
 	var name, costCentre string
 	var contractTermMonths *int
@@ -938,9 +1012,10 @@ func (r *mveResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		Name:               name,
 		CostCentre:         costCentre,
 		ContractTermMonths: contractTermMonths,
-		WaitForUpdate:      true,
-		WaitForTime:        waitForTime,
 	})
+	if err == nil {
+		err = waitForProductReady(ctx, "MVE", []string{state.UID.ValueString()}, updateTimeout, r.pollInterval, mveStatus(r.client))
+	}
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -989,9 +1064,11 @@ func (r *mveResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	state.PromoCode = plan.PromoCode
+	state.Timeouts = plan.Timeouts
 
-	diags := resp.State.Set(ctx, state)
+	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -1007,14 +1084,19 @@ func (r *mveResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	deleteTimeout, timeoutDiags := state.Timeouts.Delete(ctx, r.waitTime)
+	resp.Diagnostics.Append(timeoutDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Call the API to delete the resource
 	productUID := state.UID.ValueString()
-	err := retryTransientDelete(ctx, 3, func() error {
-		_, deleteErr := r.client.MVEService.DeleteMVE(ctx, &megaport.DeleteMVERequest{
-			MVEID:      productUID,
-			SafeDelete: true,
-		})
-		return deleteErr
+	_, err := r.client.MVEService.DeleteMVE(ctx, &megaport.DeleteMVERequest{
+		MVEID:      productUID,
+		SafeDelete: true,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -1047,11 +1129,26 @@ func (r *mveResource) Configure(_ context.Context, req resource.ConfigureRequest
 	client := data.client
 
 	r.client = client
+	r.waitTime = data.waitTime
+	r.pollInterval = data.pollInterval
 }
 
 func (r *mveResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("product_uid"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("product_uid"), path.Root("product_uid"), req, resp)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *mveResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = productIdentitySchema()
+}
+
+// UpgradeState upgrades state saved by earlier versions of the resource schema.
+// Version 0 stored the secrets that are now write-only.
+func (r *mveResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: writeOnlySecretsStateUpgrader(),
+	}
 }
 
 func (r *mveResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -1061,3 +1061,74 @@ func TestAccMegaportMVEPaloAlto_Basic(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitMegaportMVEAruba_Basic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	config := func(name, costCentre, firstVnic string) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_mve" "mve" {
+			product_name         = %q
+			location_id          = %d
+			contract_term_months = 1
+			cost_centre          = %q
+			diversity_zone       = "red"
+
+			vendor_config = {
+				vendor       = "aruba"
+				product_size = "SMALL"
+				mve_label    = "MVE 2/8"
+				image_id     = 23
+				account_name = "unit-test"
+				account_key  = "unit-test-key"
+				system_tag   = "Preconfiguration-aruba-test-1"
+			}
+
+			vnics = [
+				{ description = %q },
+				{ description = "Control Plane" },
+			]
+		}`, name, fakeLocationID, costCentre, firstVnic)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Unit Test MVE", "Unit Test", "Data Plane"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_mve.mve", "product_name", "Unit Test MVE"),
+					resource.TestCheckResourceAttr("megaport_mve.mve", "vendor", "ARUBA"),
+					resource.TestCheckResourceAttr("megaport_mve.mve", "mve_size", "SMALL"),
+					resource.TestCheckResourceAttr("megaport_mve.mve", "diversity_zone", "red"),
+					resource.TestCheckResourceAttr("megaport_mve.mve", "vnics.#", "2"),
+					resource.TestCheckResourceAttr("megaport_mve.mve", "vnics.0.description", "Data Plane"),
+					resource.TestCheckResourceAttrSet("megaport_mve.mve", "product_uid"),
+					// The fake API rejects an Aruba order without an account
+					// key, so the write-only key reached the order without
+					// being saved to state.
+					resource.TestCheckNoResourceAttr("megaport_mve.mve", "vendor_config.account_key"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_mve.mve",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "product_uid",
+				ImportStateIdFunc:                    importStateAttr("megaport_mve.mve", "product_uid"),
+				ImportStateVerifyIgnore:              []string{"last_updated", "contract_start_date", "contract_end_date", "live_date", "vendor_config", "resources", "provisioning_status"},
+			},
+			{
+				Config: config("Unit Test MVE Renamed", "Unit Test Updated", "Data Plane Updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_mve.mve", "product_name", "Unit Test MVE Renamed"),
+					resource.TestCheckResourceAttr("megaport_mve.mve", "cost_centre", "Unit Test Updated"),
+					resource.TestCheckResourceAttr("megaport_mve.mve", "vnics.0.description", "Data Plane Updated"),
+				),
+			},
+		},
+	})
+}
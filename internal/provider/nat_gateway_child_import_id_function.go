@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &natGatewayChildImportIDFunction{}
+
+// natGatewayChildImportIDFunction builds the packed import ID shared by the
+// NAT Gateway child resources.
+type natGatewayChildImportIDFunction struct{}
+
+// NewNATGatewayChildImportIDFunction is a helper function to simplify the provider implementation.
+func NewNATGatewayChildImportIDFunction() function.Function {
+	return &natGatewayChildImportIDFunction{}
+}
+
+// Metadata returns the function name.
+func (f *natGatewayChildImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "nat_gateway_child_import_id"
+}
+
+// Definition defines the function parameters and return type.
+func (f *natGatewayChildImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the import ID of a NAT Gateway child resource.",
+		Description: "Returns the `nat_gateway_uid:id` import ID accepted by the `megaport_nat_gateway_packet_filter` and `megaport_nat_gateway_prefix_list` resources, for use in `import` blocks.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "nat_gateway_uid",
+				Description: "Product UID of the NAT Gateway.",
+			},
+			function.Int64Parameter{
+				Name:        "id",
+				Description: "Numeric ID of the packet filter or prefix list.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the import ID, rejecting values the resources' import would not accept.
+func (f *natGatewayChildImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var natGatewayUID string
+	var id int64
+	resp.Error = req.Arguments.Get(ctx, &natGatewayUID, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	importID := fmt.Sprintf("%s:%d", natGatewayUID, id)
+	if _, _, err := parsePackedImportID(importID, "nat_gateway_uid", "id"); err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, importID)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNATGatewayChildImportIDFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::nat_gateway_child_import_id("nat-gw-uid", 7)
+}
+
+output "parsed" {
+  value = provider::megaport::parse_nat_gateway_child_import_id("nat-gw-uid:7")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("nat-gw-uid:7")),
+					statecheck.ExpectKnownOutputValue("parsed", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"nat_gateway_uid": knownvalue.StringExact("nat-gw-uid"),
+						"id":              knownvalue.Int64Exact(7),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestNATGatewayChildImportIDFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::nat_gateway_child_import_id("", 7)
+}
+`,
+				ExpectError: regexp.MustCompile(`cannot be empty`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::parse_nat_gateway_child_import_id("nat-gw-uid:abc")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid id "abc"`),
+			},
+		},
+	})
+}
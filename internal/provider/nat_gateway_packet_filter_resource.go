@@ -30,6 +30,7 @@ var (
 	_ resource.Resource                = &natGatewayPacketFilterResource{}
 	_ resource.ResourceWithConfigure   = &natGatewayPacketFilterResource{}
 	_ resource.ResourceWithImportState = &natGatewayPacketFilterResource{}
+	_ resource.ResourceWithIdentity    = &natGatewayPacketFilterResource{}
 )
 
 // NewNATGatewayPacketFilterResource returns a new packet filter resource.
@@ -192,6 +193,7 @@ func (r *natGatewayPacketFilterResource) Create(ctx context.Context, req resourc
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, natGatewayChildIdentityModel{NATGatewayProductUID: plan.NATGatewayProductUID, ID: plan.ID})...)
 }
 
 func (r *natGatewayPacketFilterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -226,6 +228,7 @@ func (r *natGatewayPacketFilterResource) Read(ctx context.Context, req resource.
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, natGatewayChildIdentityModel{NATGatewayProductUID: state.NATGatewayProductUID, ID: state.ID})...)
 }
 
 func (r *natGatewayPacketFilterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -258,6 +261,7 @@ func (r *natGatewayPacketFilterResource) Update(ctx context.Context, req resourc
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, natGatewayChildIdentityModel{NATGatewayProductUID: plan.NATGatewayProductUID, ID: plan.ID})...)
 }
 
 func (r *natGatewayPacketFilterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -309,17 +313,23 @@ func (r *natGatewayPacketFilterResource) Delete(ctx context.Context, req resourc
 	)
 }
 
-// ImportState parses an import ID of the form `<nat_gateway_uid>:<packet_filter_id>`.
+// ImportState parses an import ID of the form `<nat_gateway_uid>:<packet_filter_id>`,
+// or reads the same values from the import identity.
 func (r *natGatewayPacketFilterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	natUID, pfID, err := parsePackedImportID(req.ID, "nat_gateway_uid", "packet_filter_id")
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+	natUID, pfID, diags := natGatewayChildImportIDs(ctx, req, "packet_filter_id")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("nat_gateway_product_uid"), natUID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), pfID)...)
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *natGatewayPacketFilterResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = natGatewayChildIdentitySchema()
+}
+
 // toAPIRequest builds the SDK create/update payload from the plan.
 func (m *natGatewayPacketFilterResourceModel) toAPIRequest(ctx context.Context) (*megaport.NATGatewayPacketFilterRequest, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -408,3 +418,19 @@ func parsePackedImportID(importID, labelUID, labelID string) (string, int64, err
 	}
 	return uidPart, id, nil
 }
+
+// natGatewayChildImportIDs returns the NAT Gateway product UID and child object
+// ID being imported, taken from the import identity when no import ID is given.
+func natGatewayChildImportIDs(ctx context.Context, req resource.ImportStateRequest, labelID string) (string, int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if req.ID == "" {
+		var identity natGatewayChildIdentityModel
+		diags.Append(req.Identity.Get(ctx, &identity)...)
+		return identity.NATGatewayProductUID.ValueString(), identity.ID.ValueInt64(), diags
+	}
+	natUID, id, err := parsePackedImportID(req.ID, "nat_gateway_uid", labelID)
+	if err != nil {
+		diags.AddError("Invalid import ID", err.Error())
+	}
+	return natUID, id, diags
+}
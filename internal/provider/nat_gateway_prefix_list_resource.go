@@ -29,6 +29,7 @@ var (
 	_ resource.Resource                = &natGatewayPrefixListResource{}
 	_ resource.ResourceWithConfigure   = &natGatewayPrefixListResource{}
 	_ resource.ResourceWithImportState = &natGatewayPrefixListResource{}
+	_ resource.ResourceWithIdentity    = &natGatewayPrefixListResource{}
 )
 
 // NewNATGatewayPrefixListResource returns a new prefix list resource.
@@ -188,6 +189,7 @@ func (r *natGatewayPrefixListResource) Create(ctx context.Context, req resource.
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, natGatewayChildIdentityModel{NATGatewayProductUID: plan.NATGatewayProductUID, ID: plan.ID})...)
 }
 
 func (r *natGatewayPrefixListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -222,6 +224,7 @@ func (r *natGatewayPrefixListResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, natGatewayChildIdentityModel{NATGatewayProductUID: state.NATGatewayProductUID, ID: state.ID})...)
 }
 
 func (r *natGatewayPrefixListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -254,6 +257,7 @@ func (r *natGatewayPrefixListResource) Update(ctx context.Context, req resource.
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, natGatewayChildIdentityModel{NATGatewayProductUID: plan.NATGatewayProductUID, ID: plan.ID})...)
 }
 
 func (r *natGatewayPrefixListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -307,15 +311,20 @@ func (r *natGatewayPrefixListResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *natGatewayPrefixListResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	natUID, plID, err := parsePackedImportID(req.ID, "nat_gateway_uid", "prefix_list_id")
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+	natUID, plID, diags := natGatewayChildImportIDs(ctx, req, "prefix_list_id")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("nat_gateway_product_uid"), natUID)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plID)...)
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *natGatewayPrefixListResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = natGatewayChildIdentitySchema()
+}
+
 // toAPIRequest builds the SDK request from the plan. The SDK handles the
 // int↔string conversion for ge/le.
 func (m *natGatewayPrefixListResourceModel) toAPIRequest(ctx context.Context) (*megaport.NATGatewayPrefixList, diag.Diagnostics) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -30,6 +31,7 @@ var (
 	_ resource.Resource                = &natGatewayResource{}
 	_ resource.ResourceWithConfigure   = &natGatewayResource{}
 	_ resource.ResourceWithImportState = &natGatewayResource{}
+	_ resource.ResourceWithIdentity    = &natGatewayResource{}
 	_ resource.ResourceWithModifyPlan  = &natGatewayResource{}
 )
 
@@ -56,6 +58,8 @@ type natGatewayResourceModel struct {
 	ASN                types.Int64  `tfsdk:"asn"`
 	BGPShutdownDefault types.Bool   `tfsdk:"bgp_shutdown_default"`
 	SessionCount       types.Int64  `tfsdk:"session_count"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 // fromAPINATGateway maps the API NAT Gateway response to the resource schema.
@@ -121,7 +125,9 @@ func NewNATGatewayResource() resource.Resource {
 
 // natGatewayResource is the resource implementation.
 type natGatewayResource struct {
-	client *megaport.Client
+	client       *megaport.Client
+	waitTime     time.Duration
+	pollInterval time.Duration
 }
 
 // Metadata returns the resource type name.
@@ -130,7 +136,7 @@ func (r *natGatewayResource) Metadata(_ context.Context, req resource.MetadataRe
 }
 
 // Schema defines the schema for the resource.
-func (r *natGatewayResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *natGatewayResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "NAT Gateway Resource for the Megaport Terraform Provider. This can be used to create, modify, and delete Megaport NAT Gateways. " +
 			"Creating this resource places a NAT Gateway order: the design record is created, validated, and purchased, and the provider waits for the service to reach CONFIGURED/LIVE before returning.",
@@ -258,6 +264,9 @@ func (r *natGatewayResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -270,6 +279,12 @@ func (r *natGatewayResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createReq := &megaport.CreateNATGatewayRequest{
 		ProductName: plan.ProductName.ValueString(),
 		LocationID:  int(plan.LocationID.ValueInt64()),
@@ -345,7 +360,7 @@ func (r *natGatewayResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	gw, err := r.waitForNATGatewayProvisioned(ctx, createdUID)
+	gw, err := r.waitForNATGatewayProvisioned(ctx, createdUID, createTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error waiting for NAT Gateway provisioning",
@@ -361,27 +376,37 @@ func (r *natGatewayResource) Create(ctx context.Context, req resource.CreateRequ
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.ProductUID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
 // waitForNATGatewayProvisioned polls the NAT Gateway until it reaches
-// CONFIGURED/LIVE, or returns an error on timeout, terminal state, or
-// context cancellation.
-func (r *natGatewayResource) waitForNATGatewayProvisioned(ctx context.Context, productUID string) (*megaport.NATGateway, error) {
-	const pollInterval = 10 * time.Second
-
-	pollCtx, cancel := context.WithTimeout(ctx, waitForTime)
+// CONFIGURED/LIVE, or returns an error once timeout elapses, on a terminal
+// state, or on context cancellation.
+func (r *natGatewayResource) waitForNATGatewayProvisioned(ctx context.Context, productUID string, timeout time.Duration) (*megaport.NATGateway, error) {
+	// NAT Gateways provision quickly, so they are polled at least every 10
+	// seconds.
+	pollInterval := min(10*time.Second, pollIntervalOrDefault(r.pollInterval))
+
+	pollCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
 	var lastStatus string
+	timedOut := func() error {
+		return fmt.Errorf("NAT Gateway %s did not reach CONFIGURED/LIVE within %s (last status %q)", productUID, timeout, lastStatus)
+	}
 	for {
 		gw, err := r.client.NATGatewayService.GetNATGateway(pollCtx, productUID)
 		if err != nil {
+			// A poll cut short by the timeout is reported as the timeout.
+			if lastStatus != "" && pollCtx.Err() != nil && ctx.Err() == nil {
+				return nil, timedOut()
+			}
 			return nil, fmt.Errorf("polling NAT Gateway %s: %w", productUID, err)
 		}
 		lastStatus = gw.ProvisioningStatus
@@ -396,7 +421,7 @@ func (r *natGatewayResource) waitForNATGatewayProvisioned(ctx context.Context, p
 
 		select {
 		case <-pollCtx.Done():
-			return nil, fmt.Errorf("NAT Gateway %s did not reach CONFIGURED/LIVE within %s (last status %q)", productUID, waitForTime, lastStatus)
+			return nil, timedOut()
 		case <-ticker.C:
 		}
 	}
@@ -411,6 +436,14 @@ func (r *natGatewayResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	productUID := state.ProductUID.ValueString()
 
 	gw, err := r.client.NATGatewayService.GetNATGateway(ctx, productUID)
@@ -441,6 +474,7 @@ func (r *natGatewayResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.ProductUID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -456,6 +490,14 @@ func (r *natGatewayResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	productUID := state.ProductUID.ValueString()
 
 	// Build update request with all fields (full PUT)
@@ -521,8 +563,9 @@ func (r *natGatewayResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	diags := resp.State.Set(ctx, plan)
+	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.ProductUID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -537,6 +580,14 @@ func (r *natGatewayResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	productUID := state.ProductUID.ValueString()
 
 	// NATGatewayService.DeleteNATGateway inspects ProvisioningStatus and
@@ -570,10 +621,17 @@ func (r *natGatewayResource) Configure(_ context.Context, req resource.Configure
 	}
 
 	r.client = data.client
+	r.waitTime = data.waitTime
+	r.pollInterval = data.pollInterval
 }
 
 func (r *natGatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("product_uid"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("product_uid"), path.Root("product_uid"), req, resp)
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *natGatewayResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = productIdentitySchema()
 }
 
 // ModifyPlan rejects invalid speed/session_count combinations at plan time by
@@ -3,13 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // getNATGatewayTestConfig queries the staging NAT Gateway sessions API to get
@@ -338,3 +342,120 @@ resource "megaport_nat_gateway" "test" {
 		},
 	})
 }
+
+func TestUnitMegaportNATGateway_Basic(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	config := func(name, tagValue string) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_nat_gateway" "test" {
+			product_name         = %q
+			location_id          = %d
+			speed                = 1000
+			session_count        = 100000
+			contract_term_months = 1
+			diversity_zone       = "red"
+			asn                  = 64512
+			resource_tags = {
+				"key1" = %q
+			}
+		}`, name, fakeLocationID, tagValue)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Unit Test NAT Gateway", "value1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_nat_gateway.test", "product_name", "Unit Test NAT Gateway"),
+					resource.TestCheckResourceAttr("megaport_nat_gateway.test", "speed", "1000"),
+					resource.TestCheckResourceAttr("megaport_nat_gateway.test", "session_count", "100000"),
+					resource.TestCheckResourceAttr("megaport_nat_gateway.test", "diversity_zone", "red"),
+					resource.TestCheckResourceAttr("megaport_nat_gateway.test", "resource_tags.key1", "value1"),
+					resource.TestCheckResourceAttrSet("megaport_nat_gateway.test", "product_uid"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_nat_gateway.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "product_uid",
+				ImportStateIdFunc:                    importStateAttr("megaport_nat_gateway.test", "product_uid"),
+				ImportStateVerifyIgnore:              []string{"provisioning_status"},
+			},
+			{
+				Config: config("Unit Test NAT Gateway Renamed", "value1-updated"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_nat_gateway.test", "product_name", "Unit Test NAT Gateway Renamed"),
+					resource.TestCheckResourceAttr("megaport_nat_gateway.test", "resource_tags.key1", "value1-updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestWaitForNATGatewayProvisioned_Faults(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		fault   *fakeFault
+		timeout time.Duration
+		wantErr string
+	}{
+		{
+			name:    "provisions on first poll",
+			timeout: time.Second,
+		},
+		{
+			name:    "transient errors are retried",
+			fault:   &fakeFault{Method: http.MethodGet, Times: 2, Status: http.StatusBadGateway, Message: "Bad Gateway"},
+			timeout: time.Second,
+		},
+		{
+			name:    "stuck provisioning times out",
+			fault:   &fakeFault{Method: http.MethodGet, Mutate: stuckIn(fakeStatusDeployable)},
+			timeout: 20 * time.Millisecond,
+			wantErr: `did not reach CONFIGURED/LIVE within 20ms (last status "DEPLOYABLE")`,
+		},
+		{
+			name:    "terminal state fails fast",
+			fault:   &fakeFault{Method: http.MethodGet, Mutate: stuckIn(megaport.STATUS_CANCELLED)},
+			timeout: time.Minute,
+			wantErr: `reached terminal state "CANCELLED"`,
+		},
+		{
+			name:    "slow API exceeds the timeout",
+			fault:   &fakeFault{Method: http.MethodGet, Delay: 200 * time.Millisecond},
+			timeout: 20 * time.Millisecond,
+			wantErr: "polling NAT Gateway",
+		},
+		{
+			name:    "not found",
+			fault:   &fakeFault{Method: http.MethodGet, Status: http.StatusNotFound, Message: "NAT gateway not found"},
+			timeout: time.Minute,
+			wantErr: "polling NAT Gateway",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			api := newFakeMegaportAPI(t)
+			uid := api.seedNATGateway("Faulty NAT Gateway")
+			if tt.fault != nil {
+				tt.fault.Path = "/v3/products/nat_gateways/" + uid
+				api.inject(tt.fault)
+			}
+
+			r := &natGatewayResource{client: api.client(t), pollInterval: time.Millisecond}
+			gw, err := r.waitForNATGatewayProvisioned(context.Background(), uid, tt.timeout)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, megaport.SERVICE_CONFIGURED, gw.ProvisioningStatus)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &normalizeCIDRFunction{}
+
+// normalizeCIDRFunction exposes normalizeCIDR as a provider function.
+type normalizeCIDRFunction struct{}
+
+// NewNormalizeCIDRFunction is a helper function to simplify the provider implementation.
+func NewNormalizeCIDRFunction() function.Function {
+	return &normalizeCIDRFunction{}
+}
+
+// Metadata returns the function name.
+func (f *normalizeCIDRFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_cidr"
+}
+
+// Definition defines the function parameters and return type.
+func (f *normalizeCIDRFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Return the canonical network address of a CIDR prefix.",
+		Description: "Clears any host bits in an IPv4 or IPv6 CIDR prefix, so \"162.43.146.93/31\" becomes \"162.43.146.92/31\". Prefix filter list entries and other CIDR attributes reject prefixes with host bits set, so passing values through this function first keeps them consistent with what the resources accept.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "CIDR prefix to normalize, for example \"10.0.0.1/24\".",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the prefix, failing on input that is not a valid CIDR.
+func (f *normalizeCIDRFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prefix string
+	resp.Error = req.Arguments.Get(ctx, &prefix)
+	if resp.Error != nil {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(prefix); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid CIDR prefix %q: %s", prefix, err))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalizeCIDR(prefix))
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestNormalizeCIDRFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "ipv4" {
+  value = provider::megaport::normalize_cidr("162.43.146.93/31")
+}
+
+output "ipv6" {
+  value = provider::megaport::normalize_cidr("2001:db8::1/64")
+}
+
+output "canonical" {
+  value = provider::megaport::normalize_cidr("10.0.0.0/8")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("ipv4", knownvalue.StringExact("162.43.146.92/31")),
+					statecheck.ExpectKnownOutputValue("ipv6", knownvalue.StringExact("2001:db8::/64")),
+					statecheck.ExpectKnownOutputValue("canonical", knownvalue.StringExact("10.0.0.0/8")),
+				},
+			},
+		},
+	})
+}
+
+func TestNormalizeCIDRFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::normalize_cidr("10.0.0.300/24")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid CIDR prefix`),
+			},
+		},
+	})
+}
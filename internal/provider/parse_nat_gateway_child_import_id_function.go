@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseNATGatewayChildImportIDFunction{}
+
+// natGatewayChildImportIDAttrs is the object returned by the
+// parse_nat_gateway_child_import_id function.
+var natGatewayChildImportIDAttrs = map[string]attr.Type{
+	"nat_gateway_uid": types.StringType,
+	"id":              types.Int64Type,
+}
+
+// parseNATGatewayChildImportIDFunction exposes parsePackedImportID as a provider function.
+type parseNATGatewayChildImportIDFunction struct{}
+
+// NewParseNATGatewayChildImportIDFunction is a helper function to simplify the provider implementation.
+func NewParseNATGatewayChildImportIDFunction() function.Function {
+	return &parseNATGatewayChildImportIDFunction{}
+}
+
+// Metadata returns the function name.
+func (f *parseNATGatewayChildImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_nat_gateway_child_import_id"
+}
+
+// Definition defines the function parameters and return type.
+func (f *parseNATGatewayChildImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a NAT Gateway child resource import ID into its parts.",
+		Description: "Parses a `nat_gateway_uid:id` import ID the same way the `megaport_nat_gateway_packet_filter` and `megaport_nat_gateway_prefix_list` resources do, returning an object with `nat_gateway_uid` and `id`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "import_id",
+				Description: "Import ID in the format `nat_gateway_uid:id`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: natGatewayChildImportIDAttrs,
+		},
+	}
+}
+
+// Run parses the import ID.
+func (f *parseNATGatewayChildImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var importID string
+	resp.Error = req.Arguments.Get(ctx, &importID)
+	if resp.Error != nil {
+		return
+	}
+
+	natGatewayUID, id, err := parsePackedImportID(importID, "nat_gateway_uid", "id")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(natGatewayChildImportIDAttrs, map[string]attr.Value{
+		"nat_gateway_uid": types.StringValue(natGatewayUID),
+		"id":              types.Int64Value(id),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parsePrefixFilterListImportIDFunction{}
+
+// prefixFilterListImportIDAttrs is the object returned by the
+// parse_prefix_filter_list_import_id function.
+var prefixFilterListImportIDAttrs = map[string]attr.Type{
+	"mcr_uid":        types.StringType,
+	"prefix_list_id": types.Int64Type,
+}
+
+// parsePrefixFilterListImportIDFunction exposes parseImportID as a provider function.
+type parsePrefixFilterListImportIDFunction struct{}
+
+// NewParsePrefixFilterListImportIDFunction is a helper function to simplify the provider implementation.
+func NewParsePrefixFilterListImportIDFunction() function.Function {
+	return &parsePrefixFilterListImportIDFunction{}
+}
+
+// Metadata returns the function name.
+func (f *parsePrefixFilterListImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_prefix_filter_list_import_id"
+}
+
+// Definition defines the function parameters and return type.
+func (f *parsePrefixFilterListImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Split a megaport_mcr_prefix_filter_list import ID into its parts.",
+		Description: "Parses an `mcr_uid:prefix_list_id` import ID the same way the `megaport_mcr_prefix_filter_list` resource does, returning an object with `mcr_uid` and `prefix_list_id`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "import_id",
+				Description: "Import ID in the format `mcr_uid:prefix_list_id`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: prefixFilterListImportIDAttrs,
+		},
+	}
+}
+
+// Run parses the import ID.
+func (f *parsePrefixFilterListImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var importID string
+	resp.Error = req.Arguments.Get(ctx, &importID)
+	if resp.Error != nil {
+		return
+	}
+
+	mcrUID, prefixListID, err := parseImportID(importID)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result, diags := types.ObjectValue(prefixFilterListImportIDAttrs, map[string]attr.Value{
+		"mcr_uid":        types.StringValue(mcrUID),
+		"prefix_list_id": types.Int64Value(prefixListID),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -236,7 +236,7 @@ func runFiltersAndSort(ports []*megaport.PartnerMegaport, filters [](func(*megap
 	}
 
 	// sort remaining ports by rank
-	slices.SortFunc(ports, func(a *megaport.PartnerMegaport, b *megaport.PartnerMegaport) int {
+	slices.SortFunc(toReturn, func(a *megaport.PartnerMegaport, b *megaport.PartnerMegaport) int {
 		if n := cmp.Compare(a.Rank, b.Rank); n != 0 {
 			return n
 		}
@@ -272,6 +272,12 @@ func filterByLocationID(locationID int) func(*megaport.PartnerMegaport) bool {
 	}
 }
 
+func filterByLocationIDs(locationIDs map[int]bool) func(*megaport.PartnerMegaport) bool {
+	return func(pm *megaport.PartnerMegaport) bool {
+		return !locationIDs[pm.LocationId]
+	}
+}
+
 func filterByCompanyName(companyName string) func(*megaport.PartnerMegaport) bool {
 	return func(pm *megaport.PartnerMegaport) bool {
 		return !strings.EqualFold(pm.CompanyName, companyName)
@@ -156,3 +156,19 @@ func TestRank(t *testing.T) {
 		ports[1],
 	})
 }
+
+func TestRankAfterFiltering(t *testing.T) {
+	ports := []*megaport.PartnerMegaport{
+		{ProductName: "p3", Rank: 2, DiversityZone: "red"},
+		{ProductName: "p1", Rank: 0, DiversityZone: "blue"},
+		{ProductName: "p4", Rank: 3, DiversityZone: "red"},
+		{ProductName: "p2", Rank: 1, DiversityZone: "red"},
+	}
+
+	// the filtered ports are returned highest ranked first, and the ports
+	// passed in are left in their original order
+	filtered := runFiltersAndSort(ports, []func(*megaport.PartnerMegaport) bool{filterByDiversityZone("red")})
+
+	assert.Equal(t, []*megaport.PartnerMegaport{ports[3], ports[0], ports[2]}, filtered)
+	assert.Equal(t, "p3", ports[0].ProductName)
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &portLOADataSource{}
+	_ datasource.DataSourceWithConfigure = &portLOADataSource{}
+)
+
+// portLOADataSource is the data source implementation.
+type portLOADataSource struct {
+	client *megaport.Client
+}
+
+// portLOADataSourceModel maps the data source schema data.
+type portLOADataSourceModel struct {
+	UID                types.String `tfsdk:"product_uid"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	ContentBase64      types.String `tfsdk:"content_base64"`
+	ProductName        types.String `tfsdk:"product_name"`
+	ProvisioningStatus types.String `tfsdk:"provisioning_status"`
+	Demarcation        types.String `tfsdk:"demarcation"`
+	Media              types.String `tfsdk:"media"`
+	CrossConnectUp     types.Bool   `tfsdk:"cross_connect_up"`
+}
+
+// NewPortLOADataSource is a helper function to simplify the provider implementation.
+func NewPortLOADataSource() datasource.DataSource {
+	return &portLOADataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *portLOADataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_port_loa"
+}
+
+// Schema defines the schema for the data source.
+func (d *portLOADataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Downloads the Letter of Authorization (LOA) of a port, which the data centre needs to install the cross connect to it, and reports the state of the cross connect. For a LAG, read the LOA of each member port in lag_port_uids. The LOA is issued once the port has been provisioned.",
+		Attributes: map[string]schema.Attribute{
+			"product_uid": schema.StringAttribute{
+				Description: "The UID of the port, or of a member port of a LAG.",
+				Required:    true,
+			},
+			"output_path": schema.StringAttribute{
+				Description: "A local file to write the LOA PDF to. Its directory must exist, and the file is overwritten whenever the data source is read.",
+				Optional:    true,
+			},
+			"content_base64": schema.StringAttribute{
+				Description: "The LOA PDF, base64-encoded.",
+				Computed:    true,
+			},
+			"product_name": schema.StringAttribute{
+				Description: "The name of the port.",
+				Computed:    true,
+			},
+			"provisioning_status": schema.StringAttribute{
+				Description: "The provisioning status of the port, such as CONFIGURED before the cross connect is in place and LIVE after.",
+				Computed:    true,
+			},
+			"demarcation": schema.StringAttribute{
+				Description: "Where in the data centre the cross connect is to be delivered to the port.",
+				Computed:    true,
+			},
+			"media": schema.StringAttribute{
+				Description: "The optic of the port's interface, such as LR or LR4.",
+				Computed:    true,
+			},
+			"cross_connect_up": schema.BoolAttribute{
+				Description: "Whether the port's interface is up, which it is once the cross connect is installed and the other end is connected.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *portLOADataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state portLOADataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	uid := state.UID.ValueString()
+	port, err := d.client.PortService.GetPort(ctx, uid)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Port",
+			"Could not read port with ID "+uid+": "+err.Error(),
+		)
+		return
+	}
+	if !strings.EqualFold(port.Type, megaport.PRODUCT_MEGAPORT) {
+		resp.Diagnostics.AddAttributeError(path.Root("product_uid"), "Not a port",
+			fmt.Sprintf("Product %s is a %s. Only physical ports have an LOA.", uid, port.Type))
+		return
+	}
+
+	loa, err := downloadPortLOA(ctx, d.client, uid)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Downloading LOA",
+			"Could not download the LOA of port "+uid+": "+err.Error(),
+		)
+		return
+	}
+	if !state.OutputPath.IsNull() {
+		if err := os.WriteFile(state.OutputPath.ValueString(), loa, 0o644); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("output_path"), "Error Writing LOA",
+				"Could not write the LOA of port "+uid+": "+err.Error())
+			return
+		}
+	}
+
+	state.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(loa))
+	state.ProductName = types.StringValue(port.Name)
+	state.ProvisioningStatus = types.StringValue(port.ProvisioningStatus)
+	state.Demarcation = types.StringValue(port.VXCResources.Interface.Demarcation)
+	state.Media = types.StringValue(port.VXCResources.Interface.Media)
+	state.CrossConnectUp = types.BoolValue(port.VXCResources.Interface.Up == 1)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// downloadPortLOA returns the LOA PDF of a port. megaportgo has no call for
+// it, so it is fetched from the product's LOA endpoint directly.
+func downloadPortLOA(ctx context.Context, client *megaport.Client, productUID string) ([]byte, error) {
+	url := client.BaseURL.JoinPath("/v2/product", productUID, "loa").String()
+	req, err := client.NewRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/pdf")
+	loa := &bytes.Buffer{}
+	if _, err := client.Do(ctx, req, loa); err != nil {
+		return nil, err
+	}
+	if loa.Len() == 0 {
+		return nil, fmt.Errorf("the LOA is empty, which it is until the port has been provisioned")
+	}
+	return loa.Bytes(), nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *portLOADataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*megaportProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *megaportProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestUnitMegaportPortLOADataSource(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	port := api.seedPort("LOA Port")
+	mcr := api.seedMCR("LOA MCR")
+	outputPath := filepath.Join(t.TempDir(), "loa.pdf")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				data "megaport_port_loa" "port" {
+					product_uid = %q
+					output_path = %q
+				}`, port, outputPath),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.megaport_port_loa.port", "content_base64", base64.StdEncoding.EncodeToString([]byte(fakeLOA(port)))),
+					resource.TestCheckResourceAttr("data.megaport_port_loa.port", "product_name", "LOA Port"),
+					resource.TestCheckResourceAttr("data.megaport_port_loa.port", "provisioning_status", "LIVE"),
+					resource.TestCheckResourceAttr("data.megaport_port_loa.port", "demarcation", "Fake Data Centre, Level 1, Rack 1"),
+					resource.TestCheckResourceAttr("data.megaport_port_loa.port", "media", "LR4"),
+					resource.TestCheckResourceAttr("data.megaport_port_loa.port", "cross_connect_up", "true"),
+					func(_ *terraform.State) error {
+						loa, err := os.ReadFile(outputPath)
+						if err != nil {
+							return err
+						}
+						if string(loa) != fakeLOA(port) {
+							return fmt.Errorf("LOA written to %s is %q", outputPath, loa)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				data "megaport_port_loa" "mcr" {
+					product_uid = %q
+				}`, mcr),
+				ExpectError: regexp.MustCompile(`Only\s+physical\s+ports\s+have\s+an\s+LOA`),
+			},
+		},
+	})
+}
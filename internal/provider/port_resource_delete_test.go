@@ -5,11 +5,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	megaport "github.com/megaport/megaportgo"
 )
 
@@ -62,3 +65,36 @@ func TestPortDeleteAlwaysUsesCancelNow(t *testing.T) {
 		t.Fatalf("DeletePort hit unexpected path: got %q want %q", receivedPath, wantPath)
 	}
 }
+
+// TestUnitPortDeleteRetriesRollbackOnly destroys a port whose first cancel
+// requests fail with a rolled back transaction, as seen in staging, and
+// checks the provider retries until the port is decommissioned.
+func TestUnitPortDeleteRetriesRollbackOnly(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedPort("Rollback Port")
+	fault := &fakeFault{
+		Method:  http.MethodPost,
+		Path:    "/v3/product/" + uid + "/action/CANCEL_NOW",
+		Times:   2,
+		Status:  http.StatusInternalServerError,
+		Message: "Transaction silently rolled back because it has been marked as rollback-only",
+	}
+	api.inject(fault)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy: func(s *terraform.State) error {
+			if hits := api.hits(fault); hits != 2 {
+				return fmt.Errorf("expected 2 rolled back cancel requests, got %d", hits)
+			}
+			return api.checkDestroy(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: seededPortConfig(uid, "Rollback Port"),
+				Check:  resource.TestCheckResourceAttr("megaport_port.port", "product_uid", uid),
+			},
+		},
+	})
+}
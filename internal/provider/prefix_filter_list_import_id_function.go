@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &prefixFilterListImportIDFunction{}
+
+// prefixFilterListImportIDFunction exposes generateImportID as a provider function.
+type prefixFilterListImportIDFunction struct{}
+
+// NewPrefixFilterListImportIDFunction is a helper function to simplify the provider implementation.
+func NewPrefixFilterListImportIDFunction() function.Function {
+	return &prefixFilterListImportIDFunction{}
+}
+
+// Metadata returns the function name.
+func (f *prefixFilterListImportIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "prefix_filter_list_import_id"
+}
+
+// Definition defines the function parameters and return type.
+func (f *prefixFilterListImportIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the import ID of a megaport_mcr_prefix_filter_list resource.",
+		Description: "Returns the `mcr_uid:prefix_list_id` import ID accepted by the `megaport_mcr_prefix_filter_list` resource, for use in `import` blocks.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "mcr_uid",
+				Description: "UID of the MCR the prefix filter list belongs to.",
+			},
+			function.Int64Parameter{
+				Name:        "prefix_list_id",
+				Description: "Numeric ID of the prefix filter list.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the import ID, rejecting values the resource's import would not accept.
+func (f *prefixFilterListImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var mcrUID string
+	var prefixListID int64
+	resp.Error = req.Arguments.Get(ctx, &mcrUID, &prefixListID)
+	if resp.Error != nil {
+		return
+	}
+
+	importID := generateImportID(mcrUID, prefixListID)
+	if _, _, err := parseImportID(importID); err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, importID)
+}
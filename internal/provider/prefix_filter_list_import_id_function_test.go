@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestPrefixFilterListImportIDFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::prefix_filter_list_import_id("11111111-2222-3333-4444-555555555555", 42)
+}
+
+output "round_trip" {
+  value = provider::megaport::parse_prefix_filter_list_import_id(
+    provider::megaport::prefix_filter_list_import_id("11111111-2222-3333-4444-555555555555", 42)
+  )
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact("11111111-2222-3333-4444-555555555555:42")),
+					statecheck.ExpectKnownOutputValue("round_trip", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"mcr_uid":        knownvalue.StringExact("11111111-2222-3333-4444-555555555555"),
+						"prefix_list_id": knownvalue.Int64Exact(42),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestPrefixFilterListImportIDFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::prefix_filter_list_import_id("mcr-uid", 0)
+}
+`,
+				ExpectError: regexp.MustCompile(`must be a positive integer`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::parse_prefix_filter_list_import_id("mcr-uid")
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid import ID format`),
+			},
+		},
+	})
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &prefixGeLeFunction{}
+
+// prefixGeLeAttrs is the object returned by the prefix_ge_le function.
+var prefixGeLeAttrs = map[string]attr.Type{
+	"ge": types.Int64Type,
+	"le": types.Int64Type,
+}
+
+// prefixGeLeFunction exposes calculateGeLeFromPrefix as a provider function.
+type prefixGeLeFunction struct{}
+
+// NewPrefixGeLeFunction is a helper function to simplify the provider implementation.
+func NewPrefixGeLeFunction() function.Function {
+	return &prefixGeLeFunction{}
+}
+
+// Metadata returns the function name.
+func (f *prefixGeLeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "prefix_ge_le"
+}
+
+// Definition defines the function parameters and return type.
+func (f *prefixGeLeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Return the default ge and le values for a prefix filter list entry.",
+		Description: "Returns an object with `ge` set to the prefix length and `le` set to the maximum length for the prefix's address family (32 for IPv4, 128 for IPv6). These are the values the `megaport_mcr_prefix_filter_list` resource uses when the API reports an entry without explicit bounds.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "prefix",
+				Description: "CIDR prefix of the entry, for example \"10.0.0.0/16\".",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: prefixGeLeAttrs,
+		},
+	}
+}
+
+// Run calculates ge and le for the prefix.
+func (f *prefixGeLeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prefix string
+	resp.Error = req.Arguments.Get(ctx, &prefix)
+	if resp.Error != nil {
+		return
+	}
+
+	ip, _, err := net.ParseCIDR(prefix)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid CIDR prefix %q: %s", prefix, err))
+		return
+	}
+	addressFamily := "IPv4"
+	if ip.To4() == nil {
+		addressFamily = "IPv6"
+	}
+
+	ge, le, diags := calculateGeLeFromPrefix(prefix, addressFamily)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	result, diags := types.ObjectValue(prefixGeLeAttrs, map[string]attr.Value{
+		"ge": types.Int64Value(int64(ge)),
+		"le": types.Int64Value(int64(le)),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
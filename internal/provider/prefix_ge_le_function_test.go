@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestPrefixGeLeFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "ipv4" {
+  value = provider::megaport::prefix_ge_le("10.0.0.0/16")
+}
+
+output "ipv6" {
+  value = provider::megaport::prefix_ge_le("2001:db8::/48")
+}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("ipv4", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"ge": knownvalue.Int64Exact(16),
+						"le": knownvalue.Int64Exact(32),
+					})),
+					statecheck.ExpectKnownOutputValue("ipv6", knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"ge": knownvalue.Int64Exact(48),
+						"le": knownvalue.Int64Exact(128),
+					})),
+				},
+			},
+		},
+	})
+}
+
+func TestPrefixGeLeFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::megaport::prefix_ge_le("not-a-prefix")
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid CIDR prefix`),
+			},
+		},
+	})
+}
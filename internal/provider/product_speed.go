@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// validateSpeedAtLocation checks at plan time that the speed a port or MCR is
+// replaced with is offered at the product's location, in its diversity zone when it has
+// one. speeds picks the speeds for the product type out of a diversity zone.
+// As for other plan-time lookups, a failed location lookup is only a warning.
+func validateSpeedAtLocation(ctx context.Context, client *megaport.Client, productKind string, locationID int, diversityZone string, speed int, speeds func(*megaport.LocationV3DiversityZone) []int) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	location, err := client.LocationService.GetLocationByIDV3(ctx, locationID)
+	if err != nil {
+		diags.AddWarning(
+			fmt.Sprintf("Could not validate %s speed at plan time", productKind),
+			fmt.Sprintf("The lookup of location %d failed: %v. The Megaport API will still reject the replacement at apply if the location cannot support it.", locationID, err),
+		)
+		return diags
+	}
+	available := locationSpeeds(location, diversityZone, speeds)
+	if !slices.Contains(available, speed) {
+		zone := ""
+		if diversityZone != "" {
+			zone = fmt.Sprintf(" in the %s diversity zone", strings.ToLower(diversityZone))
+		}
+		diags.AddAttributeError(path.Root("port_speed"), fmt.Sprintf("%s speed not available", strings.ToUpper(productKind[:1])+productKind[1:]),
+			fmt.Sprintf("Location %q (%d) has no capacity for a %d Mbps %s%s, so it cannot be replaced with one of that speed. Available speeds: %v.", location.Name, locationID, speed, productKind, zone, available))
+	}
+	return diags
+}
+
+// locationSpeeds returns the speeds a location offers in a diversity zone, or
+// in either zone when diversityZone is empty or not a zone of the location.
+func locationSpeeds(location *megaport.LocationV3, diversityZone string, speeds func(*megaport.LocationV3DiversityZone) []int) []int {
+	zones := location.DiversityZones
+	if zones == nil {
+		return nil
+	}
+	switch strings.ToLower(diversityZone) {
+	case "red":
+		if zones.Red != nil {
+			return speeds(zones.Red)
+		}
+	case "blue":
+		if zones.Blue != nil {
+			return speeds(zones.Blue)
+		}
+	}
+	var available []int
+	for _, zone := range []*megaport.LocationV3DiversityZone{zones.Red, zones.Blue} {
+		if zone == nil {
+			continue
+		}
+		for _, s := range speeds(zone) {
+			if !slices.Contains(available, s) {
+				available = append(available, s)
+			}
+		}
+	}
+	slices.Sort(available)
+	return available
+}
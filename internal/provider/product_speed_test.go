@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"testing"
+
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocationSpeeds(t *testing.T) {
+	location := &megaport.LocationV3{
+		DiversityZones: &megaport.LocationV3DiversityZones{
+			Red:  &megaport.LocationV3DiversityZone{MegaportSpeedMbps: []int{10000, 1000}},
+			Blue: &megaport.LocationV3DiversityZone{MegaportSpeedMbps: []int{1000, 100000}},
+		},
+	}
+	portSpeeds := func(zone *megaport.LocationV3DiversityZone) []int { return zone.MegaportSpeedMbps }
+
+	assert.Equal(t, []int{10000, 1000}, locationSpeeds(location, "red", portSpeeds))
+	assert.Equal(t, []int{1000, 100000}, locationSpeeds(location, "BLUE", portSpeeds))
+	// Without a zone, a speed offered in either zone is available.
+	assert.Equal(t, []int{1000, 10000, 100000}, locationSpeeds(location, "", portSpeeds))
+
+	location.DiversityZones.Blue = nil
+	assert.Equal(t, []int{1000, 10000}, locationSpeeds(location, "blue", portSpeeds))
+	assert.Empty(t, locationSpeeds(&megaport.LocationV3{}, "red", portSpeeds))
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// defaultPollInterval is how often the provider polls a product that is being
+// provisioned or updated. megaportgo's own waits poll every 30 seconds, and
+// only after the first 30 seconds, so resources place orders and changes
+// without them and wait with waitForProductReady instead.
+const defaultPollInterval = 30 * time.Second
+
+// pollIntervalOrDefault returns interval, or defaultPollInterval if it is not
+// set.
+func pollIntervalOrDefault(interval time.Duration) time.Duration {
+	if interval <= 0 {
+		return defaultPollInterval
+	}
+	return interval
+}
+
+// waitForProductReady polls products of a kind, such as "Port" or "MCR", until
+// all of them are in a ready state, one of them reaches a terminal state, or
+// the timeout elapses. The first poll is immediate. status returns the
+// provisioning status of the product with a UID. Read errors are retried
+// rather than aborting the wait, since the order or change has already been
+// placed.
+func waitForProductReady(ctx context.Context, kind string, uids []string, timeoutAfter, pollInterval time.Duration, status func(context.Context, string) (string, error)) error {
+	// The polls share this deadline so a stalled HTTP request can't hang
+	// the wait past the overall timeout.
+	pollCtx, cancel := context.WithTimeout(ctx, timeoutAfter)
+	defer cancel()
+
+	ticker := time.NewTicker(pollIntervalOrDefault(pollInterval))
+	defer ticker.Stop()
+
+	pending := slices.Clone(uids)
+	for {
+		for i := 0; i < len(pending); {
+			s, err := status(pollCtx, pending[i])
+			switch {
+			case err != nil:
+				tflog.Warn(ctx, "error polling provisioning status, will retry", map[string]interface{}{
+					"product_kind": kind,
+					"product_uid":  pending[i],
+					"error":        err.Error(),
+				})
+				i++
+			case slices.Contains(megaport.SERVICE_STATE_READY, s):
+				pending = slices.Delete(pending, i, i+1)
+			case s == megaport.STATUS_DECOMMISSIONED || s == megaport.STATUS_CANCELLED:
+				return fmt.Errorf("%s %s reached terminal state %q before it was ready", kind, pending[i], s)
+			default:
+				i++
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-pollCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("time expired waiting for %s %s to be ready", kind, strings.Join(pending, ", "))
+		case <-ticker.C:
+		}
+	}
+}
+
+// portStatus returns a waitForProductReady status func that reads ports.
+func portStatus(client *megaport.Client) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, uid string) (string, error) {
+		port, err := client.PortService.GetPort(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		return port.ProvisioningStatus, nil
+	}
+}
+
+// mcrStatus returns a waitForProductReady status func that reads MCRs.
+func mcrStatus(client *megaport.Client) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, uid string) (string, error) {
+		mcr, err := client.MCRService.GetMCR(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		return mcr.ProvisioningStatus, nil
+	}
+}
+
+// mveStatus returns a waitForProductReady status func that reads MVEs.
+func mveStatus(client *megaport.Client) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, uid string) (string, error) {
+		mve, err := client.MVEService.GetMVE(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		return mve.ProvisioningStatus, nil
+	}
+}
+
+// ixStatus returns a waitForProductReady status func that reads IXs.
+func ixStatus(client *megaport.Client) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, uid string) (string, error) {
+		ix, err := client.IXService.GetIX(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		return ix.ProvisioningStatus, nil
+	}
+}
+
+// vxcStatus returns a waitForProductReady status func that reads VXCs.
+func vxcStatus(client *megaport.Client) func(context.Context, string) (string, error) {
+	return func(ctx context.Context, uid string) (string, error) {
+		vxc, err := client.VXCService.GetVXC(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		return vxc.ProvisioningStatus, nil
+	}
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForProductReady_WaitsForEveryProduct(t *testing.T) {
+	polls := map[string]int{}
+	status := func(_ context.Context, uid string) (string, error) {
+		polls[uid]++
+		if uid == "slow" && polls[uid] < 3 {
+			return "DEPLOYABLE", nil
+		}
+		return megaport.SERVICE_CONFIGURED, nil
+	}
+
+	err := waitForProductReady(context.Background(), "Port", []string{"fast", "slow"}, time.Second, time.Millisecond, status)
+	require.NoError(t, err)
+	assert.Equal(t, 1, polls["fast"], "a ready product is not polled again")
+	assert.Equal(t, 3, polls["slow"])
+}
+
+func TestWaitForProductReady_ReportsPendingProducts(t *testing.T) {
+	status := func(_ context.Context, uid string) (string, error) {
+		if uid == "stuck" {
+			return "DEPLOYABLE", nil
+		}
+		return megaport.SERVICE_LIVE, nil
+	}
+
+	err := waitForProductReady(context.Background(), "Port", []string{"ready", "stuck"}, 20*time.Millisecond, time.Millisecond, status)
+	require.Error(t, err)
+	assert.Equal(t, "time expired waiting for Port stuck to be ready", err.Error())
+}
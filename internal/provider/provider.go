@@ -12,6 +12,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -22,22 +25,28 @@ import (
 	megaport "github.com/megaport/megaportgo"
 )
 
-// Set by Provider Config, is 10 minutes by default.
-var waitForTime time.Duration
-
 // megaportProviderModel maps provider schema data to a Go type.
 type megaportProviderModel struct {
-	Environment       types.String `tfsdk:"environment"`
-	AccessKey         types.String `tfsdk:"access_key"`
-	SecretKey         types.String `tfsdk:"secret_key"`
-	TermsAccepted     types.Bool   `tfsdk:"accept_purchase_terms"`
-	WaitTime          types.Int64  `tfsdk:"wait_time"`
-	ManagedAccountUID types.String `tfsdk:"managed_account_uid"`
+	Environment           types.String `tfsdk:"environment"`
+	AccessKey             types.String `tfsdk:"access_key"`
+	SecretKey             types.String `tfsdk:"secret_key"`
+	TermsAccepted         types.Bool   `tfsdk:"accept_purchase_terms"`
+	WaitTime              types.Int64  `tfsdk:"wait_time"`
+	ManagedAccountUID     types.String `tfsdk:"managed_account_uid"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryMaxBackoff       types.Int64  `tfsdk:"retry_max_backoff"`
+	RequestsPerSecond     types.Int64  `tfsdk:"requests_per_second"`
+	Burst                 types.Int64  `tfsdk:"burst"`
+	Profile               types.String `tfsdk:"profile"`
+	SharedCredentialsFile types.String `tfsdk:"shared_credentials_file"`
 }
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &megaportProvider{}
+	_ provider.Provider                       = &megaportProvider{}
+	_ provider.ProviderWithEphemeralResources = &megaportProvider{}
+	_ provider.ProviderWithFunctions          = &megaportProvider{}
+	_ provider.ProviderWithListResources      = &megaportProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -55,10 +64,25 @@ type megaportProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// clientOpts are applied after the provider's own client options. Unit
+	// tests use them to point the provider at an in-process fake API.
+	clientOpts []megaport.ClientOpt
+
+	// pollInterval is how often resources poll products that are being
+	// provisioned or updated, or defaultPollInterval if zero. Unit tests
+	// shorten it so that waits on the fake API take milliseconds.
+	pollInterval time.Duration
 }
 
 type megaportProviderData struct {
 	client *megaport.Client
+	// waitTime is the provider-level wait_time, used by resources whose
+	// timeouts block leaves an operation unset.
+	waitTime time.Duration
+	// pollInterval is how often resources poll products while waiting for
+	// them to be provisioned or updated.
+	pollInterval time.Duration
 }
 
 // Metadata returns the provider type name.
@@ -91,7 +115,35 @@ func (p *megaportProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "Indicates acceptance of the Megaport API terms, this is required to use the provider. Can also be set using the environment variable MEGAPORT_ACCEPT_PURCHASE_TERMS",
 			},
 			"wait_time": schema.Int64Attribute{
-				Description: "Maximum time in minutes to wait for resources to finish provisioning during create and update operations before timing out. Defaults to 10, minimum 1. Increase this if you provision resources that take longer than 10 minutes to become live, such as MVEs or VXCs to cloud providers.",
+				Description: "Maximum time in minutes to wait for resources to finish provisioning during create and update operations before timing out. Defaults to 10, minimum 1. Increase this if you provision resources that take longer than 10 minutes to become live, such as MVEs or VXCs to cloud providers. Individual resources can override this value with a `timeouts` block.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times an API request is retried after a transient error, such as rate limiting (HTTP 429), a gateway error (HTTP 502, 503 or 504) on a read, update or delete, or a rolled back server-side transaction. Retries use exponential backoff with jitter, honour any `Retry-After` header and stop once the operation's timeout would be exceeded. Defaults to 3. Set to 0 to disable retries.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_backoff": schema.Int64Attribute{
+				Description: "Maximum time in seconds to wait between retries of an API request. Defaults to 30, minimum 1. A `Retry-After` header asking for a longer wait ends retrying and returns the error.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Int64Attribute{
+				Description: "Maximum sustained rate of requests the provider sends to the Megaport API, shared by every resource and data source. Defaults to 10, minimum 1. Lower this if large configurations run with high `-parallelism` are throttled by the API.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"burst": schema.Int64Attribute{
+				Description: "Maximum number of requests the provider may send to the Megaport API at once before `requests_per_second` applies. Defaults to 10, minimum 1.",
 				Optional:    true,
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
@@ -104,6 +156,20 @@ func (p *megaportProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "The name of the profile in the shared credentials file to read `access_key`, `secret_key`, `environment` and `managed_account_uid` from when they are not set in the configuration or environment. Can also be set using the environment variable MEGAPORT_PROFILE. Defaults to `default`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"shared_credentials_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to the shared credentials file. Can also be set using the environment variable MEGAPORT_SHARED_CREDENTIALS_FILE. Defaults to `~/.megaport/credentials`.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -166,6 +232,24 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Megaport credentials profile",
+			"The provider cannot create the Megaport API client as there is an unknown configuration value for the credentials profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MEGAPORT_PROFILE environment variable.",
+		)
+	}
+
+	if config.SharedCredentialsFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("shared_credentials_file"),
+			"Unknown Megaport shared credentials file",
+			"The provider cannot create the Megaport API client as there is an unknown configuration value for the shared credentials file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MEGAPORT_SHARED_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
 	if config.WaitTime.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("wait_time"),
@@ -175,17 +259,84 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if config.MaxRetries.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Megaport API max retries",
+			"The provider cannot create the Megaport API client as there is an unknown configuration value for the maximum number of API retries. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RetryMaxBackoff.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_max_backoff"),
+			"Unknown Megaport API retry max backoff",
+			"The provider cannot create the Megaport API client as there is an unknown configuration value for the maximum API retry backoff. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RequestsPerSecond.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("requests_per_second"),
+			"Unknown Megaport API request rate",
+			"The provider cannot create the Megaport API client as there is an unknown configuration value for the API request rate. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Burst.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("burst"),
+			"Unknown Megaport API request burst",
+			"The provider cannot create the Megaport API client as there is an unknown configuration value for the API request burst. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
-
-	environment := os.Getenv("MEGAPORT_ENVIRONMENT")
-	accessKey := os.Getenv("MEGAPORT_ACCESS_KEY")
-	secretKey := os.Getenv("MEGAPORT_SECRET_KEY")
-	managedAccountUID := os.Getenv("MEGAPORT_MANAGED_ACCOUNT_UID")
+	// Take connection settings from the Terraform configuration, then
+	// environment variables, then the shared credentials file.
+	settings, profile, err := resolveProviderSettings(config, os.Getenv)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unable to read Megaport credentials profile",
+			fmt.Sprintf("The provider cannot create the Megaport API client as the %q credentials profile could not be loaded: %s", settings.profileName, err),
+		)
+		return
+	}
+	if settings.ignoredLoadErr != nil {
+		resp.Diagnostics.AddWarning(
+			"Ignoring shared credentials file",
+			fmt.Sprintf("The default credentials profile could not be loaded, so it was skipped: %s", settings.ignoredLoadErr),
+		)
+	}
+	if settings.profileExplicit {
+		if shadowed := settings.shadowedProfileSettings(profile); len(shadowed) > 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("profile"),
+				"Megaport credentials profile partially overridden",
+				fmt.Sprintf("The %q credentials profile was selected, but these settings were taken from elsewhere: %s. "+
+					"The provider configuration and MEGAPORT_* environment variables take precedence over the shared credentials file.",
+					settings.profileName, strings.Join(shadowed, ", ")),
+			)
+		}
+	}
+	tflog.Info(ctx, "Resolved Megaport API credentials", map[string]interface{}{
+		"credentials_source":         settings.credentialsSource,
+		"environment_source":         settings.environmentSource,
+		"managed_account_uid_source": settings.managedAccountUIDSource,
+	})
+
+	environment := settings.environment
+	accessKey := settings.accessKey
+	secretKey := settings.secretKey
+	managedAccountUID := settings.managedAccountUID
 	acceptTerms := false
 	waitTime := 10
 	if strings.ToLower(os.Getenv("MEGAPORT_ACCEPT_PURCHASE_TERMS")) == "true" ||
@@ -193,28 +344,33 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 		acceptTerms = true
 	}
 
-	if !config.Environment.IsNull() {
-		environment = config.Environment.ValueString()
+	if !config.TermsAccepted.IsNull() {
+		acceptTerms = config.TermsAccepted.ValueBool()
 	}
 
-	if !config.AccessKey.IsNull() {
-		accessKey = config.AccessKey.ValueString()
+	if !config.WaitTime.IsNull() {
+		waitTime = int(config.WaitTime.ValueInt64())
 	}
 
-	if !config.SecretKey.IsNull() {
-		secretKey = config.SecretKey.ValueString()
+	retry := retryPolicy{
+		maxRetries:  defaultMaxRetries,
+		baseBackoff: retryBaseBackoff,
+		maxBackoff:  defaultRetryMaxBackoff,
 	}
-
-	if !config.ManagedAccountUID.IsNull() {
-		managedAccountUID = config.ManagedAccountUID.ValueString()
+	if !config.MaxRetries.IsNull() {
+		retry.maxRetries = int(config.MaxRetries.ValueInt64())
 	}
-
-	if !config.TermsAccepted.IsNull() {
-		acceptTerms = config.TermsAccepted.ValueBool()
+	if !config.RetryMaxBackoff.IsNull() {
+		retry.maxBackoff = time.Duration(config.RetryMaxBackoff.ValueInt64()) * time.Second
 	}
 
-	if !config.WaitTime.IsNull() {
-		waitTime = int(config.WaitTime.ValueInt64())
+	requestsPerSecond := defaultRequestsPerSecond
+	burst := defaultRateLimitBurst
+	if !config.RequestsPerSecond.IsNull() {
+		requestsPerSecond = int(config.RequestsPerSecond.ValueInt64())
+	}
+	if !config.Burst.IsNull() {
+		burst = int(config.Burst.ValueInt64())
 	}
 
 	ctx = tflog.SetField(ctx, "environment", environment)
@@ -223,6 +379,10 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 	ctx = tflog.SetField(ctx, "terms_accepted", acceptTerms)
 	ctx = tflog.SetField(ctx, "wait_time", waitTime)
 	ctx = tflog.SetField(ctx, "managed_account_uid", managedAccountUID)
+	ctx = tflog.SetField(ctx, "max_retries", retry.maxRetries)
+	ctx = tflog.SetField(ctx, "retry_max_backoff", retry.maxBackoff.String())
+	ctx = tflog.SetField(ctx, "requests_per_second", requestsPerSecond)
+	ctx = tflog.SetField(ctx, "burst", burst)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "secret_key", "access_key")
 
 	tflog.Debug(ctx, "Creating Megaport client")
@@ -243,7 +403,7 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 		resp.Diagnostics.AddAttributeError(
 			path.Root("environment"),
 			"Invalid Megaport environment",
-			fmt.Sprintf("The provider cannot create the Megaport API client as there is an invalid value for the environment: \"%s\")", environment),
+			fmt.Sprintf("The provider cannot create the Megaport API client as there is an invalid value for the environment: \"%s\" (from %s)", environment, settings.environmentSource),
 		)
 	}
 
@@ -252,8 +412,9 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 			path.Root("access_key"),
 			"Missing Megaport API access key",
 			"The provider cannot create the Megaport API client as there is a missing or empty value for the Megaport API access key. "+
-				"Set the access_key value in the configuration or use the MEGAPORT_ACCESS_KEY environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the access_key value in the configuration, use the MEGAPORT_ACCESS_KEY environment variable, "+
+				"or add access_key to "+settings.profileSource()+". "+
+				"If any of these is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -262,8 +423,9 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 			path.Root("secret_key"),
 			"Missing Megaport API secret key",
 			"The provider cannot create the Megaport API client as there is a missing or empty value for the Megaport API secret key. "+
-				"Set the secret_key value in the configuration or use the MEGAPORT_SECRET_KEY environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the secret_key value in the configuration, use the MEGAPORT_SECRET_KEY environment variable, "+
+				"or add secret_key to "+settings.profileSource()+". "+
+				"If any of these is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -284,7 +446,6 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 	// Build a useragent string with some useful information about the client
 	userAgent := fmt.Sprintf("Terraform/%s terraform-provider-megaport/%s go/%s (%s %s)", req.TerraformVersion, p.version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
 
-	waitForTime = (time.Duration(waitTime) * time.Minute)
 	clientOpts := []megaport.ClientOpt{
 		megaport.WithEnvironment(megaportGoEnv),
 		megaport.WithCredentials(accessKey, secretKey),
@@ -297,7 +458,11 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 	if managedAccountUID != "" {
 		clientOpts = append(clientOpts, megaport.WithCallContext(managedAccountUID))
 	}
-	megaportClient, err := megaport.New(nil, clientOpts...)
+	clientOpts = append(clientOpts, p.clientOpts...)
+	// Every provider instance shares one limiter across all of its resources
+	// and data sources.
+	httpClient := newAPIHTTPClient(retry, newRateLimiter(requestsPerSecond, burst))
+	megaportClient, err := megaport.New(httpClient, clientOpts...)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Megaport API Client",
@@ -322,11 +487,15 @@ func (p *megaportProvider) Configure(ctx context.Context, req provider.Configure
 	// Make the Megaport client available during DataSource and Resource
 	// type Configure methods.
 	providerData := &megaportProviderData{
-		client: megaportClient,
+		client:       megaportClient,
+		waitTime:     time.Duration(waitTime) * time.Minute,
+		pollInterval: pollIntervalOrDefault(p.pollInterval),
 	}
 
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+	resp.ListResourceData = providerData
 
 	tflog.Info(ctx, "Configured Megaport API client", map[string]any{"success": true})
 }
@@ -343,6 +512,37 @@ func (p *megaportProvider) DataSources(_ context.Context) []func() datasource.Da
 		NewMVEsDataSource,
 		NewVXCsDataSource,
 		NewNATGatewaySessionsDataSource,
+		NewVXCBGPStatusDataSource,
+		NewPortLOADataSource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *megaportProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider.
+func (p *megaportProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewPortListResource,
+		NewMCRListResource,
+		NewMVEListResource,
+		NewVXCListResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *megaportProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewNormalizeCIDRFunction,
+		NewPrefixGeLeFunction,
+		NewPrefixFilterListImportIDFunction,
+		NewParsePrefixFilterListImportIDFunction,
+		NewNATGatewayChildImportIDFunction,
+		NewParseNATGatewayChildImportIDFunction,
 	}
 }
 
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRequestsPerSecond is the sustained API request rate when the
+	// provider's requests_per_second is unset.
+	defaultRequestsPerSecond = 10
+	// defaultRateLimitBurst is the number of requests that can be sent at once
+	// when the provider's burst is unset.
+	defaultRateLimitBurst = 10
+)
+
+// rateLimiter is a token bucket shared by every request the provider sends to
+// the Megaport API. Tokens are refilled lazily from the elapsed time when a
+// caller asks for one, so the limiter owns no goroutines or tickers and needs
+// no shutdown: a caller waiting for a token returns as soon as its context is
+// cancelled, which is how Terraform stops in-flight operations.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a limiter allowing requestsPerSecond on average, with
+// up to burst requests at once. The bucket starts full.
+func newRateLimiter(requestsPerSecond, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(requestsPerSecond),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token if one is available. Otherwise it returns how long
+// until the next token is due.
+func (l *rateLimiter) reserve() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second)), false
+}
+
+// wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		delay, ok := l.reserve()
+		if ok {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// rateLimitTransport is an http.RoundTripper that takes a token from the
+// shared limiter before each request is sent.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rateLimiter
+}
+
+// newRateLimitTransport wraps next with the limiter.
+func newRateLimitTransport(next http.RoundTripper, limiter *rateLimiter) *rateLimitTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitTransport{next: next, limiter: limiter}
+}
+
+// RoundTrip waits for a token, then sends the request.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// newAPIHTTPClient returns the HTTP client used by the Megaport API client.
+// Retries sit outside the rate limiter so that every attempt takes a token.
+func newAPIHTTPClient(retry retryPolicy, limiter *rateLimiter) *http.Client {
+	return &http.Client{
+		Transport: newRetryTransport(newRateLimitTransport(http.DefaultTransport, limiter), retry),
+	}
+}
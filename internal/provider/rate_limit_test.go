@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tryTake takes a token without waiting.
+func tryTake(l *rateLimiter) bool {
+	_, ok := l.reserve()
+	return ok
+}
+
+func TestRateLimiter_BurstLimit(t *testing.T) {
+	l := newRateLimiter(1, 5)
+
+	for i := range 5 {
+		assert.True(t, tryTake(l), "token %d should be available within the burst", i+1)
+	}
+	assert.False(t, tryTake(l), "got a token beyond the burst")
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	l := newRateLimiter(20, 2)
+	assert.True(t, tryTake(l))
+	assert.True(t, tryTake(l))
+
+	delay, ok := l.reserve()
+	require.False(t, ok)
+	assert.LessOrEqual(t, delay, 50*time.Millisecond)
+
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, tryTake(l), "a token should be refilled after 1/rate seconds")
+}
+
+func TestRateLimiter_RefillCappedAtBurst(t *testing.T) {
+	l := newRateLimiter(1000, 3)
+	time.Sleep(20 * time.Millisecond)
+
+	count := 0
+	for tryTake(l) {
+		count++
+		if count > 10 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count, "idle time must not accumulate more than burst tokens")
+}
+
+func TestRateLimiter_Concurrent(t *testing.T) {
+	l := newRateLimiter(1, 10)
+	var wg sync.WaitGroup
+	var successes int32
+
+	for range 20 {
+		wg.Go(func() {
+			if tryTake(l) {
+				atomic.AddInt32(&successes, 1)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(10), atomic.LoadInt32(&successes))
+}
+
+func TestRateLimiter_WaitRate(t *testing.T) {
+	l := newRateLimiter(50, 1)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 6 {
+		require.NoError(t, l.wait(ctx))
+	}
+	elapsed := time.Since(start)
+
+	// One token is available immediately and the other five arrive every 20ms.
+	assert.GreaterOrEqual(t, elapsed, 90*time.Millisecond)
+	assert.Less(t, elapsed, 500*time.Millisecond)
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	require.True(t, tryTake(l))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- l.wait(ctx) }()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("wait did not return after its context was cancelled")
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	t.Cleanup(server.Close)
+
+	l := newRateLimiter(1, 2)
+	client := &http.Client{Transport: newRateLimitTransport(nil, l)}
+
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	// The bucket is empty, so the next request waits beyond the deadline and is
+	// never sent.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestAPIHTTPClient_RetriesTakeTokens(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	l := newRateLimiter(1, 2)
+	client := newAPIHTTPClient(testRetryPolicy, l)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.False(t, tryTake(l), "the retry should have used the second token")
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// productIdentityModel is the resource identity of Megaport products, which
+// are addressed by their product UID.
+type productIdentityModel struct {
+	ProductUID types.String `tfsdk:"product_uid"`
+}
+
+// productIdentitySchema returns the identity schema shared by product resources.
+func productIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"product_uid": identityschema.StringAttribute{
+				Description:       "The unique identifier of the product.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// mcrIPsecAddOnIdentityModel is the resource identity of an MCR IPSec add-on.
+type mcrIPsecAddOnIdentityModel struct {
+	MCRUID   types.String `tfsdk:"mcr_uid"`
+	AddOnUID types.String `tfsdk:"add_on_uid"`
+}
+
+// mcrIPsecAddOnIdentitySchema returns the identity schema of megaport_mcr_ipsec_addon.
+func mcrIPsecAddOnIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"mcr_uid": identityschema.StringAttribute{
+				Description:       "The product UID of the MCR the add-on belongs to.",
+				RequiredForImport: true,
+			},
+			"add_on_uid": identityschema.StringAttribute{
+				Description:       "The UID of the IPSec add-on.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// mcrPrefixFilterListIdentityModel is the resource identity of an MCR prefix
+// filter list.
+type mcrPrefixFilterListIdentityModel struct {
+	MCRUID       types.String `tfsdk:"mcr_uid"`
+	PrefixListID types.Int64  `tfsdk:"prefix_list_id"`
+}
+
+// mcrPrefixFilterListIdentitySchema returns the identity schema of
+// megaport_mcr_prefix_filter_list.
+func mcrPrefixFilterListIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"mcr_uid": identityschema.StringAttribute{
+				Description:       "The product UID of the MCR the prefix filter list belongs to.",
+				RequiredForImport: true,
+			},
+			"prefix_list_id": identityschema.Int64Attribute{
+				Description:       "The numeric ID of the prefix filter list.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// natGatewayChildIdentityModel is the resource identity of objects owned by a
+// NAT Gateway, such as prefix lists and packet filters.
+type natGatewayChildIdentityModel struct {
+	NATGatewayProductUID types.String `tfsdk:"nat_gateway_product_uid"`
+	ID                   types.Int64  `tfsdk:"id"`
+}
+
+// natGatewayChildIdentitySchema returns the identity schema shared by NAT
+// Gateway child resources.
+func natGatewayChildIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"nat_gateway_product_uid": identityschema.StringAttribute{
+				Description:       "The product UID of the NAT Gateway.",
+				RequiredForImport: true,
+			},
+			"id": identityschema.Int64Attribute{
+				Description:       "The numeric ID of the object on the NAT Gateway.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setIdentity records v as the resource identity. It is a no-op when Terraform
+// did not request identity data.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, v any) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, v)
+}
+
+// setProductIdentity records the product UID as the resource identity.
+func setProductIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, uid types.String) diag.Diagnostics {
+	return setIdentity(ctx, identity, productIdentityModel{ProductUID: uid})
+}
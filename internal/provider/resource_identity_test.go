@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importByIdentity calls ImportState on r the way Terraform does for an import
+// block with an identity attribute instead of an id.
+func importByIdentity(t *testing.T, r resource.ResourceWithIdentity, identity map[string]tftypes.Value) *resource.ImportStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	identitySchemaResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identitySchemaResp)
+	require.False(t, identitySchemaResp.Diagnostics.HasError())
+
+	identityRaw := tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), identity)
+	req := resource.ImportStateRequest{
+		Identity: &tfsdk.ResourceIdentity{Schema: identitySchemaResp.IdentitySchema, Raw: identityRaw},
+	}
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+		Identity: &tfsdk.ResourceIdentity{Schema: identitySchemaResp.IdentitySchema, Raw: identityRaw.Copy()},
+	}
+
+	r.(resource.ResourceWithImportState).ImportState(ctx, req, resp)
+	return resp
+}
+
+func TestResourceIdentitySchemas(t *testing.T) {
+	expected := map[string][]string{
+		"megaport_port":                      {"product_uid"},
+		"megaport_lag_port":                  {"product_uid"},
+		"megaport_mcr":                       {"product_uid"},
+		"megaport_mve":                       {"product_uid"},
+		"megaport_vxc":                       {"product_uid"},
+		"megaport_ix":                        {"product_uid"},
+		"megaport_nat_gateway":               {"product_uid"},
+		"megaport_mcr_ipsec_addon":           {"add_on_uid", "mcr_uid"},
+		"megaport_mcr_prefix_filter_list":    {"mcr_uid", "prefix_list_id"},
+		"megaport_nat_gateway_prefix_list":   {"id", "nat_gateway_product_uid"},
+		"megaport_nat_gateway_packet_filter": {"id", "nat_gateway_product_uid"},
+		// Service keys are addressed by the key itself, which is secret, so
+		// megaport_service_key has no identity.
+		"megaport_service_key": nil,
+	}
+
+	ctx := context.Background()
+	seen := map[string]bool{}
+	for _, newResource := range New("test")().Resources(ctx) {
+		r := newResource()
+		metaResp := &resource.MetadataResponse{}
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "megaport"}, metaResp)
+		name := metaResp.TypeName
+
+		t.Run(name, func(t *testing.T) {
+			withIdentity, ok := r.(resource.ResourceWithIdentity)
+			if expected[name] == nil {
+				require.False(t, ok, "%s should not implement IdentitySchema", name)
+				return
+			}
+			require.True(t, ok, "%s should implement IdentitySchema", name)
+
+			identityResp := &resource.IdentitySchemaResponse{}
+			withIdentity.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+			require.False(t, identityResp.Diagnostics.HasError())
+
+			attrs := []string{}
+			for attrName := range identityResp.IdentitySchema.Attributes {
+				attrs = append(attrs, attrName)
+			}
+			assert.ElementsMatch(t, expected[name], attrs)
+		})
+		seen[name] = true
+	}
+
+	for name := range expected {
+		assert.True(t, seen[name], "%s is not registered with the provider", name)
+	}
+}
+
+func TestImportStateByIdentity_Product(t *testing.T) {
+	resp := importByIdentity(t, &natGatewayResource{}, map[string]tftypes.Value{
+		"product_uid": tftypes.NewValue(tftypes.String, "nat-1"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	var uid types.String
+	require.False(t, resp.State.GetAttribute(context.Background(), path.Root("product_uid"), &uid).HasError())
+	assert.Equal(t, "nat-1", uid.ValueString())
+}
+
+func TestImportStateByIdentity_NATGatewayChild(t *testing.T) {
+	resp := importByIdentity(t, &natGatewayPacketFilterResource{}, map[string]tftypes.Value{
+		"nat_gateway_product_uid": tftypes.NewValue(tftypes.String, "nat-1"),
+		"id":                      tftypes.NewValue(tftypes.Number, 42),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	ctx := context.Background()
+	var natUID types.String
+	var id types.Int64
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("nat_gateway_product_uid"), &natUID).HasError())
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	assert.Equal(t, "nat-1", natUID.ValueString())
+	assert.Equal(t, int64(42), id.ValueInt64())
+}
+
+func TestImportStateByIdentity_MCRIPsecAddOn(t *testing.T) {
+	mock := &MockMCRService{
+		GetMCRResult: &megaport.MCR{
+			UID: "mcr-1",
+			AddOns: []*megaport.MCRAddOnIPsecConfig{
+				{AddOnType: megaport.AddOnTypeIPsec, AddOnUID: "addon-1", TunnelCount: 20},
+			},
+		},
+	}
+	r := &mcrIpsecAddonResource{client: &megaport.Client{MCRService: mock}}
+
+	resp := importByIdentity(t, r, map[string]tftypes.Value{
+		"mcr_uid":    tftypes.NewValue(tftypes.String, "mcr-1"),
+		"add_on_uid": tftypes.NewValue(tftypes.String, "addon-1"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+	assert.Equal(t, "mcr-1", mock.CapturedGetMCRID)
+
+	var state mcrIpsecAddonResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	assert.Equal(t, "mcr-1", state.MCRID.ValueString())
+	assert.Equal(t, "addon-1", state.AddOnUID.ValueString())
+	assert.Equal(t, int64(20), state.TunnelCount.ValueInt64())
+}
+
+func TestSetIdentity_NilIdentity(t *testing.T) {
+	diags := setProductIdentity(context.Background(), nil, types.StringValue("port-1"))
+	assert.False(t, diags.HasError())
+}
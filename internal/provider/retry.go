@@ -1,50 +1,252 @@
 package provider
 
 import (
-	"context"
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	megaport "github.com/megaport/megaportgo"
 )
 
-// retryTransientDelete retries a delete operation when the API returns transient
-// backend errors like "Transaction silently rolled back because it has been marked
-// as rollback-only". These are server-side transaction conflicts that typically
-// succeed on retry.
+const (
+	// defaultMaxRetries is the number of times a failed API request is retried
+	// when the provider's max_retries is unset.
+	defaultMaxRetries = 3
+	// defaultRetryMaxBackoff caps the wait between retries when the provider's
+	// retry_max_backoff is unset.
+	defaultRetryMaxBackoff = 30 * time.Second
+	// retryBaseBackoff is the wait before the first retry, doubled on each
+	// subsequent attempt.
+	retryBaseBackoff = time.Second
+)
+
+// retryPolicy controls how API requests that fail with a transient error are
+// retried.
+type retryPolicy struct {
+	maxRetries  int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+// isRetryableError reports whether an API error is transient and the request
+// that caused it can safely be sent again.
 //
-//nolint:unparam // maxAttempts is constant today but callers may vary it in future
-func retryTransientDelete(ctx context.Context, maxAttempts int, fn func() error) error {
-	if maxAttempts < 1 {
-		maxAttempts = 1
-	}
-	var err error
-	for attempt := range maxAttempts {
-		err = fn()
-		if err == nil {
-			return nil
-		}
-		if !isTransientDeleteError(err) {
-			return err
-		}
-		if attempt < maxAttempts-1 {
-			tflog.Debug(ctx, "Transient delete error, retrying",
-				map[string]interface{}{
-					"attempt": attempt + 1,
-					"error":   err.Error(),
-				})
-			select {
-			case <-time.After(time.Duration(attempt+1) * 2 * time.Second):
-			case <-ctx.Done():
-				return ctx.Err()
-			}
-		}
+// Rate limiting (429) and rolled back transactions are retried for every
+// method, as the API did not act on the request. Gateway errors (502, 503 and
+// 504) are only retried for idempotent methods, because a POST may have been
+// processed before the gateway gave up on it and repeating it could place a
+// duplicate order.
+func isRetryableError(err error) bool {
+	var apiErr *megaport.ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.Response == nil {
+		return false
+	}
+	if isTransientTransactionError(apiErr.Message) || isTransientTransactionError(apiErr.Data) {
+		return true
+	}
+	switch apiErr.Response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return apiErr.Response.Request != nil && isIdempotentMethod(apiErr.Response.Request.Method)
 	}
-	return err
+	return false
 }
 
-func isTransientDeleteError(err error) bool {
-	msg := err.Error()
+// isTransientTransactionError reports whether an API error message describes a
+// server-side transaction conflict, such as "Transaction silently rolled back
+// because it has been marked as rollback-only", which succeeds on retry.
+func isTransientTransactionError(msg string) bool {
 	return strings.Contains(msg, "rollback-only") ||
 		strings.Contains(msg, "Transaction silently rolled back")
 }
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// backoff returns how long to wait before the given retry attempt, counting
+// from zero. The delay grows exponentially up to maxBackoff, with up to half
+// of it replaced by random jitter so that parallel operations hitting the same
+// error do not retry in lockstep.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	d := p.maxBackoff
+	if attempt < 32 {
+		if exp := p.baseBackoff << attempt; exp > 0 && exp < p.maxBackoff {
+			d = exp
+		}
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return half + rand.N(half+1)
+}
+
+// delay returns how long to wait before retrying a response. A Retry-After
+// header from the API takes precedence over the computed backoff.
+func (p retryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d
+		}
+	}
+	return p.backoff(attempt)
+}
+
+// parseRetryAfter parses a Retry-After header, given either as a number of
+// seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// retryTransport is an http.RoundTripper that retries Megaport API requests
+// failing with a transient error, so that every resource and data source
+// operation gets the same behaviour without wrapping each call.
+type retryTransport struct {
+	next   http.RoundTripper
+	policy retryPolicy
+}
+
+// newRetryTransport wraps next with the retry policy.
+func newRetryTransport(next http.RoundTripper, policy retryPolicy) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{next: next, policy: policy}
+}
+
+// RoundTrip sends the request, retrying it while the response is classified as
+// transient, retries remain and the request context allows for the wait. A
+// Retry-After longer than maxBackoff is treated as the API asking us to stop,
+// and the error is returned instead.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			var err error
+			if req, err = rewindRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			// The request may not have been sent, but without a response
+			// there is nothing to classify; leave it to the caller.
+			return nil, err
+		}
+
+		if resp.Request == nil {
+			resp.Request = req
+		}
+		apiErr, err := peekAPIError(resp)
+		if err != nil {
+			return nil, err
+		}
+		if apiErr == nil || !isRetryableError(apiErr) || attempt >= t.policy.maxRetries || !canRewind(req) {
+			return resp, nil
+		}
+
+		wait := t.policy.delay(attempt, resp)
+		if wait > t.policy.maxBackoff {
+			return resp, nil
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			tflog.Debug(ctx, "Not retrying Megaport API request, the operation deadline would pass first", map[string]interface{}{
+				"method":      req.Method,
+				"path":        req.URL.Path,
+				"status_code": resp.StatusCode,
+				"retry_in":    wait.String(),
+			})
+			return resp, nil
+		}
+
+		tflog.Warn(ctx, "Transient Megaport API error, retrying", map[string]interface{}{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"status_code": resp.StatusCode,
+			"attempt":     attempt + 1,
+			"max_retries": t.policy.maxRetries,
+			"retry_in":    wait.String(),
+			"error":       apiErr.Error(),
+		})
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// peekAPIError decodes a non-2xx response into the error megaportgo will report
+// for it, leaving the response body readable for megaportgo.
+func peekAPIError(resp *http.Response) (*megaport.ErrorResponse, error) {
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	peek := *resp
+	peek.Body = io.NopCloser(bytes.NewReader(body))
+	var apiErr *megaport.ErrorResponse
+	if errors.As(megaport.CheckResponse(&peek), &apiErr) {
+		apiErr.Response = resp
+		return apiErr, nil
+	}
+	return nil, nil
+}
+
+// canRewind reports whether the request body can be sent again.
+func canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewindRequest returns a copy of req with a fresh body for another attempt.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retry := req.Clone(req.Context())
+	retry.Body = body
+	return retry, nil
+}
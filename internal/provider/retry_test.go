@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryPolicy retries quickly so tests don't sleep for real backoffs.
+var testRetryPolicy = retryPolicy{
+	maxRetries:  3,
+	baseBackoff: time.Millisecond,
+	maxBackoff:  5 * time.Millisecond,
+}
+
+// retryTestServer returns a server that answers with each of responses in turn,
+// repeating the last one, and counts the requests and bodies it received.
+func retryTestServer(t *testing.T, responses ...func(w http.ResponseWriter)) (*httptest.Server, *int32, *[]string) {
+	t.Helper()
+	var calls int32
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1))
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if n > len(responses) {
+			n = len(responses)
+		}
+		responses[n-1](w)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, &bodies
+}
+
+func respond(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	rolledBack := `{"message":"Transaction silently rolled back because it has been marked as rollback-only"}`
+
+	tests := []struct {
+		name       string
+		method     string
+		responses  []func(w http.ResponseWriter)
+		wantStatus int
+		wantCalls  int32
+	}{
+		{
+			name:       "rate limited then ok",
+			method:     http.MethodPost,
+			responses:  []func(w http.ResponseWriter){respond(http.StatusTooManyRequests, `{"message":"slow down"}`), respond(http.StatusOK, `{}`)},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "gateway error on read is retried",
+			method:     http.MethodGet,
+			responses:  []func(w http.ResponseWriter){respond(http.StatusServiceUnavailable, ``), respond(http.StatusBadGateway, ``), respond(http.StatusOK, `{}`)},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		{
+			name:       "gateway error on order is not retried",
+			method:     http.MethodPost,
+			responses:  []func(w http.ResponseWriter){respond(http.StatusBadGateway, ``), respond(http.StatusOK, `{}`)},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  1,
+		},
+		{
+			name:       "rolled back transaction on delete",
+			method:     http.MethodPost,
+			responses:  []func(w http.ResponseWriter){respond(http.StatusInternalServerError, rolledBack), respond(http.StatusOK, `{}`)},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		{
+			name:       "client error is not retried",
+			method:     http.MethodPut,
+			responses:  []func(w http.ResponseWriter){respond(http.StatusBadRequest, `{"message":"invalid vlan"}`)},
+			wantStatus: http.StatusBadRequest,
+			wantCalls:  1,
+		},
+		{
+			name:       "retries exhausted",
+			method:     http.MethodGet,
+			responses:  []func(w http.ResponseWriter){respond(http.StatusTooManyRequests, `{"message":"slow down"}`)},
+			wantStatus: http.StatusTooManyRequests,
+			wantCalls:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls, bodies := retryTestServer(t, tt.responses...)
+			client := &http.Client{Transport: newRetryTransport(nil, testRetryPolicy)}
+
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader(`{"productUid":"abc"}`))
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(calls))
+			for _, body := range *bodies {
+				assert.Equal(t, `{"productUid":"abc"}`, body, "request body should be replayed on every attempt")
+			}
+		})
+	}
+}
+
+func TestRetryTransport_ErrorBodyStillReadable(t *testing.T) {
+	server, _, _ := retryTestServer(t, respond(http.StatusBadRequest, `{"message":"invalid vlan","data":"vlan 5000"}`))
+
+	client, err := megaport.New(&http.Client{Transport: newRetryTransport(nil, testRetryPolicy)},
+		megaport.WithBaseURL(server.URL),
+		megaport.WithAccessToken("test-token", time.Now().Add(time.Hour)),
+	)
+	require.NoError(t, err)
+
+	_, err = client.VXCService.GetVXC(context.Background(), "vxc-1")
+	require.Error(t, err)
+	apiErr, ok := err.(*megaport.ErrorResponse)
+	require.True(t, ok, "expected a megaport.ErrorResponse, got %T", err)
+	assert.Equal(t, "invalid vlan", apiErr.Message)
+	assert.Equal(t, "vlan 5000", apiErr.Data)
+}
+
+func TestRetryTransport_DeadlineStopsRetries(t *testing.T) {
+	server, calls, _ := retryTestServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	policy := retryPolicy{maxRetries: 3, baseBackoff: time.Millisecond, maxBackoff: time.Minute}
+	client := &http.Client{Transport: newRetryTransport(nil, policy)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "should not wait when the deadline would pass first")
+}
+
+func TestRetryTransport_RetryAfterBeyondMaxBackoff(t *testing.T) {
+	server, calls, _ := retryTestServer(t, func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	client := &http.Client{Transport: newRetryTransport(nil, testRetryPolicy)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_Disabled(t *testing.T) {
+	server, calls, _ := retryTestServer(t, respond(http.StatusTooManyRequests, ``))
+	client := &http.Client{Transport: newRetryTransport(nil, retryPolicy{maxBackoff: time.Second})}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{value: "", wantOK: false},
+		{value: "5", want: 5 * time.Second, wantOK: true},
+		{value: "0", want: 0, wantOK: true},
+		{value: "-1", wantOK: false},
+		{value: "soon", wantOK: false},
+		{value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second, wantOK: true},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := retryPolicy{baseBackoff: time.Second, maxBackoff: 10 * time.Second}
+
+	for attempt, ceiling := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		for range 20 {
+			d := p.backoff(attempt)
+			assert.GreaterOrEqual(t, d, ceiling/2, "attempt %d", attempt)
+			assert.LessOrEqual(t, d, ceiling, "attempt %d", attempt)
+		}
+	}
+
+	// Large attempt counts must not overflow past the cap.
+	assert.LessOrEqual(t, p.backoff(100), 10*time.Second)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	apiErr := func(method string, status int, message string) error {
+		return &megaport.ErrorResponse{
+			Response: &http.Response{StatusCode: status, Request: &http.Request{Method: method}},
+			Message:  message,
+		}
+	}
+
+	assert.True(t, isRetryableError(apiErr(http.MethodPost, http.StatusTooManyRequests, "")))
+	assert.True(t, isRetryableError(apiErr(http.MethodGet, http.StatusGatewayTimeout, "")))
+	assert.False(t, isRetryableError(apiErr(http.MethodPost, http.StatusGatewayTimeout, "")))
+	assert.True(t, isRetryableError(apiErr(http.MethodPost, http.StatusBadRequest, "Transaction silently rolled back")))
+	assert.False(t, isRetryableError(apiErr(http.MethodGet, http.StatusNotFound, "not found")))
+	assert.False(t, isRetryableError(io.ErrUnexpectedEOF))
+}
+
+// Deletes that hit a rolled back transaction used to be retried by the
+// resources themselves; the retry transport now covers them for every
+// request, including the product action a delete is sent as.
+func TestRetryTransport_RollbackOnlyDelete(t *testing.T) {
+	t.Parallel()
+	const rollbackOnly = "Transaction silently rolled back because it has been marked as rollback-only"
+
+	t.Run("succeeds once the transaction commits", func(t *testing.T) {
+		t.Parallel()
+		api := newFakeMegaportAPI(t)
+		uid := api.seedPort("Rollback Port")
+		fault := &fakeFault{Method: http.MethodPost, Path: "/v3/product/" + uid + "/action/CANCEL_NOW", Times: 2, Status: http.StatusInternalServerError, Message: rollbackOnly}
+		api.inject(fault)
+
+		_, err := api.client(t).PortService.DeletePort(context.Background(), &megaport.DeletePortRequest{PortID: uid, DeleteNow: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, api.hits(fault))
+		port, _ := api.product(uid)
+		assert.Equal(t, megaport.STATUS_DECOMMISSIONED, port["provisioningStatus"])
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		t.Parallel()
+		api := newFakeMegaportAPI(t)
+		uid := api.seedPort("Rollback Port")
+		fault := &fakeFault{Method: http.MethodPost, Path: "/v3/product/" + uid + "/action/CANCEL_NOW", Status: http.StatusInternalServerError, Message: rollbackOnly}
+		api.inject(fault)
+
+		_, err := api.client(t).PortService.DeletePort(context.Background(), &megaport.DeletePortRequest{PortID: uid, DeleteNow: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "rollback-only")
+		assert.Equal(t, testRetryPolicy.maxRetries+1, api.hits(fault))
+		port, _ := api.product(uid)
+		assert.Equal(t, megaport.SERVICE_LIVE, port["provisioningStatus"])
+	})
+}
@@ -261,3 +261,56 @@ func TestAccMegaportServiceKey_ValidFor(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitMegaportServiceKey_Basic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	config := func(active bool) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_port" "port" {
+			product_name           = "Unit Test Port"
+			port_speed             = 1000
+			location_id            = %d
+			contract_term_months   = 1
+			marketplace_visibility = false
+		}
+		resource "megaport_service_key" "test" {
+			product_uid = megaport_port.port.product_uid
+			description = "Unit Test Key"
+			max_speed   = 500
+			single_use  = false
+			active      = %t
+		}`, fakeLocationID, active)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_service_key.test", "description", "Unit Test Key"),
+					resource.TestCheckResourceAttr("megaport_service_key.test", "max_speed", "500"),
+					resource.TestCheckResourceAttr("megaport_service_key.test", "active", "true"),
+					resource.TestCheckResourceAttrPair("megaport_service_key.test", "product_uid", "megaport_port.port", "product_uid"),
+					resource.TestCheckResourceAttrSet("megaport_service_key.test", "key"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_service_key.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "key",
+				ImportStateIdFunc:                    importStateAttr("megaport_service_key.test", "key"),
+				ImportStateVerifyIgnore:              []string{"last_updated"},
+			},
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("megaport_service_key.test", "active", "false"),
+			},
+		},
+	})
+}
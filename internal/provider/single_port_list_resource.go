@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &portResource{}
+	_ list.ListResourceWithConfigure = &portResource{}
+)
+
+// NewPortListResource is a helper function to simplify the provider implementation.
+func NewPortListResource() list.ListResource {
+	return &portResource{}
+}
+
+// ListResourceConfigSchema defines the filters accepted by `terraform query`.
+func (r *portResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the active single ports in the account so they can be imported. LAG ports are not included.",
+		Attributes:  productListSchemaAttributes("port"),
+	}
+}
+
+// List streams the active single ports in the account.
+func (r *portResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config productListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	ports, err := r.client.PortService.ListPorts(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error listing ports",
+			"Could not list ports: "+err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, port := range ports {
+			if listLimitReached(req, count) {
+				return
+			}
+			// LAG ports are managed by megaport_lag_port.
+			if isInactiveProduct(port.ProvisioningStatus) || port.LAGPrimary || port.LAGID != 0 {
+				continue
+			}
+			if !config.matches(port.Name, port.LocationID) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = port.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, productIdentityModel{ProductUID: types.StringValue(port.UID)})...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				var state singlePortResourceModel
+				result.Diagnostics.Append(newListResultModel(ctx, req, &state)...)
+
+				tags, err := r.client.PortService.ListPortResourceTags(ctx, port.UID)
+				if err != nil {
+					result.Diagnostics.AddWarning(
+						"Error reading port tags",
+						fmt.Sprintf("Could not read port tags with ID %s: %s", port.UID, err.Error()),
+					)
+				}
+
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(state.fromAPIPort(ctx, port, tags)...)
+					result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+				}
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
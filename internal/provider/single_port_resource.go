@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -28,6 +29,8 @@ var (
 	_ resource.Resource                = &portResource{}
 	_ resource.ResourceWithConfigure   = &portResource{}
 	_ resource.ResourceWithImportState = &portResource{}
+	_ resource.ResourceWithIdentity    = &portResource{}
+	_ resource.ResourceWithModifyPlan  = &portResource{}
 
 	portResourcesAttrs = map[string]attr.Type{
 		"interface": types.ObjectType{}.WithAttributeTypes(portInterfaceAttrs),
@@ -72,6 +75,8 @@ type singlePortResourceModel struct {
 	Resources types.Object `tfsdk:"resources"`
 
 	ResourceTags types.Map `tfsdk:"resource_tags"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type portResourcesModel struct {
@@ -157,7 +162,9 @@ func NewPortResource() resource.Resource {
 
 // portResource is the resource implementation.
 type portResource struct {
-	client *megaport.Client
+	client       *megaport.Client
+	waitTime     time.Duration
+	pollInterval time.Duration
 }
 
 // Metadata returns the resource type name.
@@ -166,7 +173,7 @@ func (r *portResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *portResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *portResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Single Port Resource for the Megaport Terraform Provider. This can be used to create, modify, and delete Megaport Ports. Your organization’s Port is the physical point of connection between your organization’s network and the Megaport network. You will need to deploy a Port wherever you want to direct traffic.",
 		Attributes: map[string]schema.Attribute{
@@ -208,7 +215,7 @@ func (r *portResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"port_speed": schema.Int64Attribute{
-				Description: "The speed of the port in Mbps. Can be 1000(1g), 10000 (10 G), 100000 (100 G), or 400000 (400G) where available.",
+				Description: "The speed of the port in Mbps. Can be 1000(1g), 10000 (10 G), 100000 (100 G), or 400000 (400G) where available. Changing it replaces the port, and the plan fails if the port's location has no capacity for the new speed in the port's diversity zone.",
 				Required:    true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.RequiresReplace(),
@@ -361,6 +368,9 @@ func (r *portResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -374,6 +384,12 @@ func (r *portResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	buyPortReq := &megaport.BuyPortRequest{
 		Name:                  plan.Name.ValueString(),
 		Term:                  int(plan.ContractTermMonths.ValueInt64()),
@@ -383,8 +399,6 @@ func (r *portResource) Create(ctx context.Context, req resource.CreateRequest, r
 		DiversityZone:         plan.DiversityZone.ValueString(),
 		CostCentre:            plan.CostCentre.ValueString(),
 		PromoCode:             plan.PromoCode.ValueString(),
-		WaitForProvision:      true,
-		WaitForTime:           waitForTime,
 	}
 
 	if !plan.ResourceTags.IsNull() {
@@ -414,6 +428,15 @@ func (r *portResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Wait for the port to be provisioned
+	if err := waitForProductReady(ctx, "Port", createdPort.TechnicalServiceUIDs, createTimeout, r.pollInterval, portStatus(r.client)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error buying port",
+			"Could not create port with name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
 	if len(createdPort.TechnicalServiceUIDs) != 1 {
 		resp.Diagnostics.AddError(
 			"Unexpected number of ports created",
@@ -451,6 +474,7 @@ func (r *portResource) Create(ctx context.Context, req resource.CreateRequest, r
 	// Set state to fully populated data
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -466,6 +490,14 @@ func (r *portResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed port value from API
 	port, err := r.client.PortService.GetPort(ctx, state.UID.ValueString())
 	if err != nil {
@@ -506,6 +538,7 @@ func (r *portResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -521,6 +554,12 @@ func (r *portResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Check on changes
 	var name, costCentre string
 	var marketplaceVisibility bool
@@ -548,9 +587,10 @@ func (r *portResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		MarketplaceVisibility: &marketplaceVisibility,
 		ContractTermMonths:    contractTermMonths,
 		CostCentre:            costCentre,
-		WaitForUpdate:         true,
-		WaitForTime:           waitForTime,
 	})
+	if modifyErr == nil {
+		modifyErr = waitForProductReady(ctx, "Port", []string{plan.UID.ValueString()}, updateTimeout, r.pollInterval, portStatus(r.client))
+	}
 	if modifyErr != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating port",
@@ -601,13 +641,36 @@ func (r *portResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	state.PromoCode = plan.PromoCode
+	state.Timeouts = plan.Timeouts
 
 	// Set state to fully populated data
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan checks a port_speed change against the speeds offered at the
+// port's location, so a replacement the location has no capacity for fails at
+// plan rather than after the old port has been destroyed.
+func (r *portResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var plan, state singlePortResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// A new location is checked by the API when the new port is ordered.
+	if plan.PortSpeed.IsUnknown() || plan.PortSpeed.Equal(state.PortSpeed) || !plan.LocationID.Equal(state.LocationID) {
+		return
+	}
+	resp.Diagnostics.Append(validateSpeedAtLocation(ctx, r.client, "port", int(state.LocationID.ValueInt64()), state.DiversityZone.ValueString(), int(plan.PortSpeed.ValueInt64()),
+		func(zone *megaport.LocationV3DiversityZone) []int { return zone.MegaportSpeedMbps })...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -621,16 +684,21 @@ func (r *portResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order. Ports only support immediate cancellation
 	// (CANCEL_NOW); delayed cancellation was removed in megaportgo and the
 	// API now rejects DeleteNow=false for ports.
-	err := retryTransientDelete(ctx, 3, func() error {
-		_, deleteErr := r.client.PortService.DeletePort(ctx, &megaport.DeletePortRequest{
-			PortID:     state.UID.ValueString(),
-			DeleteNow:  true,
-			SafeDelete: true,
-		})
-		return deleteErr
+	_, err := r.client.PortService.DeletePort(ctx, &megaport.DeletePortRequest{
+		PortID:     state.UID.ValueString(),
+		DeleteNow:  true,
+		SafeDelete: true,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -658,17 +726,24 @@ func (r *portResource) Configure(_ context.Context, req resource.ConfigureReques
 	}
 
 	r.client = data.client
+	r.waitTime = data.waitTime
+	r.pollInterval = data.pollInterval
 }
 
 func (r *portResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 
-	resource.ImportStatePassthroughID(ctx, path.Root("product_uid"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("product_uid"), path.Root("product_uid"), req, resp)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *portResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = productIdentitySchema()
+}
+
 func fromAPIPortInterface(ctx context.Context, p *megaport.PortInterface) (types.Object, diag.Diagnostics) {
 	portInterfaceModel := &portInterfaceModel{
 		Demarcation: types.StringValue(p.Demarcation),
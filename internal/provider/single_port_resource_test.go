@@ -2,9 +2,12 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -260,3 +263,148 @@ func TestAccMegaportSinglePort_ContractTermUpdate(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitMegaportSinglePort_Basic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				data "megaport_location" "loc" {
+					id = %d
+				}
+				resource "megaport_port" "port" {
+					product_name           = "Unit Test Port"
+					port_speed             = 1000
+					cost_centre            = "Unit Test"
+					location_id            = data.megaport_location.loc.id
+					contract_term_months   = 12
+					marketplace_visibility = true
+					diversity_zone         = "red"
+					resource_tags = {
+						"key1" = "value1"
+					}
+				}`, fakeLocationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.megaport_location.loc", "name", "Fake Data Centre"),
+					resource.TestCheckResourceAttr("megaport_port.port", "product_name", "Unit Test Port"),
+					resource.TestCheckResourceAttr("megaport_port.port", "port_speed", "1000"),
+					resource.TestCheckResourceAttr("megaport_port.port", "marketplace_visibility", "true"),
+					resource.TestCheckResourceAttr("megaport_port.port", "diversity_zone", "red"),
+					resource.TestCheckResourceAttr("megaport_port.port", "provisioning_status", "LIVE"),
+					resource.TestCheckResourceAttr("megaport_port.port", "resource_tags.key1", "value1"),
+					resource.TestCheckResourceAttrSet("megaport_port.port", "product_uid"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_port.port",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "product_uid",
+				ImportStateIdFunc:                    importStateAttr("megaport_port.port", "product_uid"),
+				ImportStateVerifyIgnore:              []string{"last_updated", "contract_start_date", "contract_end_date", "live_date", "resources", "provisioning_status"},
+			},
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				data "megaport_location" "loc" {
+					id = %d
+				}
+				resource "megaport_port" "port" {
+					product_name           = "Unit Test Port Renamed"
+					port_speed             = 1000
+					cost_centre            = "Unit Test Updated"
+					location_id            = data.megaport_location.loc.id
+					contract_term_months   = 12
+					marketplace_visibility = false
+					diversity_zone         = "red"
+					resource_tags = {
+						"key1" = "value1-updated"
+					}
+				}`, fakeLocationID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_port.port", "product_name", "Unit Test Port Renamed"),
+					resource.TestCheckResourceAttr("megaport_port.port", "cost_centre", "Unit Test Updated"),
+					resource.TestCheckResourceAttr("megaport_port.port", "marketplace_visibility", "false"),
+					resource.TestCheckResourceAttr("megaport_port.port", "resource_tags.key1", "value1-updated"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitMegaportSinglePort_SpeedChange replaces a port with one of a new
+// speed, after plan has rejected a speed the location does not offer.
+func TestUnitMegaportSinglePort_SpeedChange(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	config := func(speed int) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_port" "port" {
+			product_name           = "Speed Change Port"
+			port_speed             = %d
+			location_id            = %d
+			contract_term_months   = 12
+			marketplace_visibility = false
+			diversity_zone         = "red"
+		}`, speed, fakeLocationID)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(1000),
+				Check:  resource.TestCheckResourceAttr("megaport_port.port", "port_speed", "1000"),
+			},
+			{
+				Config:      config(400000),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`has\s+no\s+capacity\s+for\s+a\s+400000\s+Mbps\s+port\s+in\s+the\s+red\s+diversity\s+zone`),
+			},
+			{
+				Config: config(10000),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("megaport_port.port", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("megaport_port.port", "port_speed", "10000"),
+			},
+		},
+	})
+}
+
+// TestUnitMegaportSinglePort_EmptyDiversityZoneRead refreshes a port while the
+// API omits its diversity zone. diversityZoneFromAPI must keep the zone from
+// state, otherwise the RequiresReplace modifier would plan to replace the port.
+func TestUnitMegaportSinglePort_EmptyDiversityZoneRead(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedPort("Zoneless Port")
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: seededPortConfig(uid, "Zoneless Port"),
+				Check:  resource.TestCheckResourceAttr("megaport_port.port", "diversity_zone", "red"),
+			},
+			{
+				PreConfig: func() {
+					api.inject(&fakeFault{Method: http.MethodGet, Path: "/v2/product/" + uid, Mutate: withoutFields("diversityZone")})
+				},
+				Config:   seededPortConfig(uid, "Zoneless Port"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
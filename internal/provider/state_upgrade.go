@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// writeOnlySecretsStateUpgrader returns a StateUpgrader for state saved before
+// the secrets of a resource, such as BGP passwords and vendor config keys,
+// became write-only arguments. That state still holds the secrets, and
+// Terraform rejects a write-only attribute with a value in state, so the
+// upgrader reads the prior state against the current schema and nulls every
+// write-only attribute in it. The version attributes added with the write-only
+// secrets are not in the prior state and are left null.
+func writeOnlySecretsStateUpgrader() resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					"No prior state was provided to upgrade. Please report this issue to the provider developers.",
+				)
+				return
+			}
+
+			raw, err := req.RawState.UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+				ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+					IgnoreUndefinedAttributes: true,
+				},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					"Could not read the previously saved state: "+err.Error(),
+				)
+				return
+			}
+
+			raw, err = tftypes.Transform(raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+				if v.IsNull() {
+					return v, nil
+				}
+				// Paths to list elements and the like are not attributes, and
+				// are kept as they are.
+				attr, err := resp.State.Schema.AttributeAtTerraformPath(ctx, p)
+				if err != nil || !attr.IsWriteOnly() {
+					return v, nil
+				}
+				return tftypes.NewValue(v.Type(), nil), nil
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Upgrade Resource State",
+					"Could not remove the write-only secrets from the previously saved state: "+err.Error(),
+				)
+				return
+			}
+
+			resp.State.Raw = raw
+		},
+	}
+}
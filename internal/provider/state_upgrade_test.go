@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeResourceStateV0 runs raw version 0 state through the provider's
+// UpgradeResourceState RPC and returns the upgraded state decoded against the
+// resource's current schema.
+func upgradeResourceStateV0(t *testing.T, typeName string, r resource.Resource, rawJSON string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	require.NoError(t, err)
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  0,
+		RawState: &tfprotov6.RawState{JSON: []byte(rawJSON)},
+	})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, "upgrade diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	require.NotNil(t, resp.UpgradedState)
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	raw, err := resp.UpgradedState.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	require.NoError(t, err)
+	return tfsdk.State{Schema: schemaResp.Schema, Raw: raw}
+}
+
+func TestVXCUpgradeStateV0_DropsSecrets(t *testing.T) {
+	ctx := context.Background()
+	state := upgradeResourceStateV0(t, "megaport_vxc", NewVXCResource(), `{
+		"product_uid": "vxc-uid-1",
+		"product_name": "Test VXC",
+		"rate_limit": 1000,
+		"a_end": {"requested_product_uid": "mcr-uid-1", "ordered_vlan": 100},
+		"a_end_partner_config": {
+			"partner": "vrouter",
+			"vrouter_config": {
+				"interfaces": [{
+					"ip_addresses": ["10.0.0.1/30"],
+					"bgp_connections": [{
+						"peer_asn": 64512,
+						"local_ip_address": "10.0.0.1",
+						"peer_ip_address": "10.0.0.2",
+						"password": "bgp-secret"
+					}]
+				}]
+			}
+		},
+		"b_end": {"requested_product_uid": "aws-port-uid"},
+		"b_end_partner_config": {
+			"partner": "aws",
+			"aws_config": {
+				"connect_type": "AWS",
+				"owner_account": "123456789012",
+				"auth_key": "aws-secret"
+			}
+		}
+	}`)
+
+	bgp := path.Root("a_end_partner_config").AtName("vrouter_config").AtName("interfaces").AtListIndex(0).
+		AtName("bgp_connections").AtListIndex(0)
+	var password, peer types.String
+	var passwordVersion types.Int64
+	require.False(t, state.GetAttribute(ctx, bgp.AtName("password"), &password).HasError())
+	require.False(t, state.GetAttribute(ctx, bgp.AtName("peer_ip_address"), &peer).HasError())
+	require.False(t, state.GetAttribute(ctx, bgp.AtName("password_version"), &passwordVersion).HasError())
+	assert.True(t, password.IsNull())
+	assert.Equal(t, "10.0.0.2", peer.ValueString())
+	assert.True(t, passwordVersion.IsNull())
+
+	aws := path.Root("b_end_partner_config").AtName("aws_config")
+	var authKey, ownerAccount types.String
+	require.False(t, state.GetAttribute(ctx, aws.AtName("auth_key"), &authKey).HasError())
+	require.False(t, state.GetAttribute(ctx, aws.AtName("owner_account"), &ownerAccount).HasError())
+	assert.True(t, authKey.IsNull())
+	assert.Equal(t, "123456789012", ownerAccount.ValueString())
+}
+
+func TestMVEUpgradeStateV0_DropsSecrets(t *testing.T) {
+	ctx := context.Background()
+	state := upgradeResourceStateV0(t, "megaport_mve", NewMVEResource(), `{
+		"product_uid": "mve-uid-1",
+		"product_name": "Test MVE",
+		"location_id": 67,
+		"vendor_config": {
+			"vendor": "aruba",
+			"image_id": 23,
+			"product_size": "MEDIUM",
+			"account_name": "aruba-account",
+			"account_key": "aruba-secret",
+			"system_tag": "Preconfiguration-aruba-test-1"
+		}
+	}`)
+
+	vendorConfig := path.Root("vendor_config")
+	var accountKey, accountName types.String
+	var secretsVersion types.Int64
+	require.False(t, state.GetAttribute(ctx, vendorConfig.AtName("account_key"), &accountKey).HasError())
+	require.False(t, state.GetAttribute(ctx, vendorConfig.AtName("account_name"), &accountName).HasError())
+	require.False(t, state.GetAttribute(ctx, vendorConfig.AtName("secrets_version"), &secretsVersion).HasError())
+	assert.True(t, accountKey.IsNull())
+	assert.Equal(t, "aruba-account", accountName.ValueString())
+	assert.True(t, secretsVersion.IsNull())
+}
+
+func TestWriteOnlySecretsStateUpgrader_MissingState(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewMVEResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	writeOnlySecretsStateUpgrader().StateUpgrader(ctx, resource.UpgradeStateRequest{}, resp)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Unable to Upgrade Resource State", resp.Diagnostics.Errors()[0].Summary())
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// resourceTimeoutsBlock returns the `timeouts` block shared by resources that
+// wait on provisioning. Operations left unset fall back to the provider's
+// wait_time.
+func resourceTimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create:            true,
+		Read:              true,
+		Update:            true,
+		Delete:            true,
+		CreateDescription: "How long to wait for the resource to be provisioned. Defaults to the provider `wait_time`.",
+		ReadDescription:   "How long to wait when refreshing the resource. Defaults to the provider `wait_time`.",
+		UpdateDescription: "How long to wait for an update to be applied. Defaults to the provider `wait_time`.",
+		DeleteDescription: "How long to wait for the resource to be deleted. Defaults to the provider `wait_time`.",
+	})
+}
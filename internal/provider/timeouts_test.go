@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func timeoutsResources() map[string]resource.Resource {
+	return map[string]resource.Resource{
+		"megaport_vxc":         NewVXCResource(),
+		"megaport_mve":         NewMVEResource(),
+		"megaport_mcr":         NewMCRResource(),
+		"megaport_port":        NewPortResource(),
+		"megaport_lag_port":    NewLagPortResource(),
+		"megaport_ix":          NewIXResource(),
+		"megaport_nat_gateway": NewNATGatewayResource(),
+	}
+}
+
+func TestResourceTimeoutsBlock(t *testing.T) {
+	for name, r := range timeoutsResources() {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "schema diagnostics: %v", resp.Diagnostics)
+
+			block, ok := resp.Schema.Blocks["timeouts"]
+			require.True(t, ok, "%s should declare a timeouts block", name)
+			nested, ok := block.(schema.SingleNestedBlock)
+			require.True(t, ok, "%s timeouts block should be a single nested block", name)
+			for _, op := range []string{"create", "read", "update", "delete"} {
+				assert.Contains(t, nested.Attributes, op, "%s timeouts block should support %s", name, op)
+			}
+		})
+	}
+}
+
+func TestResourceConfigureStoresWaitTime(t *testing.T) {
+	data := &megaportProviderData{client: &megaport.Client{}, waitTime: 42 * time.Minute}
+
+	for name, r := range timeoutsResources() {
+		t.Run(name, func(t *testing.T) {
+			rc, ok := r.(resource.ResourceWithConfigure)
+			require.True(t, ok)
+			resp := &resource.ConfigureResponse{}
+			rc.Configure(context.Background(), resource.ConfigureRequest{ProviderData: data}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "configure diagnostics: %v", resp.Diagnostics)
+
+			var got time.Duration
+			switch res := r.(type) {
+			case *vxcResource:
+				got = res.waitTime
+			case *mveResource:
+				got = res.waitTime
+			case *mcrResource:
+				got = res.waitTime
+			case *portResource:
+				got = res.waitTime
+			case *lagPortResource:
+				got = res.waitTime
+			case *ixResource:
+				got = res.waitTime
+			case *natGatewayResource:
+				got = res.waitTime
+			default:
+				t.Fatalf("unexpected resource type %T", r)
+			}
+			assert.Equal(t, 42*time.Minute, got)
+		})
+	}
+}
+
+func TestResourceTimeoutsFallBackToWaitTime(t *testing.T) {
+	ctx := context.Background()
+	waitTime := 7 * time.Minute
+	attrTypes := map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+
+	t.Run("null block", func(t *testing.T) {
+		v := timeouts.Value{Object: types.ObjectNull(attrTypes)}
+		create, diags := v.Create(ctx, waitTime)
+		require.False(t, diags.HasError())
+		assert.Equal(t, waitTime, create)
+		del, diags := v.Delete(ctx, waitTime)
+		require.False(t, diags.HasError())
+		assert.Equal(t, waitTime, del)
+	})
+
+	t.Run("explicit values override", func(t *testing.T) {
+		v := timeouts.Value{Object: types.ObjectValueMust(attrTypes, map[string]attr.Value{
+			"create": types.StringValue("45m"),
+			"read":   types.StringNull(),
+			"update": types.StringValue("90s"),
+			"delete": types.StringNull(),
+		})}
+		create, diags := v.Create(ctx, waitTime)
+		require.False(t, diags.HasError())
+		assert.Equal(t, 45*time.Minute, create)
+		update, diags := v.Update(ctx, waitTime)
+		require.False(t, diags.HasError())
+		assert.Equal(t, 90*time.Second, update)
+		read, diags := v.Read(ctx, waitTime)
+		require.False(t, diags.HasError())
+		assert.Equal(t, waitTime, read)
+	})
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &vxcBGPStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &vxcBGPStatusDataSource{}
+
+	vxcBGPSessionAttrs = map[string]attr.Type{
+		"mcr_uid":                   types.StringType,
+		"session_id":                types.StringType,
+		"description":               types.StringType,
+		"neighbor_address":          types.StringType,
+		"neighbor_asn":              types.Int64Type,
+		"local_asn":                 types.Int64Type,
+		"status":                    types.StringType,
+		"uptime_seconds":            types.Int64Type,
+		"last_state_change_seconds": types.Int64Type,
+		"prefixes_received":         types.Int64Type,
+		"prefixes_advertised":       types.Int64Type,
+	}
+)
+
+// vxcBGPStatusDataSource is the data source implementation.
+type vxcBGPStatusDataSource struct {
+	client *megaport.Client
+}
+
+// vxcBGPStatusDataSourceModel maps the data source schema data.
+type vxcBGPStatusDataSourceModel struct {
+	UID           types.String `tfsdk:"product_uid"`
+	AllSessionsUp types.Bool   `tfsdk:"all_sessions_up"`
+	Sessions      types.List   `tfsdk:"sessions"`
+}
+
+// vxcBGPSessionModel maps a single BGP session of the VXC.
+type vxcBGPSessionModel struct {
+	MCRUID                 types.String `tfsdk:"mcr_uid"`
+	SessionID              types.String `tfsdk:"session_id"`
+	Description            types.String `tfsdk:"description"`
+	NeighborAddress        types.String `tfsdk:"neighbor_address"`
+	NeighborASN            types.Int64  `tfsdk:"neighbor_asn"`
+	LocalASN               types.Int64  `tfsdk:"local_asn"`
+	Status                 types.String `tfsdk:"status"`
+	UptimeSeconds          types.Int64  `tfsdk:"uptime_seconds"`
+	LastStateChangeSeconds types.Int64  `tfsdk:"last_state_change_seconds"`
+	PrefixesReceived       types.Int64  `tfsdk:"prefixes_received"`
+	PrefixesAdvertised     types.Int64  `tfsdk:"prefixes_advertised"`
+}
+
+// NewVXCBGPStatusDataSource is a helper function to simplify the provider implementation.
+func NewVXCBGPStatusDataSource() datasource.DataSource {
+	return &vxcBGPStatusDataSource{}
+}
+
+// Metadata returns the data source type name.
+func (d *vxcBGPStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vxc_bgp_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *vxcBGPStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the live state of the BGP sessions of a VXC, as seen by the looking glass of the MCRs at either end. Use it in a check block to assert that the sessions configured in bgp_connections came up after apply. VXCs without an MCR end have no sessions.",
+		Attributes: map[string]schema.Attribute{
+			"product_uid": schema.StringAttribute{
+				Description: "The UID of the VXC.",
+				Required:    true,
+			},
+			"all_sessions_up": schema.BoolAttribute{
+				Description: "Whether the VXC has at least one BGP session and all of its sessions are up.",
+				Computed:    true,
+			},
+			"sessions": schema.ListNestedAttribute{
+				Description: "The BGP sessions of the VXC.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"mcr_uid": schema.StringAttribute{
+							Description: "The UID of the MCR the session runs on.",
+							Computed:    true,
+						},
+						"session_id": schema.StringAttribute{
+							Description: "The ID of the session.",
+							Computed:    true,
+						},
+						"description": schema.StringAttribute{
+							Description: "The description of the BGP connection.",
+							Computed:    true,
+						},
+						"neighbor_address": schema.StringAttribute{
+							Description: "The IP address of the BGP neighbor.",
+							Computed:    true,
+						},
+						"neighbor_asn": schema.Int64Attribute{
+							Description: "The ASN of the BGP neighbor.",
+							Computed:    true,
+						},
+						"local_asn": schema.Int64Attribute{
+							Description: "The ASN of the MCR for the session.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The state of the session: UP, DOWN or UNKNOWN.",
+							Computed:    true,
+						},
+						"uptime_seconds": schema.Int64Attribute{
+							Description: "How long the session has been up, in seconds. Null while the session is down.",
+							Computed:    true,
+						},
+						"last_state_change_seconds": schema.Int64Attribute{
+							Description: "Seconds since the session last changed state.",
+							Computed:    true,
+						},
+						"prefixes_received": schema.Int64Attribute{
+							Description: "The number of prefixes received from the neighbor.",
+							Computed:    true,
+						},
+						"prefixes_advertised": schema.Int64Attribute{
+							Description: "The number of prefixes advertised to the neighbor.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *vxcBGPStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state vxcBGPStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vxc, err := d.client.VXCService.GetVXC(ctx, state.UID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading VXC",
+			"Could not read VXC with ID "+state.UID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	sessionObjects := []types.Object{}
+	allUp := true
+	for _, mcrUID := range []string{vxc.AEndConfiguration.UID, vxc.BEndConfiguration.UID} {
+		if mcrUID == "" {
+			continue
+		}
+		productType, err := d.client.ProductService.GetProductType(ctx, mcrUID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading VXC End",
+				"Could not read the product type of "+mcrUID+": "+err.Error(),
+			)
+			return
+		}
+		if !strings.EqualFold(productType, megaport.PRODUCT_MCR) {
+			continue
+		}
+		sessions, err := d.client.MCRLookingGlassService.ListBGPSessions(ctx, mcrUID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Listing BGP Sessions",
+				"Could not list the BGP sessions of MCR "+mcrUID+": "+err.Error(),
+			)
+			return
+		}
+		for _, s := range sessions {
+			if s == nil || s.VXCID != vxc.ID {
+				continue
+			}
+			allUp = allUp && s.Status == megaport.BGPSessionStatusUp
+			session := vxcBGPSessionModel{
+				MCRUID:                 types.StringValue(mcrUID),
+				SessionID:              types.StringValue(s.SessionID),
+				Description:            types.StringValue(s.Description),
+				NeighborAddress:        types.StringValue(s.NeighborAddress),
+				NeighborASN:            types.Int64Value(int64(s.NeighborASN)),
+				LocalASN:               types.Int64Value(int64(s.LocalASN)),
+				Status:                 types.StringValue(string(s.Status)),
+				UptimeSeconds:          int64PointerValue(s.Uptime),
+				LastStateChangeSeconds: int64PointerValue(s.LastStateChange),
+				PrefixesReceived:       int64PointerValue(s.PrefixesIn),
+				PrefixesAdvertised:     int64PointerValue(s.PrefixesOut),
+			}
+			obj, objDiags := types.ObjectValueFrom(ctx, vxcBGPSessionAttrs, &session)
+			resp.Diagnostics.Append(objDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			sessionObjects = append(sessionObjects, obj)
+		}
+	}
+
+	sessionsList, sessionsDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: vxcBGPSessionAttrs}, sessionObjects)
+	resp.Diagnostics.Append(sessionsDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Sessions = sessionsList
+	state.AllSessionsUp = types.BoolValue(len(sessionObjects) > 0 && allUp)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// int64PointerValue converts an optional API count to an Int64, null when the
+// API leaves it out.
+func int64PointerValue(v *int) types.Int64 {
+	if v == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(*v))
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *vxcBGPStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*megaportProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *megaportProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.client
+}
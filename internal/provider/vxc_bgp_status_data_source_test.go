@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	megaport "github.com/megaport/megaportgo"
+)
+
+func TestUnitMegaportVXCBGPStatusDataSource(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("BGP Status MCR")
+	port := api.seedPort("BGP Status Port")
+	config := fakeProviderConfig + fmt.Sprintf(`
+	resource "megaport_vxc" "vxc" {
+		product_name         = "BGP Status VXC"
+		rate_limit           = 100
+		contract_term_months = 12
+		a_end = {
+			requested_product_uid = %q
+		}
+		a_end_partner_config = {
+			partner = "vrouter"
+			vrouter_config = {
+				interfaces = [{
+					ip_addresses = ["10.0.0.1/30"]
+					bgp_connections = [{
+						peer_asn         = 64512
+						local_ip_address = "10.0.0.1"
+						peer_ip_address  = "10.0.0.2"
+						description      = "to port"
+					}]
+				}]
+			}
+		}
+		b_end = {
+			requested_product_uid = %q
+		}
+	}
+
+	data "megaport_vxc_bgp_status" "vxc" {
+		product_uid = megaport_vxc.vxc.product_uid
+	}`, mcr, port)
+	down := &fakeFault{Method: http.MethodGet, Path: "/v2/product/mcr2/*/lookingGlass/bgpSessions", Mutate: func(data map[string]any) {
+		data["status"] = string(megaport.BGPSessionStatusDown)
+		data["uptime"] = nil
+	}}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "all_sessions_up", "true"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.#", "1"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.mcr_uid", mcr),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.neighbor_address", "10.0.0.2"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.neighbor_asn", "64512"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.description", "to port"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.status", "UP"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.uptime_seconds", "3600"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.prefixes_received", "1"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.prefixes_advertised", "1"),
+				),
+			},
+			{
+				PreConfig: func() { api.inject(down) },
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "all_sessions_up", "false"),
+					resource.TestCheckResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.status", "DOWN"),
+					resource.TestCheckNoResourceAttr("data.megaport_vxc_bgp_status.vxc", "sessions.0.uptime_seconds"),
+				),
+			},
+		},
+	})
+}
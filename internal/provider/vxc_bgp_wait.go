@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// vxcBGPWaitDefaultTimeout is how long wait_for_bgp waits when
+// wait_for_bgp_timeout is not set.
+const vxcBGPWaitDefaultTimeout = 10 * time.Minute
+
+// vxcBGPPeer is an enabled BGP connection of a VXC whose session runs on the
+// MCR mcrUID. path is the peer_ip_address of the connection, which per-peer
+// diagnostics are reported against.
+type vxcBGPPeer struct {
+	mcrUID  string
+	address string
+	path    path.Path
+}
+
+// vxcBGPPeers returns the enabled BGP connections in the virtual router
+// partner configurations of the MCR ends of vxc.
+func (r *vxcResource) vxcBGPPeers(ctx context.Context, plan *vxcResourceModel, vxc *megaport.VXC) ([]vxcBGPPeer, error) {
+	var peers []vxcBGPPeer
+	for _, end := range []struct {
+		productUID    string
+		attribute     string
+		partnerConfig types.Object
+	}{
+		{vxc.AEndConfiguration.UID, "a_end_partner_config", plan.AEndPartnerConfig},
+		{vxc.BEndConfiguration.UID, "b_end_partner_config", plan.BEndPartnerConfig},
+	} {
+		endPeers, diags := enabledBGPPeers(ctx, path.Root(end.attribute), end.partnerConfig)
+		if diags.HasError() {
+			return nil, fmt.Errorf("could not read the partner configuration: %s", diags.Errors()[0].Detail())
+		}
+		if len(endPeers) == 0 {
+			continue
+		}
+		productType, err := r.client.ProductService.GetProductType(ctx, end.productUID)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(productType, megaport.PRODUCT_MCR) {
+			continue
+		}
+		for _, peer := range endPeers {
+			peer.mcrUID = end.productUID
+			peers = append(peers, peer)
+		}
+	}
+	return peers, nil
+}
+
+// enabledBGPPeers returns the BGP connections in a virtual router or A-End
+// partner configuration at partnerConfigPath that are not shut down. The
+// peers it returns have no MCR set.
+func enabledBGPPeers(ctx context.Context, partnerConfigPath path.Path, partnerConfigObj types.Object) ([]vxcBGPPeer, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	if partnerConfigObj.IsNull() || partnerConfigObj.IsUnknown() {
+		return nil, diags
+	}
+	var partnerConfig vxcPartnerConfigurationModel
+	diags.Append(partnerConfigAs(ctx, partnerConfigObj, &partnerConfig, basetypes.ObjectAsOptions{})...)
+	var interfacesObj types.Object
+	switch partnerConfig.Partner.ValueString() {
+	case "vrouter":
+		interfacesObj = partnerConfig.VrouterPartnerConfig
+		partnerConfigPath = partnerConfigPath.AtName("vrouter_config")
+	case "a-end":
+		interfacesObj = partnerConfig.PartnerAEndConfig
+		partnerConfigPath = partnerConfigPath.AtName("partner_a_end_config")
+	default:
+		return nil, diags
+	}
+	if interfacesObj.IsNull() || interfacesObj.IsUnknown() {
+		return nil, diags
+	}
+	var vrouter vxcPartnerConfigVrouterModel
+	diags.Append(interfacesObj.As(ctx, &vrouter, basetypes.ObjectAsOptions{})...)
+	var interfaces []vxcPartnerConfigInterfaceModel
+	diags.Append(vrouter.Interfaces.ElementsAs(ctx, &interfaces, false)...)
+	var peers []vxcBGPPeer
+	for i, iface := range interfaces {
+		var connections []bgpConnectionConfigModel
+		diags.Append(iface.BgpConnections.ElementsAs(ctx, &connections, false)...)
+		for j, c := range connections {
+			if c.Shutdown.ValueBool() {
+				continue
+			}
+			peers = append(peers, vxcBGPPeer{
+				address: c.PeerIPAddress.ValueString(),
+				path: partnerConfigPath.AtName("interfaces").AtListIndex(i).
+					AtName("bgp_connections").AtListIndex(j).AtName("peer_ip_address"),
+			})
+		}
+	}
+	return peers, diags
+}
+
+// vxcBGPWaitError is returned by waitForVXCBGP when some BGP sessions have not
+// come up before the timeout. pending holds the last known state of each of
+// them.
+type vxcBGPWaitError struct {
+	timeout time.Duration
+	pending []vxcBGPPeerState
+}
+
+// vxcBGPPeerState describes why the session with a BGP peer is not up.
+type vxcBGPPeerState struct {
+	peer  vxcBGPPeer
+	state string
+}
+
+func (e *vxcBGPWaitError) Error() string {
+	pending := make([]string, len(e.pending))
+	for i, p := range e.pending {
+		pending[i] = fmt.Sprintf("peer %s on MCR %s: %s", p.peer.address, p.peer.mcrUID, p.state)
+	}
+	return fmt.Sprintf("time expired waiting for BGP sessions (%s)", strings.Join(pending, "; "))
+}
+
+// diagnostics returns an error for each BGP peer that did not come up,
+// reported against its peer_ip_address.
+func (e *vxcBGPWaitError) diagnostics(vxcUID string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	for _, p := range e.pending {
+		diags.AddAttributeError(p.peer.path, "BGP session not established",
+			fmt.Sprintf("The BGP session of VXC %s with peer %s on MCR %s was not established after %v: %s. Check the BGP configuration of both peers, or increase wait_for_bgp_timeout if the session is slow to come up.",
+				vxcUID, p.peer.address, p.peer.mcrUID, e.timeout, p.state))
+	}
+	return diags
+}
+
+// waitForVXCBGP polls the looking glass of the MCRs of peers until the session
+// with each peer on the VXC with ID vxcID is up. Failed looking glass reads are
+// retried until the timeout elapses, when a *vxcBGPWaitError is returned.
+func (r *vxcResource) waitForVXCBGP(ctx context.Context, vxcID int, peers []vxcBGPPeer, timeoutAfter, pollInterval time.Duration) error {
+	pollCtx, cancel := context.WithTimeout(ctx, timeoutAfter)
+	defer cancel()
+
+	ticker := time.NewTicker(pollIntervalOrDefault(pollInterval))
+	defer ticker.Stop()
+
+	var lastPending []vxcBGPPeerState
+	for {
+		var pending []vxcBGPPeerState
+		sessions := map[string][]*megaport.LookingGlassBGPSession{}
+		readErrs := map[string]error{}
+		for _, peer := range peers {
+			if _, read := sessions[peer.mcrUID]; !read && readErrs[peer.mcrUID] == nil {
+				mcrSessions, err := r.client.MCRLookingGlassService.ListBGPSessions(pollCtx, peer.mcrUID)
+				if err != nil {
+					tflog.Warn(ctx, "error reading BGP sessions, will retry", map[string]any{
+						"mcr_uid": peer.mcrUID,
+						"error":   err.Error(),
+					})
+					readErrs[peer.mcrUID] = err
+				} else {
+					sessions[peer.mcrUID] = mcrSessions
+				}
+			}
+			if err := readErrs[peer.mcrUID]; err != nil {
+				pending = append(pending, vxcBGPPeerState{peer, "could not read the looking glass: " + err.Error()})
+				continue
+			}
+			state := "no session found"
+			for _, s := range sessions[peer.mcrUID] {
+				if s != nil && s.VXCID == vxcID && sameIPAddress(s.NeighborAddress, peer.address) {
+					state = string(s.Status)
+					break
+				}
+			}
+			if state != string(megaport.BGPSessionStatusUp) {
+				pending = append(pending, vxcBGPPeerState{peer, state})
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		// A poll cut short by the timeout says nothing about the sessions,
+		// so the states from the poll before it are reported instead.
+		if pollCtx.Err() != nil && lastPending != nil {
+			pending = lastPending
+		}
+		lastPending = pending
+
+		select {
+		case <-pollCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return &vxcBGPWaitError{timeout: timeoutAfter, pending: pending}
+		case <-ticker.C:
+		}
+	}
+}
+
+// sameIPAddress reports whether a and b are the same IP address, comparing
+// them as written if either does not parse.
+func sameIPAddress(a, b string) bool {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return addrA == addrB
+}
+
+// durationValidator checks that a string is a Go duration such as "10m" or
+// "1h30m", as accepted in a timeouts block.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a duration such as \"30s\", \"10m\" or \"1h\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration",
+			fmt.Sprintf("%q is not a positive duration such as \"30s\", \"10m\" or \"1h\".", req.ConfigValue.ValueString()))
+	}
+}
+
+// waitForRequestedBGP waits for the BGP sessions of vxc to be established if
+// plan sets wait_for_bgp, returning an error for each peer that is not.
+func (r *vxcResource) waitForRequestedBGP(ctx context.Context, plan *vxcResourceModel, vxc *megaport.VXC) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if !plan.WaitForBGP.ValueBool() {
+		return diags
+	}
+	timeout := vxcBGPWaitDefaultTimeout
+	if d, err := time.ParseDuration(plan.WaitForBGPTimeout.ValueString()); err == nil && d > 0 {
+		timeout = d
+	}
+
+	peers, err := r.vxcBGPPeers(ctx, plan, vxc)
+	if err != nil {
+		diags.AddError("Error waiting for BGP sessions",
+			"Could not find the BGP sessions of VXC "+vxc.UID+" to wait for: "+err.Error())
+		return diags
+	}
+	if len(peers) == 0 {
+		diags.AddAttributeWarning(path.Root("wait_for_bgp"), "No BGP sessions to wait for",
+			"wait_for_bgp is set, but VXC "+vxc.UID+" has no enabled bgp_connections on an MCR end, so there are no sessions to wait for.")
+		return diags
+	}
+
+	tflog.Info(ctx, "Waiting for the BGP sessions of the VXC", map[string]any{
+		"vxc_uid": vxc.UID,
+		"peers":   len(peers),
+		"timeout": timeout.String(),
+	})
+	err = r.waitForVXCBGP(ctx, vxc.ID, peers, timeout, r.pollInterval)
+	var waitErr *vxcBGPWaitError
+	switch {
+	case errors.As(err, &waitErr):
+		diags.Append(waitErr.diagnostics(vxc.UID)...)
+	case err != nil:
+		diags.AddError("Error waiting for BGP sessions",
+			"Could not wait for the BGP sessions of VXC "+vxc.UID+": "+err.Error())
+	}
+	return diags
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForBGPConfig(name, mcr, port, timeout string) string {
+	return fakeProviderConfig + fmt.Sprintf(`
+	resource "megaport_vxc" "vxc" {
+		product_name         = %q
+		rate_limit           = 100
+		contract_term_months = 12
+		wait_for_bgp         = true
+		wait_for_bgp_timeout = %q
+		a_end = {
+			requested_product_uid = %q
+		}
+		a_end_partner_config = {
+			partner = "vrouter"
+			vrouter_config = {
+				interfaces = [{
+					ip_addresses = ["10.0.0.1/30", "10.0.1.1/30"]
+					bgp_connections = [
+						{
+							peer_asn         = 64512
+							local_ip_address = "10.0.0.1"
+							peer_ip_address  = "10.0.0.2"
+						},
+						{
+							peer_asn         = 64513
+							local_ip_address = "10.0.1.1"
+							peer_ip_address  = "10.0.1.2"
+						},
+						{
+							peer_asn         = 64514
+							local_ip_address = "10.0.1.1"
+							peer_ip_address  = "10.0.1.3"
+							shutdown         = true
+						},
+					]
+				}]
+			}
+		}
+		b_end = {
+			requested_product_uid = %q
+		}
+	}`, name, timeout, mcr, port)
+}
+
+// bgpPeerDown reports the session with peer, or every session if peer is
+// empty, as down until up is called.
+func bgpPeerDown(peer string) (fault *fakeFault, up func()) {
+	var isUp atomic.Bool
+	fault = &fakeFault{Method: http.MethodGet, Path: "/v2/product/mcr2/*/lookingGlass/bgpSessions", Mutate: func(data map[string]any) {
+		if !isUp.Load() && (peer == "" || data["neighborAddress"] == peer) {
+			data["status"] = string(megaport.BGPSessionStatusDown)
+		}
+	}}
+	return fault, func() { isUp.Store(true) }
+}
+
+func TestUnitMegaportVXC_WaitForBGP(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("Wait BGP MCR")
+	port := api.seedPort("Wait BGP Port")
+	down, up := bgpPeerDown("10.0.1.2")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: waitForBGPConfig("Wait BGP VXC", mcr, port, "5s"),
+			},
+			{
+				PreConfig:   func() { api.inject(down) },
+				Config:      waitForBGPConfig("Wait BGP VXC", mcr, port, "2s"),
+				ExpectError: regexp.MustCompile(`(?s)BGP session not established.*peer\s+10\.0\.1\.2\s+on\s+MCR.*DOWN`),
+			},
+			// The update was saved before the wait failed.
+			{
+				PreConfig: up,
+				Config:    waitForBGPConfig("Wait BGP VXC", mcr, port, "2s"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestUnitMegaportVXC_WaitForBGPCreateFails(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("Wait BGP Create MCR")
+	port := api.seedPort("Wait BGP Create Port")
+	down, up := bgpPeerDown("10.0.0.2")
+	api.inject(down)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      waitForBGPConfig("Wait BGP Create VXC", mcr, port, "2s"),
+				ExpectError: regexp.MustCompile(`BGP session not established`),
+			},
+			// The VXC whose sessions did not come up is replaced.
+			{
+				PreConfig: up,
+				Config:    waitForBGPConfig("Wait BGP Create VXC", mcr, port, "2s"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("megaport_vxc.vxc", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestSameIPAddress(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{"10.0.0.2", "10.0.0.2", true},
+		{"10.0.0.2", "10.0.0.3", false},
+		{"2001:db8::1", "2001:DB8:0::1", true},
+		{"not-an-ip", "not-an-ip", true},
+		{"10.0.0.2", "", false},
+	} {
+		if got := sameIPAddress(tc.a, tc.b); got != tc.want {
+			t.Errorf("sameIPAddress(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+// TestWaitForVXCBGP_SparseSessions checks that a looking glass response with
+// null entries is waited on rather than panicking the apply.
+func TestWaitForVXCBGP_SparseSessions(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"ok","data":[null,{"vxcId":7,"neighborAddress":"10.0.0.2","status":"UP"}]}`))
+	}))
+	t.Cleanup(server.Close)
+	client, err := megaport.New(nil,
+		megaport.WithBaseURL(server.URL),
+		megaport.WithAccessToken("test-token", time.Now().Add(time.Hour)),
+	)
+	require.NoError(t, err)
+
+	r := &vxcResource{client: client}
+	peers := []vxcBGPPeer{{mcrUID: "mcr-uid", address: "10.0.0.2"}}
+	require.NoError(t, r.waitForVXCBGP(context.Background(), 7, peers, time.Second, 10*time.Millisecond))
+}
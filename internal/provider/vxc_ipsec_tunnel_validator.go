@@ -3,8 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
@@ -67,3 +71,194 @@ func (v ipSecPhaseLifetimeValidator) ValidateObject(ctx context.Context, req val
 		)
 	}
 }
+
+// ipSecTunnelOptionsValidator checks the addresses, IKE identifiers and
+// pre-shared key settings of a single tunnel against each other:
+//
+//   - the source and destination must be distinct, specific IPv4 unicast
+//     addresses (the API only builds tunnels over IPv4),
+//   - local_id and remote_id must be an IP address, an FQDN or user@FQDN,
+//   - an active tunnel (passive = false) initiates the connection, so it needs
+//     a specific destination, and a tunnel that accepts any peer
+//     (destination 0.0.0.0) needs remote_id to tell peers apart,
+//   - exactly one of pre_shared_key and generate_pre_shared_key must be set.
+//
+// Unknown values are skipped; the API validates them when the tunnel is
+// ordered.
+type ipSecTunnelOptionsValidator struct{}
+
+func (v ipSecTunnelOptionsValidator) Description(_ context.Context) string {
+	return "source and destination must be distinct IPv4 addresses, IKE identifiers must be an IP address, FQDN or user@FQDN, and exactly one of pre_shared_key and generate_pre_shared_key must be set"
+}
+
+func (v ipSecTunnelOptionsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipSecTunnelOptionsValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var tunnel ipSecTunnelOptionsModel
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &tunnel, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	source, sourceOK := parsedAddr(tunnel.SourceIPAddress)
+	destination, destinationOK := parsedAddr(tunnel.DestinationIPAddress)
+	sourcePath := req.Path.AtName("source_ip_address")
+	destinationPath := req.Path.AtName("destination_ip_address")
+	if sourceOK {
+		switch {
+		case !source.Is4():
+			resp.Diagnostics.AddAttributeError(sourcePath, "Invalid IPsec tunnel source",
+				fmt.Sprintf("The tunnel source %s is an IPv6 address. IPsec tunnels are built over IPv4.", source))
+			sourceOK = false
+		case source.IsUnspecified() || source.IsLoopback() || source.IsMulticast() || source == ipv4Broadcast:
+			resp.Diagnostics.AddAttributeError(sourcePath, "Invalid IPsec tunnel source",
+				fmt.Sprintf("The tunnel source %s is not a unicast address. Use an address of a subInterface interface on the MCR.", source))
+			sourceOK = false
+		}
+	}
+	if destinationOK {
+		switch {
+		case !destination.Is4():
+			resp.Diagnostics.AddAttributeError(destinationPath, "Invalid IPsec tunnel destination",
+				fmt.Sprintf("The tunnel destination %s is an IPv6 address. IPsec tunnels are built over IPv4.", destination))
+			destinationOK = false
+		case destination.IsLoopback() || destination.IsMulticast() || destination == ipv4Broadcast:
+			resp.Diagnostics.AddAttributeError(destinationPath, "Invalid IPsec tunnel destination",
+				fmt.Sprintf("The tunnel destination %s is not an address a peer can have.", destination))
+			destinationOK = false
+		}
+	}
+	if sourceOK && destinationOK && source == destination {
+		resp.Diagnostics.AddAttributeError(destinationPath, "Invalid IPsec tunnel destination",
+			fmt.Sprintf("The tunnel destination %s is the same as its source.", destination))
+	}
+
+	for _, id := range []struct {
+		name  string
+		value types.String
+	}{{"local_id", tunnel.LocalID}, {"remote_id", tunnel.RemoteID}} {
+		if id.value.IsNull() || id.value.IsUnknown() || validIKEIdentifier(id.value.ValueString()) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(req.Path.AtName(id.name), "Invalid IKE identifier",
+			fmt.Sprintf("%q is not an IKE identifier. Use an IP address (\"203.0.113.10\"), a fully qualified domain name (\"vpn.example.com\") or user@FQDN (\"vpn@example.com\").", id.value.ValueString()))
+	}
+
+	anyPeer := destinationOK && destination.IsUnspecified()
+	if anyPeer && !tunnel.Passive.IsNull() && !tunnel.Passive.IsUnknown() && !tunnel.Passive.ValueBool() {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("passive"), "Invalid IPsec tunnel mode",
+			"An active tunnel (passive = false) initiates the connection to its destination, so destination_ip_address cannot be 0.0.0.0. Set the peer's address, or make the tunnel passive.")
+	}
+	if anyPeer && tunnel.RemoteID.IsNull() {
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("remote_id"), "Missing IKE remote identifier",
+			"A tunnel with destination_ip_address 0.0.0.0 accepts a connection from any address, so it needs remote_id to identify its peer.")
+	}
+
+	generate := !tunnel.GeneratePreSharedKey.IsNull() && (tunnel.GeneratePreSharedKey.IsUnknown() || tunnel.GeneratePreSharedKey.ValueBool())
+	switch {
+	case tunnel.PreSharedKey.IsNull() && !generate:
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("pre_shared_key"), "Missing IPsec pre-shared key",
+			"Set pre_shared_key, or set generate_pre_shared_key = true to have the provider generate one.")
+	case !tunnel.PreSharedKey.IsNull() && generate:
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("generate_pre_shared_key"), "Conflicting IPsec pre-shared key settings",
+			"pre_shared_key and generate_pre_shared_key = true cannot both be set. Remove one of them.")
+	}
+}
+
+// ipv4Broadcast is the limited broadcast address, 255.255.255.255.
+var ipv4Broadcast = netip.AddrFrom4([4]byte{255, 255, 255, 255})
+
+// ikeFQDNPattern matches a fully qualified domain name of at least two labels.
+var ikeFQDNPattern = regexp.MustCompile(`^(?i)([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]([a-z0-9-]{0,61}[a-z0-9])?\.?$`)
+
+// validIKEIdentifier reports whether id is an IKE identifier of a type the
+// MCR accepts: an IP address, an FQDN, or user@FQDN.
+func validIKEIdentifier(id string) bool {
+	if _, err := netip.ParseAddr(id); err == nil {
+		return true
+	}
+	if user, domain, ok := strings.Cut(id, "@"); ok {
+		return user != "" && !strings.ContainsAny(user, " @") && len(domain) <= 253 && ikeFQDNPattern.MatchString(domain)
+	}
+	return len(id) <= 253 && ikeFQDNPattern.MatchString(id)
+}
+
+// ipSecTunnelInterfacesValidator checks the IPsec tunnels of a virtual router
+// configuration against the interfaces they are on: ip_sec_tunnel_options
+// goes with interface_type ipSecTunnel and nothing else, a tunnel cannot be
+// sourced from an address of its own interface, and no two tunnels can share
+// a source and destination.
+type ipSecTunnelInterfacesValidator struct{}
+
+func (v ipSecTunnelInterfacesValidator) Description(_ context.Context) string {
+	return "ip_sec_tunnel_options must be set on, and only on, interfaces of type ipSecTunnel"
+}
+
+func (v ipSecTunnelInterfacesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipSecTunnelInterfacesValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	endpoints := map[[2]netip.Addr]int{}
+	for i, elem := range req.ConfigValue.Elements() {
+		iface, ok := elem.(types.Object)
+		if !ok || iface.IsNull() || iface.IsUnknown() {
+			continue
+		}
+		ifacePath := req.Path.AtListIndex(i)
+		attrs := iface.Attributes()
+		interfaceType, _ := attrs["interface_type"].(types.String)
+		tunnelObj, _ := attrs["ip_sec_tunnel_options"].(types.Object)
+		if interfaceType.IsUnknown() || tunnelObj.IsUnknown() {
+			continue
+		}
+		isTunnel := interfaceType.ValueString() == "ipSecTunnel"
+		if !isTunnel {
+			if !tunnelObj.IsNull() {
+				resp.Diagnostics.AddAttributeError(ifacePath.AtName("ip_sec_tunnel_options"), "IPsec tunnel on a sub-interface",
+					fmt.Sprintf("Interface %d has ip_sec_tunnel_options, so it must have interface_type = \"ipSecTunnel\".", i))
+			}
+			continue
+		}
+		if tunnelObj.IsNull() {
+			resp.Diagnostics.AddAttributeError(ifacePath.AtName("ip_sec_tunnel_options"), "Missing IPsec tunnel options",
+				fmt.Sprintf("Interface %d is an ipSecTunnel interface, so it needs ip_sec_tunnel_options.", i))
+			continue
+		}
+
+		tunnel := tunnelObj.Attributes()
+		source, sourceOK := parsedAddr(tunnel["source_ip_address"])
+		destination, destinationOK := parsedAddr(tunnel["destination_ip_address"])
+		sourcePath := ifacePath.AtName("ip_sec_tunnel_options").AtName("source_ip_address")
+		if !sourceOK {
+			continue
+		}
+		ipAddresses, _ := knownListElements(attrs["ip_addresses"])
+		for _, ipAddress := range ipAddresses {
+			if prefix, ok := parsedPrefix(ipAddress); ok && prefix.Addr() == source {
+				resp.Diagnostics.AddAttributeError(sourcePath, "IPsec tunnel sourced from its own interface",
+					fmt.Sprintf("The tunnel source %s is an address of the tunnel interface itself. Source the tunnel from an address of a subInterface interface.", source))
+			}
+		}
+		if !destinationOK || destination.IsUnspecified() {
+			continue
+		}
+		key := [2]netip.Addr{source, destination}
+		if j, ok := endpoints[key]; ok {
+			resp.Diagnostics.AddAttributeError(ifacePath.AtName("ip_sec_tunnel_options").AtName("destination_ip_address"), "Duplicate IPsec tunnel",
+				fmt.Sprintf("Interfaces %d and %d both have a tunnel from %s to %s.", j, i, source, destination))
+			continue
+		}
+		endpoints[key] = i
+	}
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// generatedPreSharedKeyPlanModifier plans generated_pre_shared_key. The key is
+// null unless generate_pre_shared_key is set. Once generated it is kept from
+// state until pre_shared_key_version changes; a new tunnel, or a new version,
+// plans it as unknown until the provider generates it at apply.
+type generatedPreSharedKeyPlanModifier struct{}
+
+func (m generatedPreSharedKeyPlanModifier) Description(_ context.Context) string {
+	return "Keeps a generated pre-shared key from state while generate_pre_shared_key is set and pre_shared_key_version is unchanged, and plans a new one otherwise."
+}
+
+func (m generatedPreSharedKeyPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m generatedPreSharedKeyPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	tunnelPath := req.Path.ParentPath()
+	var generate types.Bool
+	var version, stateVersion types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tunnelPath.AtName("generate_pre_shared_key"), &generate)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tunnelPath.AtName("pre_shared_key_version"), &version)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, tunnelPath.AtName("pre_shared_key_version"), &stateVersion)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	switch {
+	case generate.IsNull() || (!generate.IsUnknown() && !generate.ValueBool()):
+		resp.PlanValue = types.StringNull()
+	case !req.StateValue.IsNull() && !req.StateValue.IsUnknown() && version.Equal(stateVersion):
+		resp.PlanValue = req.StateValue
+	default:
+		resp.PlanValue = types.StringUnknown()
+	}
+}
+
+// generateIPSecPreSharedKeys adds the keys of the tunnels of vrouterConfig
+// that have generate_pre_shared_key set to psks, keyed by interface index as
+// returned by ipSecPreSharedKeysFromConfig. A tunnel keeps a key it was
+// already given; otherwise a new one is generated and written back to the
+// tunnel's generated_pre_shared_key, so it is saved to state with the rest of
+// the partner configuration.
+func generateIPSecPreSharedKeys(ctx context.Context, vrouterConfig *vxcPartnerConfigVrouterModel, psks map[int]string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if vrouterConfig.Interfaces.IsNull() || vrouterConfig.Interfaces.IsUnknown() {
+		return diags
+	}
+	ifaces := []vxcPartnerConfigInterfaceModel{}
+	diags.Append(vrouterConfig.Interfaces.ElementsAs(ctx, &ifaces, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	generated := false
+	for i := range ifaces {
+		if ifaces[i].IpSecTunnelOptions.IsNull() || ifaces[i].IpSecTunnelOptions.IsUnknown() {
+			continue
+		}
+		var tunnel ipSecTunnelOptionsModel
+		diags.Append(ifaces[i].IpSecTunnelOptions.As(ctx, &tunnel, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return diags
+		}
+		if !tunnel.GeneratePreSharedKey.ValueBool() {
+			continue
+		}
+		if tunnel.GeneratedPreSharedKey.IsNull() || tunnel.GeneratedPreSharedKey.IsUnknown() {
+			tunnel.GeneratedPreSharedKey = types.StringValue(newIPSecPreSharedKey())
+			tunnelObj, tunnelDiags := types.ObjectValueFrom(ctx, ipSecTunnelOptionsAttrs, tunnel)
+			diags.Append(tunnelDiags...)
+			ifaces[i].IpSecTunnelOptions = tunnelObj
+			generated = true
+		}
+		psks[i] = tunnel.GeneratedPreSharedKey.ValueString()
+	}
+	if !generated || diags.HasError() {
+		return diags
+	}
+	ifaceList, listDiags := types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(vxcVrouterInterfaceAttrs), ifaces)
+	diags.Append(listDiags...)
+	vrouterConfig.Interfaces = ifaceList
+	return diags
+}
+
+// newIPSecPreSharedKey returns a random pre-shared key of 26 upper-case
+// letters and digits, which carries 130 bits of entropy and needs no escaping
+// on the peer's side.
+func newIPSecPreSharedKey() string {
+	return rand.Text()
+}
+
+// maxIPsecTunnelCount is the most tunnels an MCR IPsec add-on can have.
+var maxIPsecTunnelCount = slices.Max(megaport.ValidIPsecTunnelCounts)
+
+// validateIPsecTunnelCapacity checks the number of IPsec tunnels planned on
+// each MCR end of a VXC against the MCR's IPsec add-on, for a new VXC or a
+// changed partner configuration. More tunnels than any add-on supports is an
+// error. The add-on itself is often created or resized in the same apply as
+// the VXC, so a missing or smaller add-on is only a warning, as is a failed
+// MCR lookup. Tunnels on the MCR's other VXCs also count towards its add-on,
+// and are left to the API to check.
+func (r *vxcResource) validateIPsecTunnelCapacity(ctx context.Context, plan, state *vxcResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if r.client == nil {
+		return diags
+	}
+	ends := []struct {
+		pathRoot                string
+		planEnd, stateEnd       types.Object
+		planConfig, stateConfig types.Object
+	}{
+		{"a_end_partner_config", plan.AEndConfiguration, state.AEndConfiguration, plan.AEndPartnerConfig, state.AEndPartnerConfig},
+		{"b_end_partner_config", plan.BEndConfiguration, state.BEndConfiguration, plan.BEndPartnerConfig, state.BEndPartnerConfig},
+	}
+	for _, end := range ends {
+		if !state.UID.IsNull() && end.planConfig.Equal(end.stateConfig) && end.planEnd.Equal(end.stateEnd) {
+			continue
+		}
+		tunnels, ok := plannedIPsecTunnelCount(ctx, end.planConfig, &diags)
+		if !ok || tunnels == 0 {
+			continue
+		}
+		interfacesPath := path.Root(end.pathRoot).AtName("vrouter_config").AtName("interfaces")
+		if tunnels > maxIPsecTunnelCount {
+			diags.AddAttributeError(interfacesPath, "Too many IPsec tunnels",
+				fmt.Sprintf("The VXC has %d IPsec tunnels, but an MCR supports at most %d.", tunnels, maxIPsecTunnelCount))
+			continue
+		}
+
+		if end.planEnd.IsNull() || end.planEnd.IsUnknown() {
+			continue
+		}
+		var endConfig vxcEndConfigurationModel
+		diags.Append(endConfigurationAs(ctx, end.planEnd, &endConfig, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return diags
+		}
+		if endConfig.RequestedProductUID.IsNull() || endConfig.RequestedProductUID.IsUnknown() {
+			continue
+		}
+		mcrUID := endConfig.RequestedProductUID.ValueString()
+		mcr, err := r.client.MCRService.GetMCR(ctx, mcrUID)
+		if err != nil {
+			diags.AddWarning(
+				"Could not validate IPsec tunnel capacity at plan time",
+				fmt.Sprintf("The lookup of MCR %s failed: %v. The Megaport API will still reject tunnels the MCR has no capacity for when the VXC is ordered.", mcrUID, err),
+			)
+			continue
+		}
+		var addOn *megaport.MCRAddOnIPsecConfig
+		for _, a := range mcr.AddOns {
+			if a != nil && a.AddOnType == megaport.AddOnTypeIPsec {
+				addOn = a
+				break
+			}
+		}
+		switch {
+		case addOn == nil:
+			diags.AddAttributeWarning(interfacesPath, "MCR has no IPsec add-on",
+				fmt.Sprintf("The VXC has %d IPsec tunnels, but MCR %q (%s) has no IPsec add-on, so the order will fail unless one is added first. If a megaport_mcr_ipsec_addon for the MCR is in this configuration, add it to the VXC's depends_on so it is created before the VXC.", tunnels, mcr.Name, mcrUID))
+		case tunnels > addOn.TunnelCount:
+			diags.AddAttributeWarning(interfacesPath, "Not enough IPsec tunnels on the MCR",
+				fmt.Sprintf("The VXC has %d IPsec tunnels, but the IPsec add-on of MCR %q (%s) has a tunnel_count of %d, so the order will fail unless the add-on is resized first. If the megaport_mcr_ipsec_addon for the MCR is being resized in this configuration, add it to the VXC's depends_on.", tunnels, mcr.Name, mcrUID, addOn.TunnelCount))
+		}
+	}
+	return diags
+}
+
+// plannedIPsecTunnelCount counts the interfaces of a virtual router partner
+// configuration that have ip_sec_tunnel_options. It returns false if the
+// count is not known yet.
+func plannedIPsecTunnelCount(ctx context.Context, partnerConfig types.Object, diags *diag.Diagnostics) (int, bool) {
+	if partnerConfig.IsNull() || partnerConfig.IsUnknown() {
+		return 0, false
+	}
+	var partner vxcPartnerConfigurationModel
+	diags.Append(partnerConfigAs(ctx, partnerConfig, &partner, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || partner.Partner.ValueString() != "vrouter" || partner.VrouterPartnerConfig.IsNull() || partner.VrouterPartnerConfig.IsUnknown() {
+		return 0, false
+	}
+	interfaces, ok := knownListElements(partner.VrouterPartnerConfig.Attributes()["interfaces"])
+	if !ok {
+		return 0, false
+	}
+	tunnels := 0
+	for _, iface := range interfaces {
+		ifaceObj, ok := iface.(types.Object)
+		if !ok || ifaceObj.IsUnknown() {
+			return 0, false
+		}
+		if ifaceObj.IsNull() {
+			continue
+		}
+		tunnel, ok := ifaceObj.Attributes()["ip_sec_tunnel_options"].(types.Object)
+		if !ok || tunnel.IsUnknown() {
+			return 0, false
+		}
+		if !tunnel.IsNull() {
+			tunnels++
+		}
+	}
+	return tunnels, true
+}
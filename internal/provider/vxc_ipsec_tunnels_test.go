@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ipSecTestConfig is a VXC from mcr to port whose A-End has a subInterface
+// with the address 192.0.2.1/24 followed by the given ipSecTunnel interfaces.
+func ipSecTestConfig(mcr, port, tunnels string) string {
+	return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_vxc" "vxc" {
+			product_name         = "IPsec VXC"
+			rate_limit           = 100
+			contract_term_months = 12
+			a_end = {
+				requested_product_uid = %q
+			}
+			a_end_partner_config = {
+				partner = "vrouter"
+				vrouter_config = {
+					interfaces = concat([{
+						interface_type = "subInterface"
+						ip_addresses   = ["192.0.2.1/24"]
+					}], %s)
+				}
+			}
+			b_end = {
+				requested_product_uid = %q
+			}
+		}`, mcr, tunnels, port)
+}
+
+func TestUnitMegaportVXC_IPsecTunnelValidation(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("IPsec Validation MCR")
+	port := api.seedPort("IPsec Validation Port")
+	step := func(tunnels, wantErr string) resource.TestStep {
+		s := resource.TestStep{Config: ipSecTestConfig(mcr, port, tunnels), PlanOnly: true, ExpectNonEmptyPlan: true}
+		if wantErr != "" {
+			s.ExpectError = regexp.MustCompile(wantErr)
+		}
+		return s
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "0.0.0.0"
+					remote_id               = "peer@example.com"
+					local_id                = "vpn.example.com"
+					generate_pre_shared_key = true
+				}
+			}]`, ""),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address      = "192.0.2.1"
+					destination_ip_address = "203.0.113.10"
+				}
+			}]`, `Missing IPsec pre-shared key`),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "203.0.113.10"
+					pre_shared_key          = "secret"
+					generate_pre_shared_key = true
+				}
+			}]`, `Conflicting IPsec pre-shared key settings`),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "2001:db8::1"
+					generate_pre_shared_key = true
+				}
+			}]`, `IPsec\s+tunnels\s+are\s+built\s+over\s+IPv4`),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "192.0.2.1"
+					generate_pre_shared_key = true
+				}
+			}]`, `destination 192.0.2.1 is the same as its source`),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "203.0.113.10"
+					remote_id               = "not an id"
+					generate_pre_shared_key = true
+				}
+			}]`, `Invalid IKE identifier`),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "0.0.0.0"
+					remote_id               = "203.0.113.10"
+					passive                 = false
+					generate_pre_shared_key = true
+				}
+			}]`, `Invalid IPsec tunnel mode`),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "0.0.0.0"
+					generate_pre_shared_key = true
+				}
+			}]`, `Missing IKE remote identifier`),
+			step(`[{
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "203.0.113.10"
+					generate_pre_shared_key = true
+				}
+			}]`, `IPsec tunnel on a sub-interface`),
+			step(`[{ interface_type = "ipSecTunnel" }]`, `Missing IPsec tunnel options`),
+			step(`[{
+				interface_type = "ipSecTunnel"
+				ip_addresses   = ["198.51.100.1/30"]
+				ip_sec_tunnel_options = {
+					source_ip_address       = "198.51.100.1"
+					destination_ip_address  = "203.0.113.10"
+					generate_pre_shared_key = true
+				}
+			}]`, `IPsec tunnel sourced from its own interface`),
+			step(`[for i in range(2) : {
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "203.0.113.10"
+					generate_pre_shared_key = true
+				}
+			}]`, `Duplicate IPsec tunnel`),
+			step(`[for i in range(31) : {
+				interface_type = "ipSecTunnel"
+				ip_sec_tunnel_options = {
+					source_ip_address       = "192.0.2.1"
+					destination_ip_address  = "203.0.113.${i + 1}"
+					generate_pre_shared_key = true
+				}
+			}]`, `an MCR supports at most 30`),
+		},
+	})
+}
+
+func TestUnitMegaportVXC_GeneratedPreSharedKey(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("IPsec MCR")
+	port := api.seedPort("IPsec Port")
+	config := ipSecTestConfig(mcr, port, `[{
+		interface_type = "ipSecTunnel"
+		ip_sec_tunnel_options = {
+			source_ip_address       = "192.0.2.1"
+			destination_ip_address  = "203.0.113.10"
+			generate_pre_shared_key = true
+		}
+	}]`)
+	tunnel := "a_end_partner_config.vrouter_config.interfaces.1.ip_sec_tunnel_options."
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			// The post-apply plan is empty, so the key is kept from state.
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("megaport_vxc.vxc", tunnel+"generated_pre_shared_key", regexp.MustCompile(`^[A-Z2-7]{26}$`)),
+					resource.TestCheckNoResourceAttr("megaport_vxc.vxc", tunnel+"pre_shared_key"),
+				),
+			},
+		},
+	})
+}
+
+func TestGenerateIPSecPreSharedKeys(t *testing.T) {
+	ctx := context.Background()
+	newIface := func(generate types.Bool, generated types.String) vxcPartnerConfigInterfaceModel {
+		tunnel, diags := types.ObjectValueFrom(ctx, ipSecTunnelOptionsAttrs, ipSecTunnelOptionsModel{
+			SourceIPAddress:       types.StringValue("192.0.2.1"),
+			DestinationIPAddress:  types.StringValue("203.0.113.10"),
+			GeneratePreSharedKey:  generate,
+			GeneratedPreSharedKey: generated,
+		})
+		require.False(t, diags.HasError(), "building tunnel: %v", diags)
+		return vxcPartnerConfigInterfaceModel{
+			InterfaceType:      types.StringValue("ipSecTunnel"),
+			IPAddresses:        types.ListNull(types.StringType),
+			IPRoutes:           types.ListNull(types.ObjectType{}.WithAttributeTypes(ipRouteAttrs)),
+			NatIPAddresses:     types.ListNull(types.StringType),
+			Bfd:                types.ObjectNull(bfdConfigAttrs),
+			BgpConnections:     types.ListNull(types.ObjectType{}.WithAttributeTypes(bgpVrouterConnectionConfig)),
+			IpSecTunnelOptions: tunnel,
+		}
+	}
+	ifaces, diags := types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(vxcVrouterInterfaceAttrs), []vxcPartnerConfigInterfaceModel{
+		newIface(types.BoolValue(true), types.StringUnknown()),
+		newIface(types.BoolValue(true), types.StringValue("KEPTKEY")),
+		newIface(types.BoolNull(), types.StringNull()),
+	})
+	require.False(t, diags.HasError(), "building interfaces: %v", diags)
+	model := vxcPartnerConfigVrouterModel{Interfaces: ifaces}
+
+	psks := map[int]string{2: "configured"}
+	diags = generateIPSecPreSharedKeys(ctx, &model, psks)
+	require.False(t, diags.HasError(), "generateIPSecPreSharedKeys: %v", diags)
+
+	assert.Regexp(t, `^[A-Z2-7]{26}$`, psks[0])
+	assert.Equal(t, "KEPTKEY", psks[1])
+	assert.Equal(t, "configured", psks[2])
+
+	got := []vxcPartnerConfigInterfaceModel{}
+	require.False(t, model.Interfaces.ElementsAs(ctx, &got, false).HasError())
+	var first ipSecTunnelOptionsModel
+	require.False(t, got[0].IpSecTunnelOptions.As(ctx, &first, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, psks[0], first.GeneratedPreSharedKey.ValueString(), "the generated key must be written back to the model")
+
+	// The provider sends the generated key in the order.
+	_, vrouterConfig, _ := createVrouterPartnerConfig(ctx, model, nil, psks, nil)
+	assert.Equal(t, psks[0], vrouterConfig.Interfaces[0].IpSecTunnelOptions.PreSharedKey)
+}
+
+func TestValidateIPsecTunnelCapacity(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	api := newFakeMegaportAPI(t)
+	r := &vxcResource{client: api.client(t)}
+
+	planWithTunnels := func(mcrUID string, tunnels int) *vxcResourceModel {
+		ifaces := []vxcPartnerConfigInterfaceModel{}
+		for range tunnels {
+			tunnel, diags := types.ObjectValueFrom(ctx, ipSecTunnelOptionsAttrs, ipSecTunnelOptionsModel{
+				SourceIPAddress:      types.StringValue("192.0.2.1"),
+				DestinationIPAddress: types.StringValue("203.0.113.10"),
+			})
+			require.False(t, diags.HasError())
+			ifaces = append(ifaces, vxcPartnerConfigInterfaceModel{
+				IPAddresses:        types.ListNull(types.StringType),
+				IPRoutes:           types.ListNull(types.ObjectType{}.WithAttributeTypes(ipRouteAttrs)),
+				NatIPAddresses:     types.ListNull(types.StringType),
+				Bfd:                types.ObjectNull(bfdConfigAttrs),
+				BgpConnections:     types.ListNull(types.ObjectType{}.WithAttributeTypes(bgpVrouterConnectionConfig)),
+				IpSecTunnelOptions: tunnel,
+			})
+		}
+		ifaceList, diags := types.ListValueFrom(ctx, types.ObjectType{}.WithAttributeTypes(vxcVrouterInterfaceAttrs), ifaces)
+		require.False(t, diags.HasError())
+		vrouter, diags := types.ObjectValueFrom(ctx, vxcPartnerConfigVrouterAttrs, vxcPartnerConfigVrouterModel{Interfaces: ifaceList})
+		require.False(t, diags.HasError())
+		partnerConfig, diags := types.ObjectValueFrom(ctx, vxcPartnerConfigAttrs, vxcPartnerConfigurationModel{
+			Partner:              types.StringValue("vrouter"),
+			AWSPartnerConfig:     types.ObjectNull(vxcPartnerConfigAWSAttrs),
+			AzurePartnerConfig:   types.ObjectNull(vxcPartnerConfigAzureAttrs),
+			GooglePartnerConfig:  types.ObjectNull(vxcPartnerConfigGoogleAttrs),
+			OraclePartnerConfig:  types.ObjectNull(vxcPartnerConfigOracleAttrs),
+			IBMPartnerConfig:     types.ObjectNull(vxcPartnerConfigIbmAttrs),
+			GenericPartnerConfig: types.ObjectNull(vxcPartnerConfigGenericAttrs),
+			VrouterPartnerConfig: vrouter,
+			PartnerAEndConfig:    types.ObjectNull(vxcPartnerConfigAEndAttrs),
+		})
+		require.False(t, diags.HasError())
+		aEnd, diags := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, vxcEndConfigurationModel{
+			RequestedProductUID: types.StringValue(mcrUID),
+			PartnerPortSelector: types.ObjectNull(vxcPartnerPortSelectorAttrs),
+		})
+		require.False(t, diags.HasError())
+		return &vxcResourceModel{
+			AEndConfiguration: aEndConfigurationValue(aEnd),
+			AEndPartnerConfig: aEndPartnerConfigValue(partnerConfig),
+			BEndConfiguration: types.ObjectNull(vxcEndConfigurationAttrs),
+			BEndPartnerConfig: types.ObjectNull(vxcPartnerConfigAttrs),
+		}
+	}
+	withAddOn := func(name string, tunnelCount int) string {
+		uid := api.seedMCR(name)
+		if tunnelCount > 0 {
+			api.update(uid, func(data map[string]any) {
+				data["addOns"] = []any{map[string]any{"addOnType": megaport.AddOnTypeIPsec, "tunnelCount": tunnelCount}}
+			})
+		}
+		return uid
+	}
+	noAddOn := withAddOn("No Add-On MCR", 0)
+	tenTunnels := withAddOn("Ten Tunnel MCR", 10)
+
+	cases := []struct {
+		name        string
+		plan        *vxcResourceModel
+		wantError   string
+		wantWarning string
+	}{
+		{"within the add-on", planWithTunnels(tenTunnels, 10), "", ""},
+		{"no tunnels", planWithTunnels(noAddOn, 0), "", ""},
+		{"no add-on", planWithTunnels(noAddOn, 1), "", "MCR has no IPsec add-on"},
+		{"more than the add-on", planWithTunnels(tenTunnels, 11), "", "Not enough IPsec tunnels on the MCR"},
+		{"more than any add-on", planWithTunnels(tenTunnels, 31), "Too many IPsec tunnels", ""},
+		{"MCR lookup fails", planWithTunnels("missing-mcr", 1), "", "Could not validate IPsec tunnel capacity at plan time"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := r.validateIPsecTunnelCapacity(ctx, tc.plan, &vxcResourceModel{})
+			var errs, warnings []string
+			for _, d := range diags.Errors() {
+				errs = append(errs, d.Summary())
+			}
+			for _, d := range diags.Warnings() {
+				warnings = append(warnings, d.Summary())
+			}
+			if tc.wantError == "" {
+				assert.Empty(t, errs)
+			} else {
+				assert.Equal(t, []string{tc.wantError}, errs)
+			}
+			if tc.wantWarning == "" {
+				assert.Empty(t, warnings)
+			} else {
+				assert.Equal(t, []string{tc.wantWarning}, warnings)
+			}
+		})
+	}
+}
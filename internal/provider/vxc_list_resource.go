@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &vxcResource{}
+	_ list.ListResourceWithConfigure = &vxcResource{}
+)
+
+// vxcListModel maps the VXC list resource configuration.
+type vxcListModel struct {
+	NameContains   types.String `tfsdk:"name_contains"`
+	AEndProductUID types.String `tfsdk:"a_end_product_uid"`
+	BEndProductUID types.String `tfsdk:"b_end_product_uid"`
+}
+
+// NewVXCListResource is a helper function to simplify the provider implementation.
+func NewVXCListResource() list.ListResource {
+	return &vxcResource{}
+}
+
+// ListResourceConfigSchema defines the filters accepted by `terraform query`.
+func (r *vxcResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the active VXCs in the account so they can be imported. Partner configuration is not returned by the API and must be added to generated configuration by hand.",
+		Attributes: map[string]listschema.Attribute{
+			"name_contains": listschema.StringAttribute{
+				Description: "Only list VXCs whose name contains this value (case-insensitive).",
+				Optional:    true,
+			},
+			"a_end_product_uid": listschema.StringAttribute{
+				Description: "Only list VXCs whose A-End is attached to this product UID.",
+				Optional:    true,
+			},
+			"b_end_product_uid": listschema.StringAttribute{
+				Description: "Only list VXCs whose B-End is attached to this product UID.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// List streams the active VXCs in the account.
+func (r *vxcResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config vxcListModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	vxcs, err := r.client.VXCService.ListVXCs(ctx, &megaport.ListVXCsRequest{
+		NameContains:    config.NameContains.ValueString(),
+		AEndProductUID:  config.AEndProductUID.ValueString(),
+		BEndProductUID:  config.BEndProductUID.ValueString(),
+		IncludeInactive: false,
+	})
+	if err != nil {
+		diags.AddError(
+			"Error listing VXCs",
+			"Could not list VXCs: "+err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for _, vxc := range vxcs {
+			if listLimitReached(req, count) {
+				return
+			}
+			if isInactiveProduct(vxc.ProvisioningStatus) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = vxc.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, productIdentityModel{ProductUID: types.StringValue(vxc.UID)})...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				var state vxcResourceModel
+				result.Diagnostics.Append(newListResultModel(ctx, req, &state)...)
+
+				tags, err := r.client.VXCService.ListVXCResourceTags(ctx, vxc.UID)
+				if err != nil {
+					result.Diagnostics.AddWarning(
+						"Error reading tags for VXC",
+						fmt.Sprintf("Could not read tags for VXC with ID %s: %s", vxc.UID, err.Error()),
+					)
+				}
+
+				if !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(state.fromAPIVXC(ctx, vxc, tags, nil)...)
+					result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+				}
+			}
+
+			count++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// Values of move_strategy.
+const (
+	vxcMoveInPlace         = "in_place"
+	vxcMoveMakeBeforeBreak = "make_before_break"
+)
+
+// vxcMove is a make_before_break move of one or both ends of a VXC to a new
+// product.
+type vxcMove struct {
+	aEnd, bEnd bool
+}
+
+func (m vxcMove) planned() bool { return m.aEnd || m.bEnd }
+
+// plannedVXCMove returns the ends of the VXC in state that plan moves to a
+// new product with make_before_break. An end moves under the same conditions
+// Update sends a new product UID for it in place: the requested product
+// differs from the one in state, and the end is not a cloud partner port,
+// which reconcileVXCEnd pins. A requested product that is not known yet may
+// turn out to be a move, and is counted as one.
+func plannedVXCMove(ctx context.Context, plan, state *vxcResourceModel, diags *diag.Diagnostics) vxcMove {
+	if plan.MoveStrategy.ValueString() != vxcMoveMakeBeforeBreak || state.UID.IsNull() ||
+		anyVXCEndObjectUnknownOrNull(plan.AEndConfiguration, plan.BEndConfiguration, state.AEndConfiguration, state.BEndConfiguration) {
+		return vxcMove{}
+	}
+	moves := func(planEndObj, stateEndObj, partnerConfig types.Object) bool {
+		var planEnd, stateEnd vxcEndConfigurationModel
+		diags.Append(endConfigurationAs(ctx, planEndObj, &planEnd, basetypes.ObjectAsOptions{})...)
+		diags.Append(endConfigurationAs(ctx, stateEndObj, &stateEnd, basetypes.ObjectAsOptions{})...)
+		uid := planEnd.RequestedProductUID
+		if uid.IsNull() || uid.ValueString() == "" && !uid.IsUnknown() || isCSPPartnerConfig(ctx, partnerConfig, diags) {
+			return false
+		}
+		return uid.IsUnknown() || !uid.Equal(stateEnd.RequestedProductUID) && !uid.Equal(stateEnd.CurrentProductUID)
+	}
+	return vxcMove{
+		aEnd: moves(plan.AEndConfiguration, state.AEndConfiguration, plan.AEndPartnerConfig),
+		bEnd: moves(plan.BEndConfiguration, state.BEndConfiguration, plan.BEndPartnerConfig),
+	}
+}
+
+// planMakeBeforeBreakMove checks a planned make_before_break move and marks
+// what the new VXC will change as unknown in plan. A cloud partner connection
+// or a service key can only be used by one VXC at a time, so VXCs with either
+// cannot be moved this way.
+func planMakeBeforeBreakMove(ctx context.Context, plan *vxcResourceModel, move vxcMove, diags *diag.Diagnostics) {
+	strategyPath := path.Root("move_strategy")
+	for _, end := range []struct {
+		label         string
+		partnerConfig types.Object
+	}{{"A-End", plan.AEndPartnerConfig}, {"B-End", plan.BEndPartnerConfig}} {
+		if isCSPPartnerConfig(ctx, end.partnerConfig, diags) {
+			diags.AddAttributeError(strategyPath, "VXC cannot be moved with make_before_break",
+				fmt.Sprintf("The %s of the VXC connects to a cloud partner, which cannot be connected to a second VXC while it moves. Set move_strategy = %q to move it in place.", end.label, vxcMoveInPlace))
+		}
+	}
+	if !plan.ServiceKey.IsNull() {
+		diags.AddAttributeError(strategyPath, "VXC cannot be moved with make_before_break",
+			fmt.Sprintf("The VXC was ordered with a service key, which cannot be used for a second VXC while it moves. Set move_strategy = %q to move it in place.", vxcMoveInPlace))
+	}
+	if diags.HasError() {
+		return
+	}
+
+	plan.UID = types.StringUnknown()
+	plan.ID = types.Int64Unknown()
+	plan.ServiceID = types.Int64Unknown()
+	plan.SecondaryName = types.StringUnknown()
+	plan.CreatedBy = types.StringUnknown()
+	plan.DistanceBand = types.StringUnknown()
+	plan.UsageAlgorithm = types.StringUnknown()
+	plan.Locked = types.BoolUnknown()
+	plan.AdminLocked = types.BoolUnknown()
+	plan.Cancelable = types.BoolUnknown()
+	plan.AttributeTags = types.MapUnknown(types.StringType)
+	plan.CSPConnections = types.ListUnknown(types.ObjectType{AttrTypes: cspConnectionFullAttrs})
+}
+
+// moveVXCMakeBeforeBreak moves the VXC in state to the products in plan by
+// ordering a new VXC alongside it, and deleting the old VXC once the new one
+// is provisioned and the BGP sessions configured on its MCR ends are up. If
+// the new VXC fails to come up it is deleted again and state is left alone.
+// On success plan holds the new VXC.
+//
+// The new VXC cannot use the VLANs the old one holds on an end that stays on
+// the same product, so it is ordered with a VLAN assigned there, and moved to
+// the planned VLAN once the old VXC is gone.
+func (r *vxcResource) moveVXCMakeBeforeBreak(ctx context.Context, config tfsdk.Config, plan, state *vxcResourceModel, move vxcMove, provisionTimeout, propagationTimeout time.Duration) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	oldUID := state.UID.ValueString()
+	logFields := map[string]any{"vxc_uid": oldUID, "move_a_end": move.aEnd, "move_b_end": move.bEnd}
+
+	buyReq := r.buyVXCRequestFromPlan(ctx, config, plan, &diags)
+	if diags.HasError() {
+		return diags
+	}
+	buyReq.WaitForProvision = false
+
+	restoreVLAN := &megaport.UpdateVXCRequest{}
+	if !move.aEnd && buyReq.AEndConfiguration.VLAN != 0 {
+		restoreVLAN.AEndVLAN = megaport.PtrTo(buyReq.AEndConfiguration.VLAN)
+		buyReq.AEndConfiguration.VLAN = 0
+	}
+	if !move.bEnd && buyReq.BEndConfiguration.VLAN != 0 {
+		restoreVLAN.BEndVLAN = megaport.PtrTo(buyReq.BEndConfiguration.VLAN)
+		buyReq.BEndConfiguration.VLAN = 0
+	}
+
+	if err := r.client.VXCService.ValidateVXCOrder(ctx, buyReq); err != nil {
+		diags.AddError("Error moving VXC",
+			fmt.Sprintf("Could not move VXC %s: the new VXC was not accepted: %s. Nothing has been changed.", oldUID, err))
+		return diags
+	}
+	tflog.Info(ctx, "Moving VXC with make_before_break: ordering the new VXC", logFields)
+	ordered, err := r.client.VXCService.BuyVXC(ctx, buyReq)
+	if err != nil {
+		diags.AddError("Error moving VXC",
+			fmt.Sprintf("Could not move VXC %s: ordering the new VXC failed: %s. Nothing has been changed.", oldUID, err))
+		return diags
+	}
+	newUID := ordered.TechnicalServiceUID
+	logFields["new_vxc_uid"] = newUID
+
+	// rollback deletes the new VXC after it failed to come up, leaving the
+	// old one in place.
+	rollback := func(reason string) {
+		tflog.Warn(ctx, "Moving VXC with make_before_break: rolling back", logFields)
+		err := r.client.VXCService.DeleteVXC(ctx, newUID, &megaport.DeleteVXCRequest{DeleteNow: true})
+		if err != nil {
+			diags.AddError("Error moving VXC",
+				fmt.Sprintf("Could not move VXC %s: %s. Deleting the new VXC %s failed as well: %s. VXC %s is unchanged, but VXC %s must be deleted manually.", oldUID, reason, newUID, err, oldUID, newUID))
+			return
+		}
+		diags.AddError("Error moving VXC",
+			fmt.Sprintf("Could not move VXC %s: %s. The new VXC %s has been deleted and VXC %s is unchanged.", oldUID, reason, newUID, oldUID))
+	}
+
+	tflog.Info(ctx, "Moving VXC with make_before_break: waiting for the new VXC to provision", logFields)
+	if err := r.waitForVXCProvision(ctx, newUID, provisionTimeout, r.pollInterval); err != nil {
+		rollback("the new VXC did not provision: " + err.Error())
+		return diags
+	}
+	expectedAEndVnic, expectedBEndVnic := expectedVnicIndexes(ctx, plan, &diags)
+	vxc, err := r.waitForVnicIndex(ctx, newUID, expectedAEndVnic, expectedBEndVnic, propagationTimeout)
+	if vxc == nil {
+		rollback("the new VXC could not be read: " + err.Error())
+		return diags
+	}
+	if err != nil {
+		diags.AddWarning("VXC vnic_index Propagation Delay",
+			fmt.Sprintf("The new VXC %s was provisioned but vnic_index verification timed out: %s. The update may still be propagating.", newUID, err.Error()))
+	}
+
+	peers, err := r.vxcBGPPeers(ctx, plan, vxc)
+	if err != nil {
+		rollback("could not find the BGP sessions to wait for: " + err.Error())
+		return diags
+	}
+	if len(peers) > 0 {
+		tflog.Info(ctx, "Moving VXC with make_before_break: waiting for BGP sessions on the new VXC", logFields)
+		if err := r.waitForVXCBGP(ctx, vxc.ID, peers, provisionTimeout, r.pollInterval); err != nil {
+			rollback("the BGP sessions of the new VXC did not come up: " + err.Error())
+			return diags
+		}
+	}
+
+	tflog.Info(ctx, "Moving VXC with make_before_break: deleting the old VXC", logFields)
+	if err := r.client.VXCService.DeleteVXC(ctx, oldUID, &megaport.DeleteVXCRequest{DeleteNow: true}); err != nil {
+		rollback("deleting the old VXC failed: " + err.Error())
+		return diags
+	}
+
+	// From here on the old VXC is gone, so failures are reported against the
+	// new one and it is saved to state regardless.
+	if restoreVLAN.AEndVLAN != nil || restoreVLAN.BEndVLAN != nil {
+		tflog.Info(ctx, "Moving VXC with make_before_break: moving the new VXC to the planned VLANs", logFields)
+		_, err := r.client.VXCService.UpdateVXC(ctx, newUID, restoreVLAN)
+		if err == nil {
+			err = r.waitForVXCUpdate(ctx, newUID, restoreVLAN, propagationTimeout)
+		}
+		if err != nil {
+			diags.AddError("Error moving VXC",
+				fmt.Sprintf("VXC %s was moved to the new VXC %s, but could not be moved to the planned VLANs: %s. Apply again to retry.", oldUID, newUID, err))
+		}
+	}
+	if latest, err := r.client.VXCService.GetVXC(ctx, newUID); err == nil {
+		vxc = latest
+	} else {
+		diags.AddWarning("Error reading moved VXC",
+			fmt.Sprintf("Could not read the new VXC %s after the move: %s. State may be out of date until the next refresh.", newUID, err))
+	}
+	tags, err := r.client.VXCService.ListVXCResourceTags(ctx, newUID)
+	if err != nil {
+		diags.AddError("Error reading tags for moved VXC",
+			fmt.Sprintf("Could not read tags for the new VXC %s: %s", newUID, err))
+	}
+
+	diags.Append(plan.fromAPIVXC(ctx, vxc, tags, plan)...)
+	if restoreVLAN.AEndVLAN != nil && vxc.AEndConfiguration.VLAN != *restoreVLAN.AEndVLAN {
+		diags.Append(setEndOrderedVLAN(ctx, &plan.AEndConfiguration, vxc.AEndConfiguration.VLAN)...)
+		plan.AEndConfiguration = aEndConfigurationValue(plan.AEndConfiguration)
+	}
+	if restoreVLAN.BEndVLAN != nil && vxc.BEndConfiguration.VLAN != *restoreVLAN.BEndVLAN {
+		diags.Append(setEndOrderedVLAN(ctx, &plan.BEndConfiguration, vxc.BEndConfiguration.VLAN)...)
+	}
+	tflog.Info(ctx, "Moved VXC with make_before_break", logFields)
+	return diags
+}
+
+// setEndOrderedVLAN records vlan as the ordered VLAN of an end, so that the
+// next plan requests the configured VLAN again.
+func setEndOrderedVLAN(ctx context.Context, endObj *types.Object, vlan int) diag.Diagnostics {
+	var end vxcEndConfigurationModel
+	diags := endConfigurationAs(ctx, *endObj, &end, basetypes.ObjectAsOptions{})
+	end.OrderedVLAN = types.Int64Value(int64(vlan))
+	obj, objDiags := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, end)
+	diags.Append(objDiags...)
+	*endObj = obj
+	return diags
+}
+
+// expectedVnicIndexes returns the vnic_index planned for each end of a VXC,
+// or nil for an end without one.
+func expectedVnicIndexes(ctx context.Context, plan *vxcResourceModel, diags *diag.Diagnostics) (aEnd, bEnd *int) {
+	index := func(endObj types.Object) *int {
+		var end vxcEndConfigurationModel
+		diags.Append(endConfigurationAs(ctx, endObj, &end, basetypes.ObjectAsOptions{})...)
+		if end.NetworkInterfaceIndex.IsNull() || end.NetworkInterfaceIndex.IsUnknown() {
+			return nil
+		}
+		return megaport.PtrTo(int(end.NetworkInterfaceIndex.ValueInt64()))
+	}
+	return index(plan.AEndConfiguration), index(plan.BEndConfiguration)
+}
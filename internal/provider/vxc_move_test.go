@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	megaport "github.com/megaport/megaportgo"
+)
+
+func TestUnitMegaportVXC_MakeBeforeBreakMove(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("Move MCR")
+	firstPort := api.seedPort("Move First Port")
+	secondPort := api.seedPort("Move Second Port")
+	config := func(bEnd string) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_vxc" "vxc" {
+			product_name         = "Move VXC"
+			rate_limit           = 100
+			contract_term_months = 12
+			move_strategy        = "make_before_break"
+			a_end = {
+				requested_product_uid = %q
+				ordered_vlan          = 100
+			}
+			a_end_partner_config = {
+				partner = "vrouter"
+				vrouter_config = {
+					interfaces = [{
+						ip_addresses = ["10.0.0.1/30"]
+						bgp_connections = [{
+							peer_asn         = 64512
+							local_ip_address = "10.0.0.1"
+							peer_ip_address  = "10.0.0.2"
+						}]
+					}]
+				}
+			}
+			b_end = {
+				requested_product_uid = %q
+				ordered_vlan          = 200
+			}
+			timeouts {
+				update = "5s"
+			}
+		}`, mcr, bEnd)
+	}
+
+	var firstUID, secondUID string
+	liveVXCs := func(want ...*string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			var live []string
+			for _, uid := range want {
+				data, ok := api.product(*uid)
+				if !ok || data["provisioningStatus"] != megaport.SERVICE_LIVE && data["provisioningStatus"] != megaport.SERVICE_CONFIGURED {
+					return fmt.Errorf("VXC %s is not live", *uid)
+				}
+			}
+			api.mu.Lock()
+			defer api.mu.Unlock()
+			for uid, p := range api.products {
+				if p.productType() == "VXC" && !p.terminated() {
+					live = append(live, uid)
+				}
+			}
+			if len(live) != len(want) {
+				return fmt.Errorf("expected %d live VXCs, found %v", len(want), live)
+			}
+			return nil
+		}
+	}
+	bgpDown, bgpUp := bgpPeerDown("")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config(firstPort),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("megaport_vxc.vxc", "product_uid", func(uid string) error {
+						firstUID = uid
+						return nil
+					}),
+				),
+			},
+			{
+				Config: config(secondPort),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("megaport_vxc.vxc", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("megaport_vxc.vxc", tfjsonpath.New("product_uid")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("megaport_vxc.vxc", "product_uid", func(uid string) error {
+						if uid == firstUID {
+							return fmt.Errorf("product_uid is still %s", uid)
+						}
+						secondUID = uid
+						return nil
+					}),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "b_end.current_product_uid", secondPort),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "a_end.vlan", "100"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "b_end.vlan", "200"),
+					liveVXCs(&secondUID),
+				),
+			},
+			{
+				PreConfig:   func() { api.inject(bgpDown) },
+				Config:      config(firstPort),
+				ExpectError: regexp.MustCompile(`BGP sessions of\s+the\s+new\s+VXC\s+did\s+not\s+come\s+up`),
+			},
+			// The failed move leaves the VXC as it was.
+			{
+				PreConfig: bgpUp,
+				Config:    config(secondPort),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: liveVXCs(&secondUID),
+			},
+		},
+	})
+}
+
+func TestUnitMegaportVXC_MakeBeforeBreakMoveCloudPartner(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	firstPort := api.seedPort("Move Cloud First Port")
+	secondPort := api.seedPort("Move Cloud Second Port")
+	api.seedPartnerPort("ALIBABA", "alibaba-key")
+	config := func(aEnd string) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_vxc" "vxc" {
+			product_name         = "Move Cloud VXC"
+			rate_limit           = 100
+			contract_term_months = 12
+			move_strategy        = "make_before_break"
+			a_end = {
+				requested_product_uid = %q
+			}
+			b_end = {}
+			b_end_partner_config = {
+				partner = "generic"
+				generic_config = {
+					connect_type = "ALIBABA"
+					pairing_key  = "alibaba-key"
+				}
+			}
+		}`, aEnd)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config(firstPort),
+			},
+			{
+				Config:      config(secondPort),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`The B-End of the VXC connects to a cloud partner`),
+			},
+		},
+	})
+}
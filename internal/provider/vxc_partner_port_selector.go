@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// selectBEndPartnerPort resolves b_end.partner_port_selector into
+// b_end.requested_product_uid in plan. A new VXC gets the best matching port.
+// An existing VXC keeps the port it was ordered on, with a warning if the
+// selector now matches a different one, the same way a rotated partner port
+// is handled in reconcileVXCEnd. It reports whether plan was changed.
+func (r *vxcResource) selectBEndPartnerPort(ctx context.Context, config tfsdk.Config, plan, state *vxcResourceModel, diags *diag.Diagnostics) bool {
+	selectorPath := path.Root("b_end").AtName("partner_port_selector")
+
+	if plan.BEndConfiguration.IsNull() || plan.BEndConfiguration.IsUnknown() {
+		return false
+	}
+	var bEnd vxcEndConfigurationModel
+	diags.Append(plan.BEndConfiguration.As(ctx, &bEnd, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || bEnd.PartnerPortSelector.IsNull() || bEnd.PartnerPortSelector.IsUnknown() {
+		return false
+	}
+
+	var configured types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("b_end").AtName("requested_product_uid"), &configured)...)
+	if !configured.IsNull() {
+		diags.AddAttributeError(selectorPath, "Conflicting B-End partner port",
+			"b_end.partner_port_selector cannot be used together with b_end.requested_product_uid. Remove one of them.")
+		return false
+	}
+
+	var selector vxcPartnerPortSelectorModel
+	diags.Append(bEnd.PartnerPortSelector.As(ctx, &selector, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || !selector.isKnown() || r.client == nil {
+		return false
+	}
+
+	var stateUID string
+	if !state.UID.IsNull() && !state.BEndConfiguration.IsNull() {
+		var stateBEnd vxcEndConfigurationModel
+		diags.Append(state.BEndConfiguration.As(ctx, &stateBEnd, basetypes.ObjectAsOptions{})...)
+		stateUID = stateBEnd.RequestedProductUID.ValueString()
+	}
+
+	port, err := r.findPartnerPort(ctx, selector)
+	switch {
+	case err != nil && stateUID == "":
+		diags.AddAttributeError(selectorPath, "Could not select a partner port for the B-End", err.Error())
+		return false
+	case err != nil:
+		diags.AddAttributeWarning(selectorPath, "Could not check the partner port selected for the B-End",
+			fmt.Sprintf("%s. The VXC stays on partner port %s.", err.Error(), stateUID))
+		bEnd.RequestedProductUID = types.StringValue(stateUID)
+	case stateUID == "":
+		bEnd.RequestedProductUID = types.StringValue(port.ProductUID)
+	default:
+		if port.ProductUID != stateUID {
+			diags.AddAttributeWarning(selectorPath, "The partner port selected for the B-End has changed",
+				fmt.Sprintf("b_end.partner_port_selector now matches partner port %q (%s), but the VXC was ordered on %s and stays there. To move the VXC to the new port, replace it with terraform apply -replace.",
+					port.ProductName, port.ProductUID, stateUID))
+		}
+		bEnd.RequestedProductUID = types.StringValue(stateUID)
+	}
+
+	bEndObj, objDiags := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, bEnd)
+	diags.Append(objDiags...)
+	plan.BEndConfiguration = bEndObj
+	return true
+}
+
+// isKnown reports whether all of the selector's filters are known, so that
+// the port it selects can be.
+func (s vxcPartnerPortSelectorModel) isKnown() bool {
+	return !s.ConnectType.IsUnknown() && !s.CompanyName.IsUnknown() && !s.ProductName.IsUnknown() &&
+		!s.LocationID.IsUnknown() && !s.Metro.IsUnknown() && !s.DiversityZone.IsUnknown()
+}
+
+// findPartnerPort returns the highest ranked partner port accepting VXCs that
+// matches selector, filtering the same way the megaport_partner data source
+// does.
+func (r *vxcResource) findPartnerPort(ctx context.Context, selector vxcPartnerPortSelectorModel) (*megaport.PartnerMegaport, error) {
+	ports, err := r.client.PartnerService.ListPartnerMegaports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list partner ports: %w", err)
+	}
+
+	filters := [](func(*megaport.PartnerMegaport) bool){
+		filterByVXCPermitted(true),
+		filterByConnectType(selector.ConnectType.ValueString()),
+	}
+	if !selector.ProductName.IsNull() {
+		filters = append(filters, filterByProductName(selector.ProductName.ValueString()))
+	}
+	if !selector.CompanyName.IsNull() {
+		filters = append(filters, filterByCompanyName(selector.CompanyName.ValueString()))
+	}
+	if !selector.LocationID.IsNull() {
+		filters = append(filters, filterByLocationID(int(selector.LocationID.ValueInt64())))
+	}
+	if !selector.DiversityZone.IsNull() {
+		filters = append(filters, filterByDiversityZone(selector.DiversityZone.ValueString()))
+	}
+	if !selector.Metro.IsNull() {
+		locations, err := r.client.LocationService.ListLocationsV3(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not list locations: %w", err)
+		}
+		locationIDs := map[int]bool{}
+		for _, l := range locations {
+			if strings.EqualFold(l.Metro, selector.Metro.ValueString()) {
+				locationIDs[l.ID] = true
+			}
+		}
+		filters = append(filters, filterByLocationIDs(locationIDs))
+	}
+
+	ports = runFiltersAndSort(ports, filters)
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("no %s partner ports accepting VXCs match b_end.partner_port_selector", selector.ConnectType.ValueString())
+	}
+	return ports[0], nil
+}
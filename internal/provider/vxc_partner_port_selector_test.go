@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	megaport "github.com/megaport/megaportgo"
+)
+
+func TestUnitMegaportVXC_PartnerPortSelector(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	aEnd := api.seedPort("Selector A-End")
+	redPort := api.seedPartnerPort("GOOGLE", "google-key")
+	bluePort := api.seedPartnerPort("GOOGLE", "google-key-blue")
+	api.setPartnerPort(bluePort, func(port *megaport.PartnerMegaport) {
+		port.DiversityZone = "blue"
+		port.Rank = 2
+	})
+
+	vxc := func(name, bEnd string) string {
+		return fmt.Sprintf(`
+		resource "megaport_vxc" %q {
+			product_name         = %q
+			rate_limit           = 100
+			contract_term_months = 12
+			a_end = {
+				requested_product_uid = %q
+			}
+			b_end = {
+				%s
+			}
+			b_end_partner_config = {
+				partner = "google"
+				google_config = {
+					pairing_key = "google-key/australia-southeast1/2"
+				}
+			}
+		}`, name, name, aEnd, bEnd)
+	}
+	blueSelector := `partner_port_selector = {
+					connect_type   = "GOOGLE"
+					metro          = "sydney"
+					diversity_zone = "blue"
+				}`
+	config := fakeProviderConfig + vxc("selected", blueSelector)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_vxc.selected", "b_end.requested_product_uid", bluePort),
+					resource.TestCheckResourceAttr("megaport_vxc.selected", "b_end.current_product_uid", bluePort),
+					resource.TestCheckResourceAttr("megaport_vxc.selected", "b_end.partner_port_selector.diversity_zone", "blue"),
+				),
+			},
+			// A better ranked port appearing in the zone does not move the VXC.
+			{
+				PreConfig: func() {
+					api.setPartnerPort(bluePort, func(port *megaport.PartnerMegaport) { port.VXCPermitted = false })
+					api.setPartnerPort(redPort, func(port *megaport.PartnerMegaport) { port.DiversityZone = "blue" })
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config:      config + vxc("unmatched", `partner_port_selector = { connect_type = "GOOGLE", metro = "Melbourne" }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`no GOOGLE partner ports accepting VXCs match`),
+			},
+			{
+				Config: config + vxc("conflicting", `requested_product_uid = "`+redPort+`"
+				`+blueSelector),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting B-End partner port`),
+			},
+		},
+	})
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	megaport "github.com/megaport/megaportgo"
+)
+
+// vxcPartnerPortRequest is what a planned VXC asks of the partner port on its
+// B-End.
+type vxcPartnerPortRequest struct {
+	// productUID is the partner port requested in b_end.
+	productUID string
+	// partner is the b_end_partner_config partner, and connectType the
+	// connect type of the partner port it needs.
+	partner     string
+	connectType string
+	// rateLimit is zero when not known yet.
+	rateLimit int64
+	// orderedVLAN is nil when not set or not known yet.
+	orderedVLAN *int64
+	// diversityZone is the zone the partner configuration is for, if it
+	// implies one, with the attribute that implies it.
+	diversityZone     string
+	diversityZonePath path.Path
+	// newConnection is set when the VXC is being ordered to the port, rather
+	// than changed in place on it. The port's connect type, zone and whether
+	// it accepts new VXCs only matter for new connections, as a port can
+	// change after a VXC has been connected to it.
+	newConnection bool
+}
+
+// validateBEndPartnerPort checks the partner port requested for a VXC's B-End
+// against its partner configuration at plan time, so that combinations the
+// port cannot accept fail the plan instead of the order. It is a no-op unless
+// b_end.requested_product_uid is a partner port and the VXC is new or is
+// changing how it uses the port. A failed partner port lookup is reported as
+// a warning and the order is left to the API to validate.
+func (r *vxcResource) validateBEndPartnerPort(ctx context.Context, plan, state *vxcResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	req, ok := vxcPartnerPortRequestFromPlan(ctx, plan, state, &diags)
+	if !ok || diags.HasError() || r.client == nil {
+		return diags
+	}
+
+	ports, err := r.client.PartnerService.ListPartnerMegaports(ctx)
+	if err != nil {
+		diags.AddWarning(
+			"Could not validate VXC partner port at plan time",
+			fmt.Sprintf("The partner port lookup failed: %v. The Megaport API will still reject a partner port that cannot accept the VXC when it is ordered.", err),
+		)
+		return diags
+	}
+	diags.Append(checkVXCPartnerPort(ports, req)...)
+	return diags
+}
+
+// vxcPartnerPortRequestFromPlan collects what plan needs from its B-End
+// partner port. It returns false when there is nothing to validate: the
+// B-End is not a cloud partner, the port is not known yet, or nothing that
+// depends on the port is changing from state.
+func vxcPartnerPortRequestFromPlan(ctx context.Context, plan, state *vxcResourceModel, diags *diag.Diagnostics) (vxcPartnerPortRequest, bool) {
+	req := vxcPartnerPortRequest{}
+	if plan.BEndPartnerConfig.IsNull() || plan.BEndPartnerConfig.IsUnknown() || plan.BEndConfiguration.IsNull() || plan.BEndConfiguration.IsUnknown() {
+		return req, false
+	}
+
+	var bEnd vxcEndConfigurationModel
+	diags.Append(plan.BEndConfiguration.As(ctx, &bEnd, basetypes.ObjectAsOptions{})...)
+	var partnerConfig vxcPartnerConfigurationModel
+	diags.Append(partnerConfigAs(ctx, plan.BEndPartnerConfig, &partnerConfig, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return req, false
+	}
+	if bEnd.RequestedProductUID.IsNull() || bEnd.RequestedProductUID.IsUnknown() || bEnd.RequestedProductUID.ValueString() == "" {
+		return req, false
+	}
+	req.productUID = bEnd.RequestedProductUID.ValueString()
+	req.partner = partnerConfig.Partner.ValueString()
+
+	switch req.partner {
+	case "aws":
+		var aws vxcPartnerConfigAWSModel
+		if partnerConfig.AWSPartnerConfig.IsNull() || partnerConfig.AWSPartnerConfig.IsUnknown() {
+			return req, false
+		}
+		diags.Append(partnerConfig.AWSPartnerConfig.As(ctx, &aws, basetypes.ObjectAsOptions{})...)
+		req.connectType = aws.ConnectType.ValueString()
+	case "azure":
+		req.connectType = "AZURE"
+	case "google":
+		req.connectType = "GOOGLE"
+		var google vxcPartnerConfigGoogleModel
+		if !partnerConfig.GooglePartnerConfig.IsNull() && !partnerConfig.GooglePartnerConfig.IsUnknown() {
+			diags.Append(partnerConfig.GooglePartnerConfig.As(ctx, &google, basetypes.ObjectAsOptions{})...)
+			req.diversityZone = googlePairingKeyDiversityZone(google.PairingKey.ValueString())
+			req.diversityZonePath = path.Root("b_end_partner_config").AtName("google_config").AtName("pairing_key")
+		}
+	case "oracle":
+		req.connectType = "ORACLE"
+	case "ibm":
+		req.connectType = "IBM"
+	case "generic":
+		var generic vxcPartnerConfigGenericModel
+		if partnerConfig.GenericPartnerConfig.IsNull() || partnerConfig.GenericPartnerConfig.IsUnknown() {
+			return req, false
+		}
+		diags.Append(partnerConfig.GenericPartnerConfig.As(ctx, &generic, basetypes.ObjectAsOptions{})...)
+		req.connectType = generic.ConnectType.ValueString()
+	default:
+		// Transit and virtual router B-Ends are not cloud partner ports.
+		return req, false
+	}
+
+	if !plan.RateLimit.IsNull() && !plan.RateLimit.IsUnknown() {
+		req.rateLimit = plan.RateLimit.ValueInt64()
+	}
+	if !bEnd.OrderedVLAN.IsNull() && !bEnd.OrderedVLAN.IsUnknown() {
+		vlan := bEnd.OrderedVLAN.ValueInt64()
+		req.orderedVLAN = &vlan
+	}
+
+	if state == nil || state.UID.IsNull() {
+		req.newConnection = true
+		return req, true
+	}
+	var stateBEnd vxcEndConfigurationModel
+	if !state.BEndConfiguration.IsNull() {
+		diags.Append(state.BEndConfiguration.As(ctx, &stateBEnd, basetypes.ObjectAsOptions{})...)
+	}
+	// A changed partner port or partner configuration replaces the VXC, see
+	// reconcileVXCEnd.
+	req.newConnection = !bEnd.RequestedProductUID.Equal(stateBEnd.RequestedProductUID) || !plan.BEndPartnerConfig.Equal(state.BEndPartnerConfig)
+	if !req.newConnection && plan.RateLimit.Equal(state.RateLimit) {
+		return req, false
+	}
+	return req, true
+}
+
+// googlePairingKeyDiversityZone returns the diversity zone encoded in the
+// availability domain at the end of a Google pairing key, or "" if the key
+// does not end in one. Domain 1 is the red zone and domain 2 the blue zone.
+func googlePairingKeyDiversityZone(pairingKey string) string {
+	i := strings.LastIndex(pairingKey, "/")
+	if i < 0 {
+		return ""
+	}
+	switch pairingKey[i+1:] {
+	case "1":
+		return "red"
+	case "2":
+		return "blue"
+	}
+	return ""
+}
+
+// checkVXCPartnerPort checks req against the partner port it names in ports.
+// Ports that are not listed, such as a customer's own port, are not checked.
+func checkVXCPartnerPort(ports []*megaport.PartnerMegaport, req vxcPartnerPortRequest) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	matches := runFiltersAndSort(ports, [](func(*megaport.PartnerMegaport) bool){
+		func(pm *megaport.PartnerMegaport) bool { return pm.ProductUID != req.productUID },
+	})
+	if len(matches) == 0 {
+		return diags
+	}
+	port := matches[0]
+	portPath := path.Root("b_end").AtName("requested_product_uid")
+
+	if req.newConnection {
+		if req.connectType != "" && filterByConnectType(req.connectType)(port) {
+			diags.AddAttributeError(portPath, "VXC partner port does not match the partner configuration",
+				fmt.Sprintf("Partner port %q (%s) is a %s port, but the %q partner configuration needs a %s port. Use the megaport_partner data source with connect_type = %q to find one.",
+					port.ProductName, port.ProductUID, port.ConnectType, req.partner, req.connectType, req.connectType))
+		}
+		if filterByVXCPermitted(true)(port) {
+			diags.AddAttributeError(portPath, "VXCs are not permitted on the partner port",
+				fmt.Sprintf("Partner port %q (%s) is not accepting new VXCs. Megaport rotates partner ports as capacity is used; look the port up again with the megaport_partner data source, which only returns ports that accept VXCs.",
+					port.ProductName, port.ProductUID))
+		}
+		if req.diversityZone != "" && filterByDiversityZone(req.diversityZone)(port) {
+			diags.AddAttributeError(req.diversityZonePath, "VXC partner port is in the wrong diversity zone",
+				fmt.Sprintf("The pairing key is for the %s diversity zone, but partner port %q (%s) is in the %s zone. Set diversity_zone = %q on the megaport_partner data source used for b_end.requested_product_uid.",
+					req.diversityZone, port.ProductName, port.ProductUID, port.DiversityZone, req.diversityZone))
+		}
+		if req.orderedVLAN != nil && *req.orderedVLAN == -1 {
+			diags.AddAttributeError(path.Root("b_end").AtName("ordered_vlan"), "VXC to a partner port cannot be untagged",
+				fmt.Sprintf("Partner port %q (%s) is shared with other customers, so the B-End of a VXC to it must be on a VLAN. Remove ordered_vlan, or set it to 0 to have a VLAN assigned.",
+					port.ProductName, port.ProductUID))
+		}
+	}
+
+	if req.rateLimit > 0 && port.Speed > 0 && req.rateLimit > int64(port.Speed) {
+		diags.AddAttributeError(path.Root("rate_limit"), "VXC rate limit exceeds the partner port speed",
+			fmt.Sprintf("rate_limit is %d Mbps, but partner port %q (%s) is only %d Mbps.", req.rateLimit, port.ProductName, port.ProductUID, port.Speed))
+	}
+	return diags
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	megaport "github.com/megaport/megaportgo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVXCPartnerPort(t *testing.T) {
+	ports := []*megaport.PartnerMegaport{
+		{ConnectType: "GOOGLE", ProductUID: "google-red", ProductName: "Google Red", DiversityZone: "red", Speed: 10000, VXCPermitted: true},
+		{ConnectType: "AWSHC", ProductUID: "aws-full", ProductName: "AWS Full", DiversityZone: "blue", Speed: 10000, VXCPermitted: false},
+		{ConnectType: "AWSHC", ProductUID: "aws-small", ProductName: "AWS Small", DiversityZone: "blue", Speed: 1000, VXCPermitted: true},
+	}
+	untagged := int64(-1)
+	tagged := int64(200)
+	pairingKeyPath := path.Root("b_end_partner_config").AtName("google_config").AtName("pairing_key")
+
+	for _, tc := range []struct {
+		name      string
+		req       vxcPartnerPortRequest
+		wantPaths []path.Path
+	}{
+		{
+			name: "matching port",
+			req:  vxcPartnerPortRequest{productUID: "google-red", partner: "google", connectType: "GOOGLE", rateLimit: 1000, orderedVLAN: &tagged, diversityZone: "red", diversityZonePath: pairingKeyPath, newConnection: true},
+		},
+		{
+			name: "not a partner port",
+			req:  vxcPartnerPortRequest{productUID: "customer-port", partner: "google", connectType: "GOOGLE", rateLimit: 100000, newConnection: true},
+		},
+		{
+			name:      "wrong connect type",
+			req:       vxcPartnerPortRequest{productUID: "google-red", partner: "aws", connectType: "AWSHC", newConnection: true},
+			wantPaths: []path.Path{path.Root("b_end").AtName("requested_product_uid")},
+		},
+		{
+			name:      "connect type is case insensitive",
+			req:       vxcPartnerPortRequest{productUID: "google-red", partner: "generic", connectType: "google", newConnection: true},
+			wantPaths: nil,
+		},
+		{
+			name:      "VXCs not permitted",
+			req:       vxcPartnerPortRequest{productUID: "aws-full", partner: "aws", connectType: "AWSHC", newConnection: true},
+			wantPaths: []path.Path{path.Root("b_end").AtName("requested_product_uid")},
+		},
+		{
+			name:      "wrong diversity zone",
+			req:       vxcPartnerPortRequest{productUID: "google-red", partner: "google", connectType: "GOOGLE", diversityZone: "blue", diversityZonePath: pairingKeyPath, newConnection: true},
+			wantPaths: []path.Path{pairingKeyPath},
+		},
+		{
+			name:      "untagged",
+			req:       vxcPartnerPortRequest{productUID: "google-red", partner: "google", connectType: "GOOGLE", orderedVLAN: &untagged, newConnection: true},
+			wantPaths: []path.Path{path.Root("b_end").AtName("ordered_vlan")},
+		},
+		{
+			name:      "rate limit above port speed",
+			req:       vxcPartnerPortRequest{productUID: "aws-small", partner: "aws", connectType: "AWSHC", rateLimit: 2000, newConnection: true},
+			wantPaths: []path.Path{path.Root("rate_limit")},
+		},
+		{
+			name: "existing connection only checks the rate limit",
+			req:  vxcPartnerPortRequest{productUID: "aws-full", partner: "google", connectType: "GOOGLE", rateLimit: 10000, orderedVLAN: &untagged},
+		},
+		{
+			name:      "existing connection rate limit above port speed",
+			req:       vxcPartnerPortRequest{productUID: "aws-small", partner: "aws", connectType: "AWSHC", rateLimit: 5000},
+			wantPaths: []path.Path{path.Root("rate_limit")},
+		},
+		{
+			name: "everything wrong",
+			req:  vxcPartnerPortRequest{productUID: "aws-full", partner: "oracle", connectType: "ORACLE", rateLimit: 20000, orderedVLAN: &untagged, newConnection: true},
+			wantPaths: []path.Path{
+				path.Root("b_end").AtName("requested_product_uid"),
+				path.Root("b_end").AtName("requested_product_uid"),
+				path.Root("b_end").AtName("ordered_vlan"),
+				path.Root("rate_limit"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := checkVXCPartnerPort(ports, tc.req)
+			var gotPaths []path.Path
+			for _, d := range diags.Errors() {
+				withPath, ok := d.(interface{ Path() path.Path })
+				require.True(t, ok, "diagnostic %q has no attribute path", d.Summary())
+				gotPaths = append(gotPaths, withPath.Path())
+			}
+			assert.Equal(t, tc.wantPaths, gotPaths)
+		})
+	}
+}
+
+func TestGooglePairingKeyDiversityZone(t *testing.T) {
+	assert.Equal(t, "red", googlePairingKeyDiversityZone("7e51371e-72a3-40b5-b844-2e3efefaee59/australia-southeast1/1"))
+	assert.Equal(t, "blue", googlePairingKeyDiversityZone("7e51371e-72a3-40b5-b844-2e3efefaee59/australia-southeast1/2"))
+	assert.Equal(t, "", googlePairingKeyDiversityZone("7e51371e-72a3-40b5-b844-2e3efefaee59/australia-southeast1/3"))
+	assert.Equal(t, "", googlePairingKeyDiversityZone("not-a-pairing-key"))
+}
+
+func TestUnitMegaportVXC_PartnerPortPlanValidation(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	aEnd := api.seedPort("Partner Validation A-End")
+	googlePort := api.seedPartnerPort("GOOGLE", "google-key")
+	config := func(rateLimit int, bEndVLAN int, pairingKey string) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_vxc" "vxc" {
+			product_name         = "Partner Validation VXC"
+			rate_limit           = %d
+			contract_term_months = 12
+			a_end = {
+				requested_product_uid = %q
+			}
+			b_end = {
+				requested_product_uid = %q
+				ordered_vlan          = %d
+			}
+			b_end_partner_config = {
+				partner = "google"
+				google_config = {
+					pairing_key = %q
+				}
+			}
+		}`, rateLimit, aEnd, googlePort, bEndVLAN, pairingKey)
+	}
+	notPermitted := &fakeFault{Method: http.MethodGet, Path: "/v2/dropdowns/partner/megaports", Times: 1, Mutate: func(data map[string]any) {
+		data["vxcPermitted"] = false
+	}}
+	lookupFails := &fakeFault{Method: http.MethodGet, Path: "/v2/dropdowns/partner/megaports", Status: http.StatusInternalServerError, Message: "Internal Server Error"}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:             config(500, 0, "google-key/australia-southeast1/1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      config(5000, 0, "google-key/australia-southeast1/1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`VXC rate limit exceeds the partner port speed`),
+			},
+			{
+				Config:      config(500, -1, "google-key/australia-southeast1/1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`VXC to a partner port cannot be untagged`),
+			},
+			{
+				Config:      config(500, 0, "google-key/australia-southeast1/2"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`VXC partner port is in the wrong diversity zone`),
+			},
+			{
+				PreConfig:   func() { api.inject(notPermitted) },
+				Config:      config(500, 0, "google-key/australia-southeast1/1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`VXCs are not permitted on the partner port`),
+			},
+			// A failed lookup must not block the plan. This stays the last
+			// step, as the lookup keeps failing from here on.
+			{
+				PreConfig:          func() { api.inject(lookupFails) },
+				Config:             config(5000, 0, "google-key/australia-southeast1/1"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+	assert.Equal(t, 1, api.hits(notPermitted))
+	assert.Positive(t, api.hits(lookupFails))
+}
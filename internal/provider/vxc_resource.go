@@ -2,12 +2,15 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
-	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -22,20 +25,24 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	megaport "github.com/megaport/megaportgo"
 )
 
-// Update Timeout for VXC Update Verification - will be configurable in future release.
-const updateTimeout = 120 * time.Second
+// vxcUpdatePropagationTimeout bounds the post-update verification polls when
+// the resource's timeouts block does not set an update timeout.
+const vxcUpdatePropagationTimeout = 120 * time.Second
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &vxcResource{}
-	_ resource.ResourceWithConfigure   = &vxcResource{}
-	_ resource.ResourceWithImportState = &vxcResource{}
+	_ resource.Resource                 = &vxcResource{}
+	_ resource.ResourceWithConfigure    = &vxcResource{}
+	_ resource.ResourceWithImportState  = &vxcResource{}
+	_ resource.ResourceWithIdentity     = &vxcResource{}
+	_ resource.ResourceWithUpgradeState = &vxcResource{}
 
 	vxcEndConfigurationAttrs = map[string]attr.Type{
 		"owner_uid":             types.StringType,
@@ -49,6 +56,20 @@ var (
 		"inner_vlan":            types.Int64Type,
 		"vnic_index":            types.Int64Type,
 		"secondary_name":        types.StringType,
+		"partner_port_selector": types.ObjectType{}.WithAttributeTypes(vxcPartnerPortSelectorAttrs),
+	}
+
+	// The A-End must be a product the customer owns, so it has no partner
+	// port selector.
+	vxcAEndConfigurationAttrs = withoutAttribute(vxcEndConfigurationAttrs, "partner_port_selector")
+
+	vxcPartnerPortSelectorAttrs = map[string]attr.Type{
+		"connect_type":   types.StringType,
+		"company_name":   types.StringType,
+		"product_name":   types.StringType,
+		"location_id":    types.Int64Type,
+		"metro":          types.StringType,
+		"diversity_zone": types.StringType,
 	}
 
 	cspConnectionFullAttrs = map[string]attr.Type{
@@ -84,6 +105,7 @@ var (
 		"ipv6_gateway_address": types.StringType,
 		"ip_addresses":         types.ListType{}.WithElementType(types.StringType),
 		"virtual_router_name":  types.StringType,
+		"attributes":           types.MapType{}.WithElementType(types.StringType),
 	}
 
 	vxcPartnerConfigAttrs = map[string]attr.Type{
@@ -95,8 +117,14 @@ var (
 		"vrouter_config":       types.ObjectType{}.WithAttributeTypes(vxcPartnerConfigVrouterAttrs),
 		"partner_a_end_config": types.ObjectType{}.WithAttributeTypes(vxcPartnerConfigAEndAttrs),
 		"ibm_config":           types.ObjectType{}.WithAttributeTypes(vxcPartnerConfigIbmAttrs),
+		"generic_config":       types.ObjectType{}.WithAttributeTypes(vxcPartnerConfigGenericAttrs),
 	}
 
+	// vxcAEndPartnerConfigAttrs are the attributes of a_end_partner_config,
+	// which has no generic_config: a generic partner is only ordered at the
+	// B-End.
+	vxcAEndPartnerConfigAttrs = withoutAttribute(vxcPartnerConfigAttrs, "generic_config")
+
 	vxcPartnerConfigAWSAttrs = map[string]attr.Type{
 		"connect_type":        types.StringType,
 		"type":                types.StringType,
@@ -104,6 +132,7 @@ var (
 		"asn":                 types.Int64Type,
 		"amazon_asn":          types.Int64Type,
 		"auth_key":            types.StringType,
+		"auth_key_version":    types.Int64Type,
 		"prefixes":            types.StringType,
 		"customer_ip_address": types.StringType,
 		"amazon_ip_address":   types.StringType,
@@ -116,6 +145,14 @@ var (
 		"peers":       types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(partnerOrderAzurePeeringConfigAttrs)),
 	}
 
+	vxcPartnerConfigGenericAttrs = map[string]attr.Type{
+		"connect_type":      types.StringType,
+		"pairing_key":       types.StringType,
+		"pairing_key_field": types.StringType,
+		"peers":             types.ListType{}.WithElementType(types.ObjectType{}.WithAttributeTypes(partnerOrderAzurePeeringConfigAttrs)),
+		"attributes":        types.MapType{}.WithElementType(types.StringType),
+	}
+
 	partnerOrderAzurePeeringConfigAttrs = map[string]attr.Type{
 		"type":             types.StringType,
 		"peer_asn":         types.StringType,
@@ -171,6 +208,7 @@ var (
 		"local_ip_address":      types.StringType,
 		"peer_ip_address":       types.StringType,
 		"password":              types.StringType,
+		"password_version":      types.Int64Type,
 		"shutdown":              types.BoolType,
 		"description":           types.StringType,
 		"med_in":                types.Int64Type,
@@ -207,14 +245,17 @@ var (
 	}
 
 	ipSecTunnelOptionsAttrs = map[string]attr.Type{
-		"source_ip_address":      types.StringType,
-		"destination_ip_address": types.StringType,
-		"pre_shared_key":         types.StringType,
-		"passive":                types.BoolType,
-		"local_id":               types.StringType,
-		"remote_id":              types.StringType,
-		"phase1_lifetime":        types.Int64Type,
-		"phase2_lifetime":        types.Int64Type,
+		"source_ip_address":        types.StringType,
+		"destination_ip_address":   types.StringType,
+		"pre_shared_key":           types.StringType,
+		"pre_shared_key_version":   types.Int64Type,
+		"generate_pre_shared_key":  types.BoolType,
+		"generated_pre_shared_key": types.StringType,
+		"passive":                  types.BoolType,
+		"local_id":                 types.StringType,
+		"remote_id":                types.StringType,
+		"phase1_lifetime":          types.Int64Type,
+		"phase2_lifetime":          types.Int64Type,
 	}
 
 	ipRouteAttrs = map[string]attr.Type{
@@ -236,6 +277,7 @@ var (
 		"local_ip_address":      types.StringType,
 		"peer_ip_address":       types.StringType,
 		"password":              types.StringType,
+		"password_version":      types.Int64Type,
 		"shutdown":              types.BoolType,
 		"description":           types.StringType,
 		"med_in":                types.Int64Type,
@@ -296,6 +338,13 @@ type vxcResourceModel struct {
 	CSPConnections types.List `tfsdk:"csp_connections"`
 
 	ResourceTags types.Map `tfsdk:"resource_tags"`
+
+	MoveStrategy types.String `tfsdk:"move_strategy"`
+
+	WaitForBGP        types.Bool   `tfsdk:"wait_for_bgp"`
+	WaitForBGPTimeout types.String `tfsdk:"wait_for_bgp_timeout"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type cspConnectionModel struct {
@@ -331,6 +380,7 @@ type cspConnectionModel struct {
 	CustomerIP6Network types.String `tfsdk:"customer_ip6_network"`
 	IPv4GatewayAddress types.String `tfsdk:"ipv4_gateway_address"`
 	IPv6GatewayAddress types.String `tfsdk:"ipv6_gateway_address"`
+	Attributes         types.Map    `tfsdk:"attributes"`
 }
 
 // vxcEndConfigurationModel maps the end configuration schema data.
@@ -346,6 +396,17 @@ type vxcEndConfigurationModel struct {
 	InnerVLAN             types.Int64  `tfsdk:"inner_vlan"`
 	NetworkInterfaceIndex types.Int64  `tfsdk:"vnic_index"`
 	SecondaryName         types.String `tfsdk:"secondary_name"`
+	PartnerPortSelector   types.Object `tfsdk:"partner_port_selector"`
+}
+
+// vxcPartnerPortSelectorModel maps the partner port selector schema data.
+type vxcPartnerPortSelectorModel struct {
+	ConnectType   types.String `tfsdk:"connect_type"`
+	CompanyName   types.String `tfsdk:"company_name"`
+	ProductName   types.String `tfsdk:"product_name"`
+	LocationID    types.Int64  `tfsdk:"location_id"`
+	Metro         types.String `tfsdk:"metro"`
+	DiversityZone types.String `tfsdk:"diversity_zone"`
 }
 
 type vxcPartnerConfigurationModel struct {
@@ -356,9 +417,80 @@ type vxcPartnerConfigurationModel struct {
 	OraclePartnerConfig  types.Object `tfsdk:"oracle_config"`
 	VrouterPartnerConfig types.Object `tfsdk:"vrouter_config"`
 	IBMPartnerConfig     types.Object `tfsdk:"ibm_config"`
+	GenericPartnerConfig types.Object `tfsdk:"generic_config"`
 	PartnerAEndConfig    types.Object `tfsdk:"partner_a_end_config"` // DEPRECATED: Use vrouter_config instead.
 }
 
+// partnerConfigAs reads a_end_partner_config or b_end_partner_config into
+// target, a vxcPartnerConfigurationModel. An A-End configuration, which has no
+// generic_config, is read with a null one.
+func partnerConfigAs(ctx context.Context, partnerConfig types.Object, target any, opts basetypes.ObjectAsOptions) diag.Diagnostics {
+	if partnerConfig.IsNull() || partnerConfig.IsUnknown() {
+		return partnerConfig.As(ctx, target, opts)
+	}
+	attrs := maps.Clone(partnerConfig.Attributes())
+	if _, ok := attrs["generic_config"]; !ok {
+		attrs["generic_config"] = types.ObjectNull(vxcPartnerConfigGenericAttrs)
+	}
+	full, diags := types.ObjectValue(vxcPartnerConfigAttrs, attrs)
+	if diags.HasError() {
+		return diags
+	}
+	return full.As(ctx, target, opts)
+}
+
+// aEndPartnerConfigValue converts a partner configuration built with
+// vxcPartnerConfigAttrs to the type of a_end_partner_config.
+func aEndPartnerConfigValue(partnerConfig types.Object) types.Object {
+	switch {
+	case partnerConfig.IsNull():
+		return types.ObjectNull(vxcAEndPartnerConfigAttrs)
+	case partnerConfig.IsUnknown():
+		return types.ObjectUnknown(vxcAEndPartnerConfigAttrs)
+	}
+	attrs := maps.Clone(partnerConfig.Attributes())
+	delete(attrs, "generic_config")
+	return types.ObjectValueMust(vxcAEndPartnerConfigAttrs, attrs)
+}
+
+// endConfigurationAs decodes an end configuration into target, which may be of
+// either end's type.
+func endConfigurationAs(ctx context.Context, endConfig types.Object, target any, opts basetypes.ObjectAsOptions) diag.Diagnostics {
+	if endConfig.IsNull() || endConfig.IsUnknown() {
+		return endConfig.As(ctx, target, opts)
+	}
+	attrs := maps.Clone(endConfig.Attributes())
+	if _, ok := attrs["partner_port_selector"]; !ok {
+		attrs["partner_port_selector"] = types.ObjectNull(vxcPartnerPortSelectorAttrs)
+	}
+	full, diags := types.ObjectValue(vxcEndConfigurationAttrs, attrs)
+	if diags.HasError() {
+		return diags
+	}
+	return full.As(ctx, target, opts)
+}
+
+// aEndConfigurationValue converts an end configuration built with
+// vxcEndConfigurationAttrs to the type of a_end.
+func aEndConfigurationValue(endConfig types.Object) types.Object {
+	switch {
+	case endConfig.IsNull():
+		return types.ObjectNull(vxcAEndConfigurationAttrs)
+	case endConfig.IsUnknown():
+		return types.ObjectUnknown(vxcAEndConfigurationAttrs)
+	}
+	attrs := maps.Clone(endConfig.Attributes())
+	delete(attrs, "partner_port_selector")
+	return types.ObjectValueMust(vxcAEndConfigurationAttrs, attrs)
+}
+
+// withoutAttribute returns a copy of attribute types without name.
+func withoutAttribute(attrTypes map[string]attr.Type, name string) map[string]attr.Type {
+	attrTypes = maps.Clone(attrTypes)
+	delete(attrTypes, name)
+	return attrTypes
+}
+
 type vxcPartnerConfig interface {
 	isPartnerConfig()
 }
@@ -372,6 +504,7 @@ type vxcPartnerConfigAWSModel struct {
 	ASN               types.Int64  `tfsdk:"asn"`
 	AmazonASN         types.Int64  `tfsdk:"amazon_asn"`
 	AuthKey           types.String `tfsdk:"auth_key"`
+	AuthKeyVersion    types.Int64  `tfsdk:"auth_key_version"`
 	Prefixes          types.String `tfsdk:"prefixes"`
 	CustomerIPAddress types.String `tfsdk:"customer_ip_address"`
 	AmazonIPAddress   types.String `tfsdk:"amazon_ip_address"`
@@ -408,6 +541,17 @@ type vxcPartnerConfigOracleModel struct {
 	VirtualCircuitId types.String `tfsdk:"virtual_circuit_id"`
 }
 
+// vxcPartnerConfigGenericModel maps the partner configuration schema data for
+// cloud partners that have no dedicated configuration block.
+type vxcPartnerConfigGenericModel struct {
+	vxcPartnerConfig `tfsdk:"-"`
+	ConnectType      types.String `tfsdk:"connect_type"`
+	PairingKey       types.String `tfsdk:"pairing_key"`
+	PairingKeyField  types.String `tfsdk:"pairing_key_field"`
+	Peers            types.List   `tfsdk:"peers"`
+	Attributes       types.Map    `tfsdk:"attributes"`
+}
+
 // vxcPartnerConfigVrouterModel maps the partner configuration schema data for a vrouter configuration.
 type vxcPartnerConfigVrouterModel struct {
 	vxcPartnerConfig `tfsdk:"-"`
@@ -448,16 +592,21 @@ type vxcPartnerConfigInterfaceModel struct {
 // ipSecTunnelOptionsModel maps a single ip_sec_tunnel_options block. The API
 // never returns the PSK or lifetimes. PreSharedKey is a write-only argument, so
 // it is null in plan/state and sourced from the configuration when ordering;
-// the lifetimes are preserved from plan/state rather than read back.
+// the lifetimes are preserved from plan/state rather than read back. A key the
+// provider generates is kept in GeneratedPreSharedKey so it can be given to
+// the peer.
 type ipSecTunnelOptionsModel struct {
-	SourceIPAddress      types.String `tfsdk:"source_ip_address"`
-	DestinationIPAddress types.String `tfsdk:"destination_ip_address"`
-	PreSharedKey         types.String `tfsdk:"pre_shared_key"`
-	Passive              types.Bool   `tfsdk:"passive"`
-	LocalID              types.String `tfsdk:"local_id"`
-	RemoteID             types.String `tfsdk:"remote_id"`
-	Phase1Lifetime       types.Int64  `tfsdk:"phase1_lifetime"`
-	Phase2Lifetime       types.Int64  `tfsdk:"phase2_lifetime"`
+	SourceIPAddress       types.String `tfsdk:"source_ip_address"`
+	DestinationIPAddress  types.String `tfsdk:"destination_ip_address"`
+	PreSharedKey          types.String `tfsdk:"pre_shared_key"`
+	PreSharedKeyVersion   types.Int64  `tfsdk:"pre_shared_key_version"`
+	GeneratePreSharedKey  types.Bool   `tfsdk:"generate_pre_shared_key"`
+	GeneratedPreSharedKey types.String `tfsdk:"generated_pre_shared_key"`
+	Passive               types.Bool   `tfsdk:"passive"`
+	LocalID               types.String `tfsdk:"local_id"`
+	RemoteID              types.String `tfsdk:"remote_id"`
+	Phase1Lifetime        types.Int64  `tfsdk:"phase1_lifetime"`
+	Phase2Lifetime        types.Int64  `tfsdk:"phase2_lifetime"`
 }
 
 // ipRouteModel maps the IP route schema data.
@@ -482,6 +631,7 @@ type bgpConnectionConfigModel struct {
 	LocalIPAddress     types.String `tfsdk:"local_ip_address"`
 	PeerIPAddress      types.String `tfsdk:"peer_ip_address"`
 	Password           types.String `tfsdk:"password"`
+	PasswordVersion    types.Int64  `tfsdk:"password_version"`
 	Shutdown           types.Bool   `tfsdk:"shutdown"`
 	Description        types.String `tfsdk:"description"`
 	MedIn              types.Int64  `tfsdk:"med_in"`
@@ -505,12 +655,17 @@ func NewVXCResource() resource.Resource {
 
 // vxcResource is the resource implementation.
 type vxcResource struct {
-	client *megaport.Client
+	client       *megaport.Client
+	waitTime     time.Duration
+	pollInterval time.Duration
 }
 
 // Metadata returns the resource type name.
 func (r *vxcResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_vxc"
+	// A make_before_break move replaces the VXC with a new one, so its
+	// product_uid changes in an update.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 // vrouterPrefixFilterListsForEndpoint returns the prefix filter lists owned
@@ -561,8 +716,9 @@ func (r *vxcResource) vrouterPrefixFilterListsForEndpoint(ctx context.Context, p
 }
 
 // Schema defines the schema for the resource.
-func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *vxcResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Virtual Cross Connect (VXC) Resource for the Megaport Terraform Provider. This resource allows you to create, modify, and update VXCs. VXCs are Layer 2 Ethernet circuits providing private, flexible, and on-demand connections between any of the locations on the Megaport network with 1 Mbps to 100 Gbps of capacity.",
 		Attributes: map[string]schema.Attribute{
 			"last_updated": schema.StringAttribute{
@@ -820,7 +976,7 @@ func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 							},
 						},
 						"auth_key": schema.StringAttribute{
-							Description: "The authentication key of the CSP connection.",
+							Description: "The authentication key of the CSP connection. Always null: the key is a secret, so the provider does not read it back from the API into state. Set it with the write-only `aws_config.auth_key`.",
 							Optional:    true,
 							Computed:    true,
 							PlanModifiers: []planmodifier.String{
@@ -917,6 +1073,15 @@ func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 								listplanmodifier.UseStateForUnknown(),
 							},
 						},
+						"attributes": schema.MapAttribute{
+							Description: "The remaining fields of a CSP connection to a partner without a dedicated configuration block, keyed by their name in the Megaport API. Numbers and booleans are formatted as strings and nested values as JSON. Sensitive, as the fields can include pairing, service, or shared keys.",
+							Computed:    true,
+							Sensitive:   true,
+							ElementType: types.StringType,
+							PlanModifiers: []planmodifier.Map{
+								mapplanmodifier.UseStateForUnknown(),
+							},
+						},
 						"virtual_router_name": schema.StringAttribute{
 							Description: "The name of the Virtual Router.",
 							Optional:    true,
@@ -960,6 +1125,24 @@ func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					mapplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"move_strategy": schema.StringAttribute{
+				Description: "How the VXC is moved when the `requested_product_uid` of an end changes. With `in_place`, the default, the VXC is updated to the new product, and traffic stops while it moves. With `make_before_break`, a new VXC is ordered to the new product alongside the existing one, and the existing VXC is only deleted once the new one is live and the BGP sessions configured on its MCR ends are up. If the new VXC does not come up, it is deleted and the existing VXC is left as it was. The new VXC has a new `product_uid` and starts a new contract term. It is not supported for VXCs with a cloud partner configuration or a service key, whose connections cannot be ordered twice.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(vxcMoveInPlace, vxcMoveMakeBeforeBreak),
+				},
+			},
+			"wait_for_bgp": schema.BoolAttribute{
+				Description: "Whether to wait, after the VXC is created or updated, until the BGP session with every peer in the `bgp_connections` of its MCR ends is established. Connections that are shut down are not waited for. If a session is not established within `wait_for_bgp_timeout`, the apply fails with an error for each peer that did not come up. A VXC that was created is still saved to state, and Terraform will replace it on the next apply. Defaults to false.",
+				Optional:    true,
+			},
+			"wait_for_bgp_timeout": schema.StringAttribute{
+				Description: "How long to wait for BGP sessions when `wait_for_bgp` is true, as a duration such as \"30s\", \"10m\" or \"1h\". Defaults to 10m.",
+				Optional:    true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
 			"contract_start_date": schema.StringAttribute{
 				Description: "The date the contract starts. This value is managed by the Megaport API and may be updated when the VXC is provisioned or when contract terms change. During import, this field may show as changing from unknown to its actual value - this is expected behavior.",
 				Computed:    true,
@@ -1106,7 +1289,7 @@ func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						},
 					},
 					"requested_product_uid": schema.StringAttribute{
-						Description: "The Product UID of the B-End product. For partner connections (AWS, Google, etc.), use the `megaport_partner` data source to look up the correct UID. For Google connections this field is especially important: Google exposes multiple partner ports across different locations and diversity zones, so omitting it allows the API to select any available port — which may be in an unexpected region. Set this to a specific Google partner port UID to control the on-ramp location and diversity zone. The value stored in state may differ from the requested UID when Megaport rotates a partner port within the same location and diversity zone.",
+						Description: "The Product UID of the B-End product. For partner connections (AWS, Google, etc.), use the `megaport_partner` data source to look up the correct UID. For Google connections this field is especially important: Google exposes multiple partner ports across different locations and diversity zones, so omitting it allows the API to select any available port — which may be in an unexpected region. Set this to a specific Google partner port UID to control the on-ramp location and diversity zone. The value stored in state may differ from the requested UID when Megaport rotates a partner port within the same location and diversity zone. To have the provider choose a partner port instead, use `partner_port_selector`.",
 						Optional:    true,
 						Computed:    true,
 						// PlanModifiers: []planmodifier.String{
@@ -1176,6 +1359,7 @@ func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 							stringplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"partner_port_selector": partnerPortSelectorSchema,
 				},
 			},
 			"a_end_partner_config": schema.SingleNestedAttribute{
@@ -1206,7 +1390,7 @@ func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 						Description: "The partner of the partner configuration.",
 						Required:    true,
 						Validators: []validator.String{
-							stringvalidator.OneOf("aws", "azure", "google", "oracle", "ibm", "transit", "vrouter"),
+							stringvalidator.OneOf("aws", "azure", "google", "oracle", "ibm", "transit", "vrouter", "generic"),
 						},
 					},
 					"aws_config":           awsPartnerConfigSchema,
@@ -1215,10 +1399,14 @@ func (r *vxcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *
 					"ibm_config":           ibmPartnerConfigSchema,
 					"oracle_config":        oraclePartnerConfigSchema,
 					"vrouter_config":       vrouterPartnerConfigSchema,
+					"generic_config":       genericPartnerConfigSchema,
 					"partner_a_end_config": aEndPartnerConfigSchema,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": resourceTimeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -1232,6 +1420,97 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	buyReq := r.buyVXCRequestFromPlan(ctx, req.Config, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.VXCService.ValidateVXCOrder(ctx, buyReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Validation error while attempting to create VXC",
+			"Validation error while attempting to create VXC with name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	createdVXC, err := r.client.VXCService.BuyVXC(ctx, buyReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating VXC",
+			"Could not order VXC with name "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	createdID := createdVXC.TechnicalServiceUID
+
+	// Persist the UID immediately so any failure below leaves a tracked
+	// (tainted) resource instead of an orphan that later applies try to recreate.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("product_uid"), createdID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.waitForVXCProvision(ctx, createdID, createTimeout, r.pollInterval); err != nil {
+		resp.Diagnostics.AddError(
+			"VXC ordered but not ready",
+			"VXC "+plan.Name.ValueString()+" ("+createdID+") was ordered successfully but did not reach a ready state: "+err.Error()+". Its UID has been saved to state and Terraform will replace it on the next apply.",
+		)
+		return
+	}
+
+	// get the created VXC
+	vxc, err := r.client.VXCService.GetVXC(ctx, createdID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading newly created VXC",
+			"VXC "+plan.Name.ValueString()+" ("+createdID+") was created but could not be read back: "+err.Error()+". Its UID has been saved to state.",
+		)
+		return
+	}
+
+	tags, err := r.client.VXCService.ListVXCResourceTags(ctx, createdID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading tags for newly created VXC",
+			"VXC "+plan.Name.ValueString()+" ("+createdID+") was created but its tags could not be read: "+err.Error()+". Its UID has been saved to state.",
+		)
+		return
+	}
+
+	// update the plan with the VXC info
+	// Pass &plan so that user-only fields (ordered_vlan, requested_product_uid,
+	// vnic_index, partner configs) are preserved from the plan — the API may
+	// not return them reliably immediately after create.
+	apiDiags := plan.fromAPIVXC(ctx, vxc, tags, &plan)
+	resp.Diagnostics.Append(apiDiags...)
+
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.UID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The VXC is in state, so if its BGP sessions don't come up it is kept
+	// and replaced on the next apply.
+	resp.Diagnostics.Append(r.waitForRequestedBGP(ctx, &plan, vxc)...)
+}
+
+// buyVXCRequestFromPlan builds the order for a VXC from plan, resolving a
+// service key to its product and replacing the partner configurations in plan
+// with the ones that are ordered. It returns nil if diags has errors.
+func (r *vxcResource) buyVXCRequestFromPlan(ctx context.Context, config tfsdk.Config, plan *vxcResourceModel, diags *diag.Diagnostics) *megaport.BuyVXCRequest {
 	buyReq := &megaport.BuyVXCRequest{
 		VXCName:    plan.Name.ValueString(),
 		Term:       int(plan.ContractTermMonths.ValueInt64()),
@@ -1251,18 +1530,18 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 		// If a service key is provided, we should look up the product UID pertaining to that service key and use that B-End Product UID
 		serviceKeyRes, err := r.client.ServiceKeyService.GetServiceKey(ctx, plan.ServiceKey.ValueString())
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error creating VXC",
 				"Could not create VXC with name "+plan.Name.ValueString()+": looking up Service Key failed: "+err.Error(),
 			)
-			return
+			return nil
 		}
 		if serviceKeyRes.ProductUID == "" {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error creating VXC",
 				"Could not create VXC with name "+plan.Name.ValueString()+": the provided Service Key is not associated with a Product",
 			)
-			return
+			return nil
 		}
 		serviceKeyBEndUID = serviceKeyRes.ProductUID
 	}
@@ -1273,9 +1552,9 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	if !plan.ResourceTags.IsNull() {
 		tagMap, tagDiags := toResourceTagMap(ctx, plan.ResourceTags)
-		resp.Diagnostics.Append(tagDiags...)
-		if resp.Diagnostics.HasError() {
-			return
+		diags.Append(tagDiags...)
+		if diags.HasError() {
+			return nil
 		}
 		buyReq.ResourceTags = tagMap
 	}
@@ -1284,10 +1563,10 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 	bEndObj := plan.BEndConfiguration
 
 	var a vxcEndConfigurationModel
-	aEndDiags := aEndObj.As(ctx, &a, basetypes.ObjectAsOptions{})
+	aEndDiags := endConfigurationAs(ctx, aEndObj, &a, basetypes.ObjectAsOptions{})
 	if aEndDiags.HasError() {
-		resp.Diagnostics.Append(aEndDiags...)
-		return
+		diags.Append(aEndDiags...)
+		return nil
 	}
 	aEndConfig := &megaport.VXCOrderEndpointConfiguration{
 		ProductUID: a.RequestedProductUID.ValueString(),
@@ -1305,11 +1584,11 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 	productType, _ := r.client.ProductService.GetProductType(ctx, a.RequestedProductUID.ValueString())
 	if strings.EqualFold(productType, megaport.PRODUCT_MVE) {
 		if a.NetworkInterfaceIndex.IsNull() && a.NetworkInterfaceIndex.IsUnknown() {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error creating VXC",
 				"Could not create VXC with name "+plan.Name.ValueString()+": Network Interface Index is required for MVE products",
 			)
-			return
+			return nil
 		}
 	}
 
@@ -1326,61 +1605,66 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	if !plan.AEndPartnerConfig.IsNull() {
 		var aPartnerConfig vxcPartnerConfigurationModel
-		aPartnerDiags := plan.AEndPartnerConfig.As(ctx, &aPartnerConfig, basetypes.ObjectAsOptions{
+		aPartnerDiags := partnerConfigAs(ctx, plan.AEndPartnerConfig, &aPartnerConfig, basetypes.ObjectAsOptions{
 			UnhandledNullAsEmpty:    true,
 			UnhandledUnknownAsEmpty: true,
 		})
-		resp.Diagnostics.Append(aPartnerDiags...)
+		diags.Append(aPartnerDiags...)
 		switch aPartnerConfig.Partner.ValueString() {
 		case "aws":
 			if aPartnerConfig.AWSPartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": AWS Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var awsConfig vxcPartnerConfigAWSModel
 			awsDiags := aPartnerConfig.AWSPartnerConfig.As(ctx, &awsConfig, basetypes.ObjectAsOptions{})
 			if awsDiags.HasError() {
-				resp.Diagnostics.Append(awsDiags...)
-				return
+				diags.Append(awsDiags...)
+				return nil
 			}
 			if awsConfig.ConnectType.ValueString() == "AWS" {
 				// Only allow type of "public", "private", or "transit" for AWS VIFs
 				if awsConfig.Type.ValueString() != "public" && awsConfig.Type.ValueString() != "private" && awsConfig.Type.ValueString() != "transit" {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						"Could not create VXC with name "+plan.Name.ValueString()+": AWS Connect Type must be public, private, or transit",
 					)
-					return
+					return nil
 				}
 			}
-			awsDiags, partnerConfig, partnerConfigObj := createAWSPartnerConfig(ctx, awsConfig)
+			var authKey types.String
+			diags.Append(config.GetAttribute(ctx, path.Root("a_end_partner_config").AtName("aws_config").AtName("auth_key"), &authKey)...)
+			if diags.HasError() {
+				return nil
+			}
+			awsDiags, partnerConfig, partnerConfigObj := createAWSPartnerConfig(ctx, awsConfig, authKey.ValueString())
 			if awsDiags.HasError() {
-				resp.Diagnostics.Append(awsDiags...)
-				return
+				diags.Append(awsDiags...)
+				return nil
 			}
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = partnerConfig
 		case "azure":
 			if aPartnerConfig.AzurePartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Azure Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var azureConfig vxcPartnerConfigAzureModel
 			azureDiags := aPartnerConfig.AzurePartnerConfig.As(ctx, &azureConfig, basetypes.ObjectAsOptions{})
 			if azureDiags.HasError() {
-				resp.Diagnostics.Append(azureDiags...)
-				return
+				diags.Append(azureDiags...)
+				return nil
 			}
 			azureDiags, azurePartnerConfig, partnerConfigObj := createAzurePartnerConfig(ctx, azureConfig)
 			if azureDiags.HasError() {
-				resp.Diagnostics.Append(azureDiags...)
-				return
+				diags.Append(azureDiags...)
+				return nil
 			}
 			if aEndConfig.ProductUID == "" {
 				partnerPortReq := &megaport.ListPartnerPortsRequest{
@@ -1389,11 +1673,11 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 				}
 				partnerPortRes, err := r.client.VXCService.ListPartnerPorts(ctx, partnerPortReq)
 				if err != nil {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not create %s, there was an error looking up partner ports: %s", plan.Name.ValueString(), err.Error()),
 					)
-					return
+					return nil
 				}
 				// find primary or secondary port
 				for _, port := range partnerPortRes.Data.Megaports {
@@ -1403,34 +1687,34 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 					}
 				}
 				if aEndConfig.ProductUID == "" {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not find azure port with type: %s", azureConfig.PortChoice.ValueString()),
 					)
-					return
+					return nil
 				}
 			}
 
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = azurePartnerConfig
 		case "google":
 			if aPartnerConfig.GooglePartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Google Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var googleConfig vxcPartnerConfigGoogleModel
 			googleDiags := aPartnerConfig.GooglePartnerConfig.As(ctx, &googleConfig, basetypes.ObjectAsOptions{})
 			if googleDiags.HasError() {
-				resp.Diagnostics.Append(googleDiags...)
-				return
+				diags.Append(googleDiags...)
+				return nil
 			}
 			googleDiags, googlePartnerConfig, partnerConfigObj := createGooglePartnerConfig(ctx, googleConfig)
 			if googleDiags.HasError() {
-				resp.Diagnostics.Append(googleDiags...)
-				return
+				diags.Append(googleDiags...)
+				return nil
 			}
 			if aEndConfig.ProductUID == "" {
 				partnerPortReq := &megaport.LookupPartnerPortsRequest{
@@ -1441,35 +1725,35 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 				partnerPortReq.ProductID = a.RequestedProductUID.ValueString()
 				partnerPortRes, err := r.client.VXCService.LookupPartnerPorts(ctx, partnerPortReq)
 				if err != nil {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not create %s, there was an error looking up partner ports: %s", plan.Name.ValueString(), err.Error()),
 					)
-					return
+					return nil
 				}
 				aEndConfig.ProductUID = partnerPortRes.ProductUID
 			}
 
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = googlePartnerConfig
 		case "oracle":
 			if aPartnerConfig.OraclePartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Oracle Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var oracleConfig vxcPartnerConfigOracleModel
 			oracleDiags := aPartnerConfig.OraclePartnerConfig.As(ctx, &oracleConfig, basetypes.ObjectAsOptions{})
 			if oracleDiags.HasError() {
-				resp.Diagnostics.Append(oracleDiags...)
-				return
+				diags.Append(oracleDiags...)
+				return nil
 			}
 			oracleDiags, oraclePartnerConfig, partnerConfigObj := createOraclePartnerConfig(ctx, oracleConfig)
 			if oracleDiags.HasError() {
-				resp.Diagnostics.Append(oracleDiags...)
-				return
+				diags.Append(oracleDiags...)
+				return nil
 			}
 			if aEndConfig.ProductUID == "" {
 				partnerPortReq := &megaport.LookupPartnerPortsRequest{
@@ -1481,116 +1765,124 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 				partnerPortRes, err := r.client.VXCService.LookupPartnerPorts(ctx, partnerPortReq)
 				if err != nil {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not create %s, there was an error looking up partner ports: %s", plan.Name.ValueString(), err.Error()),
 					)
-					return
+					return nil
 				}
 				aEndConfig.ProductUID = partnerPortRes.ProductUID
 			}
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = oraclePartnerConfig
 		case "ibm":
 			if aPartnerConfig.IBMPartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": IBM Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var ibmConfig vxcPartnerConfigIbmModel
 			ibmDiags := aPartnerConfig.IBMPartnerConfig.As(ctx, &ibmConfig, basetypes.ObjectAsOptions{})
-			resp.Diagnostics.Append(ibmDiags...)
-			if resp.Diagnostics.HasError() {
-				return
+			diags.Append(ibmDiags...)
+			if diags.HasError() {
+				return nil
 			}
 			ibmDiags, ibmPartnerConfig, partnerConfigObj := createIBMPartnerConfig(ctx, ibmConfig)
 			if ibmDiags.HasError() {
-				resp.Diagnostics.Append(ibmDiags...)
-				return
+				diags.Append(ibmDiags...)
+				return nil
 			}
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = ibmPartnerConfig
 		case "vrouter":
 			if aPartnerConfig.VrouterPartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Virtual router configuration is required",
 				)
-				return
+				return nil
 			}
 			var partnerConfigAEnd vxcPartnerConfigVrouterModel
 			aEndDiags := aPartnerConfig.VrouterPartnerConfig.As(ctx, &partnerConfigAEnd, basetypes.ObjectAsOptions{})
 			if aEndDiags.HasError() {
-				resp.Diagnostics.Append(aEndDiags...)
-				return
+				diags.Append(aEndDiags...)
+				return nil
 			}
 			prefixFilterList, err := r.vrouterPrefixFilterListsForEndpoint(ctx, a.RequestedProductUID.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": "+err.Error(),
 				)
-				return
+				return nil
 			}
 
-			psks, pskDiags := ipSecPreSharedKeysFromConfig(ctx, req.Config, "a_end_partner_config", len(partnerConfigAEnd.Interfaces.Elements()))
-			resp.Diagnostics.Append(pskDiags...)
-			if resp.Diagnostics.HasError() {
-				return
+			psks, pskDiags := ipSecPreSharedKeysFromConfig(ctx, config, "a_end_partner_config", len(partnerConfigAEnd.Interfaces.Elements()))
+			diags.Append(pskDiags...)
+			bgpPasswords, passwordDiags := bgpPasswordsFromConfig(ctx, config, path.Root("a_end_partner_config").AtName("vrouter_config").AtName("interfaces"), len(partnerConfigAEnd.Interfaces.Elements()))
+			diags.Append(passwordDiags...)
+			diags.Append(generateIPSecPreSharedKeys(ctx, &partnerConfigAEnd, psks)...)
+			if diags.HasError() {
+				return nil
 			}
-			vrouterDiags, vrouterMegaportConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList, psks)
+			vrouterDiags, vrouterMegaportConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList, psks, bgpPasswords)
 			if vrouterDiags.HasError() {
-				resp.Diagnostics.Append(vrouterDiags...)
-				return
+				diags.Append(vrouterDiags...)
+				return nil
 			}
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = vrouterMegaportConfig
 		case "a-end":
 			if aPartnerConfig.PartnerAEndConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": A-End Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var partnerConfigAEnd vxcPartnerConfigAEndModel
 			aEndDiags := aPartnerConfig.PartnerAEndConfig.As(ctx, &partnerConfigAEnd, basetypes.ObjectAsOptions{})
 			if aEndDiags.HasError() {
-				resp.Diagnostics.Append(aEndDiags...)
-				return
+				diags.Append(aEndDiags...)
+				return nil
 			}
 			prefixFilterList, err := r.vrouterPrefixFilterListsForEndpoint(ctx, a.RequestedProductUID.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": "+err.Error(),
 				)
-				return
+				return nil
+			}
+			bgpPasswords, passwordDiags := bgpPasswordsFromConfig(ctx, config, path.Root("a_end_partner_config").AtName("partner_a_end_config").AtName("interfaces"), len(partnerConfigAEnd.Interfaces.Elements()))
+			diags.Append(passwordDiags...)
+			if diags.HasError() {
+				return nil
 			}
-			aEndDiags, aEndMegaportConfig, partnerConfigObj := createAEndPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList)
+			aEndDiags, aEndMegaportConfig, partnerConfigObj := createAEndPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList, bgpPasswords)
 			if aEndDiags.HasError() {
-				resp.Diagnostics.Append(aEndDiags...)
-				return
+				diags.Append(aEndDiags...)
+				return nil
 			}
 
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = aEndMegaportConfig
 		case "transit":
 			transitDiags, transitPartnerConfig, partnerConfigObj := createTransitPartnerConfig(ctx)
 			if transitDiags.HasError() {
-				resp.Diagnostics.Append(transitDiags...)
-				return
+				diags.Append(transitDiags...)
+				return nil
 			}
-			plan.AEndPartnerConfig = partnerConfigObj
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			aEndConfig.PartnerConfig = transitPartnerConfig
 		default:
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error creating VXC",
 				"Could not create VXC with name "+plan.Name.ValueString()+": Partner configuration not supported",
 			)
-			return
+			return nil
 		}
 	}
 
@@ -1599,8 +1891,8 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 	var b vxcEndConfigurationModel
 	bEndDiags := bEndObj.As(ctx, &b, basetypes.ObjectAsOptions{})
 	if bEndDiags.HasError() {
-		resp.Diagnostics.Append(bEndDiags...)
-		return
+		diags.Append(bEndDiags...)
+		return nil
 	}
 	bEndConfig := &megaport.VXCOrderEndpointConfiguration{
 		ProductUID: b.RequestedProductUID.ValueString(),
@@ -1609,7 +1901,7 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 	if serviceKeyBEndUID != "" {
 		// If B End Requested Product UID was provided and it differs from the Service Key Product UID, warn that it is being overridden
 		if b.RequestedProductUID.ValueString() != "" && b.RequestedProductUID.ValueString() != serviceKeyBEndUID {
-			resp.Diagnostics.AddWarning(
+			diags.AddWarning(
 				"Overriding B-End Product UID",
 				"Overriding the requested B-End Product UID of "+b.RequestedProductUID.ValueString()+" with "+serviceKeyBEndUID+" based on the provided Service Key.",
 			)
@@ -1626,11 +1918,11 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 	productType, _ = r.client.ProductService.GetProductType(ctx, b.RequestedProductUID.ValueString())
 	if strings.EqualFold(productType, megaport.PRODUCT_MVE) {
 		if b.NetworkInterfaceIndex.IsNull() && b.NetworkInterfaceIndex.IsUnknown() {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error creating VXC",
 				"Could not create VXC with name "+plan.Name.ValueString()+": Network Interface Index is required for MVE products",
 			)
-			return
+			return nil
 		}
 	}
 
@@ -1646,59 +1938,64 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 	if !plan.BEndPartnerConfig.IsNull() {
 		var bPartnerConfig vxcPartnerConfigurationModel
-		bPartnerDiags := plan.BEndPartnerConfig.As(ctx, &bPartnerConfig, basetypes.ObjectAsOptions{})
-		resp.Diagnostics.Append(bPartnerDiags...)
+		bPartnerDiags := partnerConfigAs(ctx, plan.BEndPartnerConfig, &bPartnerConfig, basetypes.ObjectAsOptions{})
+		diags.Append(bPartnerDiags...)
 		switch bPartnerConfig.Partner.ValueString() {
 		case "aws":
 			if bPartnerConfig.AWSPartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": AWS Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var awsConfig vxcPartnerConfigAWSModel
 			awsDiags := bPartnerConfig.AWSPartnerConfig.As(ctx, &awsConfig, basetypes.ObjectAsOptions{})
 			if awsDiags.HasError() {
-				resp.Diagnostics.Append(awsDiags...)
-				return
+				diags.Append(awsDiags...)
+				return nil
 			}
 			if awsConfig.ConnectType.ValueString() == "AWS" {
 				// Only allow type of "public", "private", or "transit" for AWS VIFs
 				if awsConfig.Type.ValueString() != "public" && awsConfig.Type.ValueString() != "private" && awsConfig.Type.ValueString() != "transit" {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						"Could not create VXC with name "+plan.Name.ValueString()+": AWS Connect Type must be public, private, or transit",
 					)
-					return
+					return nil
 				}
 			}
-			awsDiags, partnerConfig, partnerConfigObj := createAWSPartnerConfig(ctx, awsConfig)
+			var authKey types.String
+			diags.Append(config.GetAttribute(ctx, path.Root("b_end_partner_config").AtName("aws_config").AtName("auth_key"), &authKey)...)
+			if diags.HasError() {
+				return nil
+			}
+			awsDiags, partnerConfig, partnerConfigObj := createAWSPartnerConfig(ctx, awsConfig, authKey.ValueString())
 			if awsDiags.HasError() {
-				resp.Diagnostics.Append(awsDiags...)
-				return
+				diags.Append(awsDiags...)
+				return nil
 			}
 			plan.BEndPartnerConfig = partnerConfigObj
 			bEndConfig.PartnerConfig = partnerConfig
 		case "azure":
 			if bPartnerConfig.AzurePartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Azure Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var azureConfig vxcPartnerConfigAzureModel
 			azureDiags := bPartnerConfig.AzurePartnerConfig.As(ctx, &azureConfig, basetypes.ObjectAsOptions{})
 			if azureDiags.HasError() {
-				resp.Diagnostics.Append(azureDiags...)
-				return
+				diags.Append(azureDiags...)
+				return nil
 			}
 
 			azureDiags, azurePartnerConfig, partnerConfigObj := createAzurePartnerConfig(ctx, azureConfig)
 			if azureDiags.HasError() {
-				resp.Diagnostics.Append(azureDiags...)
-				return
+				diags.Append(azureDiags...)
+				return nil
 			}
 			if bEndConfig.ProductUID == "" {
 				partnerPortReq := &megaport.ListPartnerPortsRequest{
@@ -1707,11 +2004,11 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 				}
 				partnerPortRes, err := r.client.VXCService.ListPartnerPorts(ctx, partnerPortReq)
 				if err != nil {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not create %s, there was an error looking up partner ports: %s", plan.Name.ValueString(), err.Error()),
 					)
-					return
+					return nil
 				}
 				// find primary or secondary port
 				for _, port := range partnerPortRes.Data.Megaports {
@@ -1721,34 +2018,34 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 					}
 				}
 				if bEndConfig.ProductUID == "" {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not find azure port with type: %s", azureConfig.PortChoice.ValueString()),
 					)
-					return
+					return nil
 				}
 			}
 			plan.BEndPartnerConfig = partnerConfigObj
 			bEndConfig.PartnerConfig = azurePartnerConfig
 		case "google":
 			if bPartnerConfig.GooglePartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Google Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var googleConfig vxcPartnerConfigGoogleModel
 			googleDiags := bPartnerConfig.GooglePartnerConfig.As(ctx, &googleConfig, basetypes.ObjectAsOptions{})
 			if googleDiags.HasError() {
-				resp.Diagnostics.Append(googleDiags...)
-				return
+				diags.Append(googleDiags...)
+				return nil
 			}
 
 			googleDiags, googlePartnerConfig, partnerConfigObj := createGooglePartnerConfig(ctx, googleConfig)
 			if googleDiags.HasError() {
-				resp.Diagnostics.Append(googleDiags...)
-				return
+				diags.Append(googleDiags...)
+				return nil
 			}
 			if bEndConfig.ProductUID == "" {
 				partnerPortReq := &megaport.LookupPartnerPortsRequest{
@@ -1761,11 +2058,11 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 				}
 				partnerPortRes, err := r.client.VXCService.LookupPartnerPorts(ctx, partnerPortReq)
 				if err != nil {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not create %s, there was an error looking up partner ports: %s", plan.Name.ValueString(), err.Error()),
 					)
-					return
+					return nil
 				}
 				bEndConfig.ProductUID = partnerPortRes.ProductUID
 			}
@@ -1774,22 +2071,22 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 			bEndConfig.PartnerConfig = googlePartnerConfig
 		case "oracle":
 			if bPartnerConfig.OraclePartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Oracle Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var oracleConfig vxcPartnerConfigOracleModel
 			oracleDiags := bPartnerConfig.OraclePartnerConfig.As(ctx, &oracleConfig, basetypes.ObjectAsOptions{})
 			if oracleDiags.HasError() {
-				resp.Diagnostics.Append(oracleDiags...)
-				return
+				diags.Append(oracleDiags...)
+				return nil
 			}
 			oracleDiags, oraclePartnerConfig, partnerConfigObj := createOraclePartnerConfig(ctx, oracleConfig)
 			if oracleDiags.HasError() {
-				resp.Diagnostics.Append(oracleDiags...)
-				return
+				diags.Append(oracleDiags...)
+				return nil
 			}
 			if bEndConfig.ProductUID == "" {
 				partnerPortReq := &megaport.LookupPartnerPortsRequest{
@@ -1802,200 +2099,144 @@ func (r *vxcResource) Create(ctx context.Context, req resource.CreateRequest, re
 				}
 				partnerPortRes, err := r.client.VXCService.LookupPartnerPorts(ctx, partnerPortReq)
 				if err != nil {
-					resp.Diagnostics.AddError(
+					diags.AddError(
 						"Error creating VXC",
 						fmt.Sprintf("Could not create %s, there was an error looking up partner ports: %s", plan.Name.ValueString(), err.Error()),
 					)
-					return
+					return nil
 				}
 				bEndConfig.ProductUID = partnerPortRes.ProductUID
 			}
 
 			plan.BEndPartnerConfig = partnerConfigObj
 			bEndConfig.PartnerConfig = oraclePartnerConfig
+		case "generic":
+			if bPartnerConfig.GenericPartnerConfig.IsNull() {
+				diags.AddError(
+					"Error creating VXC",
+					"Could not create VXC with name "+plan.Name.ValueString()+": Generic partner configuration is required",
+				)
+				return nil
+			}
+			var genericConfig vxcPartnerConfigGenericModel
+			genericDiags := bPartnerConfig.GenericPartnerConfig.As(ctx, &genericConfig, basetypes.ObjectAsOptions{})
+			if genericDiags.HasError() {
+				diags.Append(genericDiags...)
+				return nil
+			}
+			genericDiags, genericPartnerConfig, partnerConfigObj := createGenericPartnerConfig(ctx, genericConfig)
+			if genericDiags.HasError() {
+				diags.Append(genericDiags...)
+				return nil
+			}
+			if bEndConfig.ProductUID == "" {
+				if genericConfig.PairingKey.IsNull() {
+					diags.AddError(
+						"Error creating VXC",
+						"Could not create VXC with name "+plan.Name.ValueString()+": either requested_product_uid or a pairing_key to look up the partner port with is required",
+					)
+					return nil
+				}
+				partnerPortRes, err := r.client.VXCService.LookupPartnerPorts(ctx, &megaport.LookupPartnerPortsRequest{
+					Key:       genericConfig.PairingKey.ValueString(),
+					PortSpeed: int(plan.RateLimit.ValueInt64()),
+					Partner:   genericConfig.ConnectType.ValueString(),
+				})
+				if err != nil {
+					diags.AddError(
+						"Error creating VXC",
+						fmt.Sprintf("Could not create %s, there was an error looking up partner ports: %s", plan.Name.ValueString(), err.Error()),
+					)
+					return nil
+				}
+				bEndConfig.ProductUID = partnerPortRes.ProductUID
+			}
+
+			plan.BEndPartnerConfig = partnerConfigObj
+			bEndConfig.PartnerConfig = genericPartnerConfig
 		case "ibm":
 			if bPartnerConfig.IBMPartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": IBM Partner configuration is required",
 				)
-				return
+				return nil
 			}
 			var ibmConfig vxcPartnerConfigIbmModel
 			ibmDiags := bPartnerConfig.IBMPartnerConfig.As(ctx, &ibmConfig, basetypes.ObjectAsOptions{})
-			resp.Diagnostics.Append(ibmDiags...)
-			if resp.Diagnostics.HasError() {
-				return
+			diags.Append(ibmDiags...)
+			if diags.HasError() {
+				return nil
 			}
 			ibmDiags, ibmPartnerConfig, partnerConfigObj := createIBMPartnerConfig(ctx, ibmConfig)
 			if ibmDiags.HasError() {
-				resp.Diagnostics.Append(ibmDiags...)
-				return
+				diags.Append(ibmDiags...)
+				return nil
 			}
 			plan.BEndPartnerConfig = partnerConfigObj
 			bEndConfig.PartnerConfig = ibmPartnerConfig
 		case "transit":
 			transitDiags, transitPartnerConfig, partnerConfigObj := createTransitPartnerConfig(ctx)
 			if transitDiags.HasError() {
-				resp.Diagnostics.Append(transitDiags...)
-				return
+				diags.Append(transitDiags...)
+				return nil
 			}
 			plan.BEndPartnerConfig = partnerConfigObj
 			bEndConfig.PartnerConfig = transitPartnerConfig
 		case "vrouter":
 			if bPartnerConfig.VrouterPartnerConfig.IsNull() {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": Virtual router configuration is required",
 				)
-				return
+				return nil
 			}
 			var partnerConfigBEnd vxcPartnerConfigVrouterModel
 			bEndDiags := bPartnerConfig.VrouterPartnerConfig.As(ctx, &partnerConfigBEnd, basetypes.ObjectAsOptions{})
 			if aEndDiags.HasError() {
-				resp.Diagnostics.Append(bEndDiags...)
-				return
+				diags.Append(bEndDiags...)
+				return nil
 			}
 			prefixFilterList, err := r.vrouterPrefixFilterListsForEndpoint(ctx, b.RequestedProductUID.ValueString())
 			if err != nil {
-				resp.Diagnostics.AddError(
+				diags.AddError(
 					"Error creating VXC",
 					"Could not create VXC with name "+plan.Name.ValueString()+": "+err.Error(),
 				)
-				return
+				return nil
 			}
 
-			psks, pskDiags := ipSecPreSharedKeysFromConfig(ctx, req.Config, "b_end_partner_config", len(partnerConfigBEnd.Interfaces.Elements()))
-			resp.Diagnostics.Append(pskDiags...)
-			if resp.Diagnostics.HasError() {
-				return
+			psks, pskDiags := ipSecPreSharedKeysFromConfig(ctx, config, "b_end_partner_config", len(partnerConfigBEnd.Interfaces.Elements()))
+			diags.Append(pskDiags...)
+			bgpPasswords, passwordDiags := bgpPasswordsFromConfig(ctx, config, path.Root("b_end_partner_config").AtName("vrouter_config").AtName("interfaces"), len(partnerConfigBEnd.Interfaces.Elements()))
+			diags.Append(passwordDiags...)
+			diags.Append(generateIPSecPreSharedKeys(ctx, &partnerConfigBEnd, psks)...)
+			if diags.HasError() {
+				return nil
 			}
-			vrouterDiags, vrouterMegaportConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, partnerConfigBEnd, prefixFilterList, psks)
+			vrouterDiags, vrouterMegaportConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, partnerConfigBEnd, prefixFilterList, psks, bgpPasswords)
 			if vrouterDiags.HasError() {
-				resp.Diagnostics.Append(vrouterDiags...)
-				return
+				diags.Append(vrouterDiags...)
+				return nil
 			}
 			plan.BEndPartnerConfig = partnerConfigObj
 			bEndConfig.PartnerConfig = vrouterMegaportConfig
 		default:
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"Error creating VXC",
 				"Could not create VXC with name "+plan.Name.ValueString()+": Partner configuration not supported",
 			)
-			return
+			return nil
 		}
 	}
-
-	buyReq.BEndConfiguration = *bEndConfig
-
 	buyReq.BEndConfiguration = *bEndConfig
-
-	err := r.client.VXCService.ValidateVXCOrder(ctx, buyReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Validation error while attempting to create VXC",
-			"Validation error while attempting to create VXC with name "+plan.Name.ValueString()+": "+err.Error(),
-		)
-		return
-	}
-
-	createdVXC, err := r.client.VXCService.BuyVXC(ctx, buyReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating VXC",
-			"Could not order VXC with name "+plan.Name.ValueString()+": "+err.Error(),
-		)
-		return
-	}
-
-	createdID := createdVXC.TechnicalServiceUID
-
-	// Persist the UID immediately so any failure below leaves a tracked
-	// (tainted) resource instead of an orphan that later applies try to recreate.
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("product_uid"), createdID)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	if err := r.waitForVXCProvision(ctx, createdID, waitForTime, 30*time.Second); err != nil {
-		resp.Diagnostics.AddError(
-			"VXC ordered but not ready",
-			"VXC "+plan.Name.ValueString()+" ("+createdID+") was ordered successfully but did not reach a ready state: "+err.Error()+". Its UID has been saved to state and Terraform will replace it on the next apply.",
-		)
-		return
-	}
-
-	// get the created VXC
-	vxc, err := r.client.VXCService.GetVXC(ctx, createdID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading newly created VXC",
-			"VXC "+plan.Name.ValueString()+" ("+createdID+") was created but could not be read back: "+err.Error()+". Its UID has been saved to state.",
-		)
-		return
-	}
-
-	tags, err := r.client.VXCService.ListVXCResourceTags(ctx, createdID)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading tags for newly created VXC",
-			"VXC "+plan.Name.ValueString()+" ("+createdID+") was created but its tags could not be read: "+err.Error()+". Its UID has been saved to state.",
-		)
-		return
-	}
-
-	// update the plan with the VXC info
-	// Pass &plan so that user-only fields (ordered_vlan, requested_product_uid,
-	// vnic_index, partner configs) are preserved from the plan — the API may
-	// not return them reliably immediately after create.
-	apiDiags := plan.fromAPIVXC(ctx, vxc, tags, &plan)
-	resp.Diagnostics.Append(apiDiags...)
-
-	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
-
-	// Set state to fully populated data
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	return buyReq
 }
 
 // waitForVXCProvision polls the VXC until it reaches a ready state, hits a
-// terminal state, or the timeout elapses. Transient read errors are retried
-// rather than aborting the wait, since the order has already been placed.
+// terminal state, or the timeout elapses.
 func (r *vxcResource) waitForVXCProvision(ctx context.Context, uid string, timeoutAfter, pollInterval time.Duration) error {
-	// The polls share this deadline so a stalled HTTP request can't hang
-	// the wait past the overall timeout.
-	pollCtx, cancel := context.WithTimeout(ctx, timeoutAfter)
-	defer cancel()
-
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
-
-	for {
-		vxc, err := r.client.VXCService.GetVXC(pollCtx, uid)
-		switch {
-		case err != nil:
-			tflog.Warn(ctx, "error polling VXC provisioning status, will retry", map[string]interface{}{
-				"vxc_uid": uid,
-				"error":   err.Error(),
-			})
-		case slices.Contains(megaport.SERVICE_STATE_READY, vxc.ProvisioningStatus):
-			return nil
-		case vxc.ProvisioningStatus == megaport.STATUS_DECOMMISSIONED || vxc.ProvisioningStatus == megaport.STATUS_CANCELLED:
-			return fmt.Errorf("VXC %s reached terminal state %q before provisioning", uid, vxc.ProvisioningStatus)
-		}
-
-		select {
-		case <-pollCtx.Done():
-			if ctx.Err() != nil {
-				return ctx.Err()
-			}
-			return fmt.Errorf("time expired waiting for VXC %s to provision", uid)
-		case <-ticker.C:
-		}
-	}
+	return waitForProductReady(ctx, "VXC", []string{uid}, timeoutAfter, pollInterval, vxcStatus(r.client))
 }
 
 // Read resource information.
@@ -2008,6 +2249,14 @@ func (r *vxcResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed vxc value from API
 	vxc, err := r.client.VXCService.GetVXC(ctx, state.UID.ValueString())
 	if err != nil {
@@ -2050,10 +2299,11 @@ func (r *vxcResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 
 	aEndConfig := &vxcEndConfigurationModel{}
 	bEndConfig := &vxcEndConfigurationModel{}
-	aEndConfigDiags := state.AEndConfiguration.As(ctx, aEndConfig, basetypes.ObjectAsOptions{})
+	aEndConfigDiags := endConfigurationAs(ctx, state.AEndConfiguration, aEndConfig, basetypes.ObjectAsOptions{})
 	bEndConfigDiags := state.BEndConfiguration.As(ctx, bEndConfig, basetypes.ObjectAsOptions{})
 	resp.Diagnostics.Append(aEndConfigDiags...)
 	resp.Diagnostics.Append(bEndConfigDiags...)
@@ -2069,6 +2319,38 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
+	// The provisioning wait falls back to the provider wait_time, while the
+	// propagation checks that follow keep their shorter default unless the
+	// user sets an explicit update timeout.
+	updateTimeout, diags := plan.Timeouts.Update(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	propagationTimeout, diags := plan.Timeouts.Update(ctx, vxcUpdatePropagationTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	move := plannedVXCMove(ctx, &plan, &state, &resp.Diagnostics)
+	if move.planned() && !plan.UID.IsUnknown() {
+		// The move was not known when the plan was made, so it cannot
+		// replace the VXC without Terraform reporting an inconsistent result.
+		resp.Diagnostics.AddWarning("VXC moved in place",
+			fmt.Sprintf("The products the VXC moves to were not known when the plan was made, so VXC %s is moved in place instead of with %s. Plan the move once the products exist to move it with %s.",
+				state.UID.ValueString(), vxcMoveMakeBeforeBreak, vxcMoveMakeBeforeBreak))
+		move = vxcMove{}
+	}
+	if move.planned() && !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.moveVXCMakeBeforeBreak(ctx, req.Config, &plan, &state, move, updateTimeout, propagationTimeout)...)
+		if plan.UID.IsUnknown() {
+			// The move was rolled back, leaving the old VXC in state.
+			return
+		}
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, plan.UID)...)
+		return
+	}
+
 	var aEndPartnerChange, bEndPartnerChange bool
 
 	// Detect changes BEFORE normalizing null state values, otherwise adding
@@ -2095,7 +2377,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// Check if AEnd or BEnd is a CSP Partner Configuration
 	var aEndCSP, bEndCSP bool
 
-	aEndPlanDiags := plan.AEndConfiguration.As(ctx, &aEndPlan, basetypes.ObjectAsOptions{})
+	aEndPlanDiags := endConfigurationAs(ctx, plan.AEndConfiguration, &aEndPlan, basetypes.ObjectAsOptions{})
 	resp.Diagnostics.Append(aEndPlanDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -2106,7 +2388,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	aEndStateDiags := state.AEndConfiguration.As(ctx, &aEndState, basetypes.ObjectAsOptions{})
+	aEndStateDiags := endConfigurationAs(ctx, state.AEndConfiguration, &aEndState, basetypes.ObjectAsOptions{})
 	resp.Diagnostics.Append(aEndStateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -2117,7 +2399,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	aEndPartnerPlanDiags := plan.AEndPartnerConfig.As(ctx, &aEndPartnerPlan, basetypes.ObjectAsOptions{})
+	aEndPartnerPlanDiags := partnerConfigAs(ctx, plan.AEndPartnerConfig, &aEndPartnerPlan, basetypes.ObjectAsOptions{})
 	resp.Diagnostics.Append(aEndPartnerPlanDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -2130,7 +2412,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 			}
 		}
 	}
-	bEndPartnerPlanDiags := plan.BEndPartnerConfig.As(ctx, &bEndPartnerPlan, basetypes.ObjectAsOptions{})
+	bEndPartnerPlanDiags := partnerConfigAs(ctx, plan.BEndPartnerConfig, &bEndPartnerPlan, basetypes.ObjectAsOptions{})
 	resp.Diagnostics.Append(bEndPartnerPlanDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -2144,12 +2426,12 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		}
 	}
 
-	aEndPartnerStateDiags := state.AEndPartnerConfig.As(ctx, &aEndPartnerState, basetypes.ObjectAsOptions{})
+	aEndPartnerStateDiags := partnerConfigAs(ctx, state.AEndPartnerConfig, &aEndPartnerState, basetypes.ObjectAsOptions{})
 	resp.Diagnostics.Append(aEndPartnerStateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	bEndPartnerStateDiags := state.BEndPartnerConfig.As(ctx, &bEndPartnerState, basetypes.ObjectAsOptions{})
+	bEndPartnerStateDiags := partnerConfigAs(ctx, state.BEndPartnerConfig, &bEndPartnerState, basetypes.ObjectAsOptions{})
 	resp.Diagnostics.Append(bEndPartnerStateDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -2158,10 +2440,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	aEndProductType, _ := r.client.ProductService.GetProductType(ctx, aEndPlan.RequestedProductUID.ValueString())
 	bEndProductType, _ := r.client.ProductService.GetProductType(ctx, bEndPlan.RequestedProductUID.ValueString())
 
-	updateReq := &megaport.UpdateVXCRequest{
-		WaitForUpdate: true,
-		WaitForTime:   waitForTime,
-	}
+	updateReq := &megaport.UpdateVXCRequest{}
 
 	if !plan.Name.Equal(state.Name) {
 		updateReq.Name = megaport.PtrTo(plan.Name.ValueString())
@@ -2189,11 +2468,14 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	// Check VNIC index for A End
 	if strings.EqualFold(aEndProductType, megaport.PRODUCT_MVE) {
-		updateReq.AVnicIndex = megaport.PtrTo(int(aEndPlan.NetworkInterfaceIndex.ValueInt64()))
+		// Only include the VNIC index, and the VLAN the API needs alongside it,
+		// when the VNIC index changes
+		if !aEndPlan.NetworkInterfaceIndex.Equal(aEndState.NetworkInterfaceIndex) {
+			updateReq.AVnicIndex = megaport.PtrTo(int(aEndPlan.NetworkInterfaceIndex.ValueInt64()))
+		}
 
 		// Only include the VLAN when VNIC index changes AND the VLAN isn't already set correctly
-		if supportVLANUpdates(aEndPartnerType) &&
-			(!aEndPlan.NetworkInterfaceIndex.Equal(aEndState.NetworkInterfaceIndex)) {
+		if supportVLANUpdates(aEndPartnerType) && updateReq.AVnicIndex != nil {
 			// Only include VLAN if we need it for validation but don't already have it set correctly
 			if !aEndPlan.OrderedVLAN.IsNull() &&
 				!aEndPlan.OrderedVLAN.Equal(aEndState.OrderedVLAN) {
@@ -2248,11 +2530,14 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	// Check VNIC index for B End
 	if strings.EqualFold(bEndProductType, megaport.PRODUCT_MVE) {
-		updateReq.BVnicIndex = megaport.PtrTo(int(bEndPlan.NetworkInterfaceIndex.ValueInt64()))
+		// Only include the VNIC index, and the VLAN the API needs alongside it,
+		// when the VNIC index changes
+		if !bEndPlan.NetworkInterfaceIndex.Equal(bEndState.NetworkInterfaceIndex) {
+			updateReq.BVnicIndex = megaport.PtrTo(int(bEndPlan.NetworkInterfaceIndex.ValueInt64()))
+		}
 
 		// Only include the VLAN when VNIC index changes AND the VLAN isn't already set correctly
-		if supportVLANUpdates(bEndPartnerType) &&
-			(!bEndPlan.NetworkInterfaceIndex.Equal(bEndState.NetworkInterfaceIndex)) {
+		if supportVLANUpdates(bEndPartnerType) && updateReq.BVnicIndex != nil {
 			// Only include VLAN if we need it for validation but don't already have it set correctly
 			if !bEndPlan.OrderedVLAN.IsNull() &&
 				!bEndPlan.OrderedVLAN.Equal(bEndState.OrderedVLAN) {
@@ -2279,9 +2564,13 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		updateReq.RateLimit = megaport.PtrTo(int(plan.RateLimit.ValueInt64()))
 	}
 
-	// Always use the planned cost centre value, even if it's empty/null
-	costCentre := plan.CostCentre.ValueString()
-	updateReq.CostCentre = &costCentre
+	if !plan.CostCentre.IsUnknown() && !plan.CostCentre.Equal(state.CostCentre) {
+		updateReq.CostCentre = megaport.PtrTo(plan.CostCentre.ValueString())
+	}
+
+	if !plan.Shutdown.IsNull() && !plan.Shutdown.IsUnknown() && !plan.Shutdown.Equal(state.Shutdown) {
+		updateReq.Shutdown = megaport.PtrTo(plan.Shutdown.ValueBool())
+	}
 
 	if !plan.ContractTermMonths.IsNull() && !plan.ContractTermMonths.Equal(state.ContractTermMonths) {
 		updateReq.Term = megaport.PtrTo(int(plan.ContractTermMonths.ValueInt64()))
@@ -2314,7 +2603,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 				resp.Diagnostics.Append(transitDiags...)
 				return
 			}
-			state.AEndPartnerConfig = partnerConfigObj
+			state.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			updateReq.AEndPartnerConfig = transitPartnerConfig
 		case "a-end":
 			if aPartnerConfig.PartnerAEndConfig.IsNull() {
@@ -2338,12 +2627,17 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 				)
 				return
 			}
-			aEndDiags, aEndMegaportConfig, partnerConfigObj := createAEndPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList)
+			bgpPasswords, passwordDiags := bgpPasswordsFromConfig(ctx, req.Config, path.Root("a_end_partner_config").AtName("partner_a_end_config").AtName("interfaces"), len(partnerConfigAEnd.Interfaces.Elements()))
+			resp.Diagnostics.Append(passwordDiags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			aEndDiags, aEndMegaportConfig, partnerConfigObj := createAEndPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList, bgpPasswords)
 			if aEndDiags.HasError() {
 				resp.Diagnostics.Append(aEndDiags...)
 				return
 			}
-			state.AEndPartnerConfig = partnerConfigObj
+			state.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			updateReq.AEndPartnerConfig = aEndMegaportConfig
 		case "vrouter":
 			if aEndPartnerPlan.VrouterPartnerConfig.IsNull() {
@@ -2369,15 +2663,21 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 			}
 			psks, pskDiags := ipSecPreSharedKeysFromConfig(ctx, req.Config, "a_end_partner_config", len(partnerConfigAEnd.Interfaces.Elements()))
 			resp.Diagnostics.Append(pskDiags...)
+			bgpPasswords, passwordDiags := bgpPasswordsFromConfig(ctx, req.Config, path.Root("a_end_partner_config").AtName("vrouter_config").AtName("interfaces"), len(partnerConfigAEnd.Interfaces.Elements()))
+			resp.Diagnostics.Append(passwordDiags...)
+			resp.Diagnostics.Append(generateIPSecPreSharedKeys(ctx, &partnerConfigAEnd, psks)...)
 			if resp.Diagnostics.HasError() {
 				return
 			}
-			vrouterDiags, vrouterPartnerConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList, psks)
+			vrouterDiags, vrouterPartnerConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, partnerConfigAEnd, prefixFilterList, psks, bgpPasswords)
 			if vrouterDiags.HasError() {
 				resp.Diagnostics.Append(vrouterDiags...)
 				return
 			}
-			state.AEndPartnerConfig = partnerConfigObj
+			// The final state is built from the plan's partner config, which
+			// must carry any pre-shared key generated above.
+			plan.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
+			state.AEndPartnerConfig = aEndPartnerConfigValue(partnerConfigObj)
 			updateReq.AEndPartnerConfig = vrouterPartnerConfig
 		default:
 			resp.Diagnostics.AddError(
@@ -2423,15 +2723,19 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 			psks, pskDiags := ipSecPreSharedKeysFromConfig(ctx, req.Config, "b_end_partner_config", len(vrouterModel.Interfaces.Elements()))
 			resp.Diagnostics.Append(pskDiags...)
+			bgpPasswords, passwordDiags := bgpPasswordsFromConfig(ctx, req.Config, path.Root("b_end_partner_config").AtName("vrouter_config").AtName("interfaces"), len(vrouterModel.Interfaces.Elements()))
+			resp.Diagnostics.Append(passwordDiags...)
+			resp.Diagnostics.Append(generateIPSecPreSharedKeys(ctx, &vrouterModel, psks)...)
 			if resp.Diagnostics.HasError() {
 				return
 			}
-			vrouterDiags, vrouterPartnerConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, vrouterModel, prefixFilterList, psks)
+			vrouterDiags, vrouterPartnerConfig, partnerConfigObj := createVrouterPartnerConfig(ctx, vrouterModel, prefixFilterList, psks, bgpPasswords)
 			if vrouterDiags.HasError() {
 				resp.Diagnostics.Append(vrouterDiags...)
 				return
 			}
 
+			plan.BEndPartnerConfig = partnerConfigObj
 			state.BEndPartnerConfig = partnerConfigObj
 			updateReq.BEndPartnerConfig = vrouterPartnerConfig
 		default:
@@ -2458,6 +2762,9 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 	if isChanged {
 		_, err := r.client.VXCService.UpdateVXC(ctx, plan.UID.ValueString(), updateReq)
+		if err == nil {
+			err = waitForProductReady(ctx, "VXC", []string{plan.UID.ValueString()}, updateTimeout, r.pollInterval, vxcStatus(r.client))
+		}
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Updating VXC",
@@ -2466,9 +2773,11 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 			return
 		}
 
-		// Add retry logic to wait for API propagation
-		waitErr = r.waitForVXCUpdate(ctx, plan.UID.ValueString(), updateReq, updateTimeout)
-		if waitErr != nil {
+		// Add retry logic to wait for API propagation. Fields that time out are
+		// reported one by one once the VXC has been read back below.
+		waitErr = r.waitForVXCUpdate(ctx, plan.UID.ValueString(), updateReq, propagationTimeout)
+		var timeoutErr *vxcUpdateTimeoutError
+		if waitErr != nil && !errors.As(waitErr, &timeoutErr) {
 			resp.Diagnostics.AddWarning(
 				"VXC Update Propagation Delay",
 				fmt.Sprintf("VXC update completed but verification timed out: %s. The update may still be propagating.", waitErr.Error()),
@@ -2479,7 +2788,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// Update state with any changes from plan configuration following successful update
 	aEndStateObj, aEndStateDiags := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, aEndState)
 	resp.Diagnostics.Append(aEndStateDiags...)
-	state.AEndConfiguration = aEndStateObj
+	state.AEndConfiguration = aEndConfigurationValue(aEndStateObj)
 	bEndStateObj, bEndStateDiags := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, bEndState)
 	resp.Diagnostics.Append(bEndStateDiags...)
 	state.BEndConfiguration = bEndStateObj
@@ -2496,7 +2805,7 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	// Get refreshed vxc value from API, waiting for vnic_index to propagate
-	vxc, err := r.waitForVnicIndex(ctx, state.UID.ValueString(), expectedAEndVnic, expectedBEndVnic, updateTimeout)
+	vxc, err := r.waitForVnicIndex(ctx, state.UID.ValueString(), expectedAEndVnic, expectedBEndVnic, propagationTimeout)
 	if err != nil {
 		if vxc == nil {
 			resp.Diagnostics.AddError(
@@ -2511,22 +2820,14 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		)
 	}
 
-	// Only show VLAN mismatch warnings if waitForVXCUpdate failed (timed out or errored)
-	if isChanged && waitErr != nil {
-		if updateReq.AEndInnerVLAN != nil && !innerVLANMatches(*updateReq.AEndInnerVLAN, vxc.AEndConfiguration.InnerVLAN) {
-			resp.Diagnostics.AddWarning(
-				"A-End Inner VLAN Mismatch",
-				fmt.Sprintf("Expected A-End inner_vlan=%d but API returned %d. This may indicate API propagation delay.",
-					*updateReq.AEndInnerVLAN, vxc.AEndConfiguration.InnerVLAN),
-			)
-		}
-
-		if updateReq.BEndInnerVLAN != nil && !innerVLANMatches(*updateReq.BEndInnerVLAN, vxc.BEndConfiguration.InnerVLAN) {
-			resp.Diagnostics.AddWarning(
-				"B-End Inner VLAN Mismatch",
-				fmt.Sprintf("Expected B-End inner_vlan=%d but API returned %d. This may indicate API propagation delay.",
-					*updateReq.BEndInnerVLAN, vxc.BEndConfiguration.InnerVLAN),
-			)
+	// Report the fields that still differ from the update request if
+	// waitForVXCUpdate gave up on them.
+	var timeoutErr *vxcUpdateTimeoutError
+	if isChanged && errors.As(waitErr, &timeoutErr) {
+		for _, m := range unappliedVXCUpdateFields(vxc, updateReq) {
+			resp.Diagnostics.AddAttributeWarning(m.attribute, "VXC Update Not Applied",
+				fmt.Sprintf("VXC %s was updated but %s was still not applied after %v: requested %v, the API returned %v. The update may still be propagating; run terraform plan later to check.",
+					state.UID.ValueString(), m.attribute, timeoutErr.timeout, m.requested, m.observed))
 		}
 	}
 
@@ -2560,14 +2861,21 @@ func (r *vxcResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	apiDiags := state.fromAPIVXC(ctx, vxc, tags, &plan)
 	state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 	state.PromoCode = plan.PromoCode
+	state.MoveStrategy = plan.MoveStrategy
+	state.WaitForBGP = plan.WaitForBGP
+	state.WaitForBGPTimeout = plan.WaitForBGPTimeout
+	state.Timeouts = plan.Timeouts
 	resp.Diagnostics.Append(apiDiags...)
 
 	// Set refreshed state
-	diags := resp.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setProductIdentity(ctx, resp.Identity, state.UID)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(r.waitForRequestedBGP(ctx, &plan, vxc)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -2580,11 +2888,17 @@ func (r *vxcResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, r.waitTime)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete existing order
-	err := retryTransientDelete(ctx, 3, func() error {
-		return r.client.VXCService.DeleteVXC(ctx, state.UID.ValueString(), &megaport.DeleteVXCRequest{
-			DeleteNow: true,
-		})
+	err := r.client.VXCService.DeleteVXC(ctx, state.UID.ValueString(), &megaport.DeleteVXCRequest{
+		DeleteNow: true,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -2614,12 +2928,27 @@ func (r *vxcResource) Configure(_ context.Context, req resource.ConfigureRequest
 	client := data.client
 
 	r.client = client
+	r.waitTime = data.waitTime
+	r.pollInterval = data.pollInterval
 }
 
 func (r *vxcResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
-	resource.ImportStatePassthroughID(ctx, path.Root("product_uid"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("product_uid"), path.Root("product_uid"), req, resp)
+
+}
+
+// IdentitySchema defines the identity schema for the resource.
+func (r *vxcResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = productIdentitySchema()
+}
 
+// UpgradeState upgrades state saved by earlier versions of the resource schema.
+// Version 0 stored the secrets that are now write-only.
+func (r *vxcResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: writeOnlySecretsStateUpgrader(),
+	}
 }
 
 func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (types.Object, diag.Diagnostics) {
@@ -2627,14 +2956,15 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 	switch provider := c.(type) {
 	case megaport.CSPConnectionAWS:
 		awsModel := &cspConnectionModel{
-			ConnectType:       types.StringValue(provider.ConnectType),
-			ResourceName:      types.StringValue(provider.ResourceName),
-			ResourceType:      types.StringValue(provider.ResourceType),
-			VLAN:              types.Int64Value(int64(provider.VLAN)),
-			Account:           types.StringValue(provider.Account),
-			AmazonAddress:     types.StringValue(provider.AmazonAddress),
-			ASN:               types.Int64Value(int64(provider.ASN)),
-			AuthKey:           types.StringValue(provider.AuthKey),
+			ConnectType:   types.StringValue(provider.ConnectType),
+			ResourceName:  types.StringValue(provider.ResourceName),
+			ResourceType:  types.StringValue(provider.ResourceType),
+			VLAN:          types.Int64Value(int64(provider.VLAN)),
+			Account:       types.StringValue(provider.Account),
+			AmazonAddress: types.StringValue(provider.AmazonAddress),
+			ASN:           types.Int64Value(int64(provider.ASN)),
+			// The auth key is a secret, so it is never read back into state.
+			AuthKey:           types.StringNull(),
 			CustomerAddress:   types.StringValue(provider.CustomerAddress),
 			CustomerIPAddress: types.StringValue(provider.CustomerIPAddress),
 			ID:                types.Int64Value(int64(provider.ID)),
@@ -2646,6 +2976,7 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 		}
 		awsModel.Bandwidths = types.ListNull(types.Int64Type)
 		awsModel.IPAddresses = types.ListNull(types.StringType)
+		awsModel.Attributes = types.MapNull(types.StringType)
 		awsObject, awsDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, awsModel)
 		apiDiags = append(apiDiags, awsDiags...)
 		return awsObject, apiDiags
@@ -2667,6 +2998,7 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 		apiDiags = append(apiDiags, bandwidthDiags...)
 		awsHCModel.Bandwidths = bandwidthList
 		awsHCModel.IPAddresses = types.ListNull(types.StringType)
+		awsHCModel.Attributes = types.MapNull(types.StringType)
 		awsHCObject, awsHCDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, awsHCModel)
 		apiDiags = append(apiDiags, awsHCDiags...)
 		return awsHCObject, apiDiags
@@ -2682,6 +3014,7 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 		}
 		azureModel.Bandwidths = types.ListNull(types.Int64Type)
 		azureModel.IPAddresses = types.ListNull(types.StringType)
+		azureModel.Attributes = types.MapNull(types.StringType)
 		azureObject, azureObjDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, azureModel)
 		apiDiags = append(apiDiags, azureObjDiags...)
 		return azureObject, apiDiags
@@ -2700,6 +3033,7 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 			bandwidths = append(bandwidths, int64(b))
 		}
 		googleModel.IPAddresses = types.ListNull(types.StringType)
+		googleModel.Attributes = types.MapNull(types.StringType)
 		bandwidthList, bwListDiags := types.ListValueFrom(ctx, types.Int64Type, bandwidths)
 		apiDiags = append(apiDiags, bwListDiags...)
 		googleModel.Bandwidths = bandwidthList
@@ -2720,6 +3054,7 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 		ipList, ipListDiags := types.ListValueFrom(ctx, types.StringType, ipAddresses)
 		apiDiags = append(apiDiags, ipListDiags...)
 		virtualRouterModel.IPAddresses = ipList
+		virtualRouterModel.Attributes = types.MapNull(types.StringType)
 		virtualRouterObject, vrObjDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, virtualRouterModel)
 		apiDiags = append(apiDiags, vrObjDiags...)
 		return virtualRouterObject, apiDiags
@@ -2735,6 +3070,7 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 		}
 		transitModel.Bandwidths = types.ListNull(types.Int64Type)
 		transitModel.IPAddresses = types.ListNull(types.StringType)
+		transitModel.Attributes = types.MapNull(types.StringType)
 		transitObject, transitObjectDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, transitModel)
 		apiDiags = append(apiDiags, transitObjectDiags...)
 		return transitObject, apiDiags
@@ -2757,6 +3093,7 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 		// Set null values for fields that don't apply to Oracle connections
 		oracleModel.Bandwidths = types.ListNull(types.Int64Type)
 		oracleModel.IPAddresses = types.ListNull(types.StringType)
+		oracleModel.Attributes = types.MapNull(types.StringType)
 
 		// Convert the model to a Terraform Object
 		oracleObj, oracleObjDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, oracleModel)
@@ -2782,14 +3119,88 @@ func fromAPICSPConnection(ctx context.Context, c megaport.CSPConnectionConfig) (
 		apiDiags = append(apiDiags, bandwidthListDiags...)
 		ibmModel.Bandwidths = bandwidthList
 		ibmModel.IPAddresses = types.ListNull(types.StringType)
+		ibmModel.Attributes = types.MapNull(types.StringType)
 		ibmObject, ibmObjectDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, ibmModel)
 		apiDiags = append(apiDiags, ibmObjectDiags...)
 		return ibmObject, apiDiags
+	case megaport.CSPConnectionOther:
+		return fromAPIOtherCSPConnection(ctx, provider.CSPConnection)
 	}
 	apiDiags.AddError("Error creating CSP Connection", "Could not create CSP Connection, unknown type")
 	return types.ObjectNull(cspConnectionFullAttrs), apiDiags
 }
 
+// fromAPIOtherCSPConnection converts a CSP connection of a type megaportgo does
+// not model, such as one ordered through generic_config. Fields shared by all
+// CSP connections are mapped to their attributes and everything else is kept
+// in attributes, so that new partners are readable without a dedicated struct.
+func fromAPIOtherCSPConnection(ctx context.Context, fields map[string]any) (types.Object, diag.Diagnostics) {
+	apiDiags := diag.Diagnostics{}
+	// Fields the API leaves out are null.
+	str := func(key string) types.String {
+		v, ok := fields[key].(string)
+		if !ok {
+			return types.StringNull()
+		}
+		return types.StringValue(v)
+	}
+	num := func(key string) types.Int64 {
+		v, ok := fields[key].(float64)
+		if !ok {
+			return types.Int64Null()
+		}
+		return types.Int64Value(int64(v))
+	}
+	model := &cspConnectionModel{
+		ConnectType:  str("connectType"),
+		ResourceName: str("resource_name"),
+		ResourceType: str("resource_type"),
+		Name:         str("name"),
+		CSPName:      str("csp_name"),
+		VLAN:         num("vlan"),
+		Bandwidth:    num("bandwidth"),
+		IPAddresses:  types.ListNull(types.StringType),
+	}
+
+	model.Bandwidths = types.ListNull(types.Int64Type)
+	if list, ok := fields["bandwidths"].([]any); ok {
+		bandwidths := []int64{}
+		for _, b := range list {
+			if f, ok := b.(float64); ok {
+				bandwidths = append(bandwidths, int64(f))
+			}
+		}
+		bandwidthList, bandwidthDiags := types.ListValueFrom(ctx, types.Int64Type, bandwidths)
+		apiDiags = append(apiDiags, bandwidthDiags...)
+		model.Bandwidths = bandwidthList
+	}
+
+	mapped := map[string]bool{"connectType": true, "resource_name": true, "resource_type": true, "name": true, "csp_name": true, "vlan": true, "bandwidth": true, "bandwidths": true}
+	attributes := map[string]string{}
+	for k, v := range fields {
+		if mapped[k] || v == nil {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			attributes[k] = s
+			continue
+		}
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			apiDiags.AddWarning("Error reading CSP Connection", fmt.Sprintf("Could not read field %q of the %s CSP connection: %s", k, model.ConnectType.ValueString(), err))
+			continue
+		}
+		attributes[k] = string(encoded)
+	}
+	attributeMap, attributeDiags := types.MapValueFrom(ctx, types.StringType, attributes)
+	apiDiags = append(apiDiags, attributeDiags...)
+	model.Attributes = attributeMap
+
+	obj, objDiags := types.ObjectValueFrom(ctx, cspConnectionFullAttrs, model)
+	apiDiags = append(apiDiags, objDiags...)
+	return obj, apiDiags
+}
+
 func (r *vxcResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	// Get current state
 	var plan, state vxcResourceModel
@@ -2810,6 +3221,20 @@ func (r *vxcResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 		}
 	}
 
+	if !req.Plan.Raw.IsNull() {
+		if r.selectBEndPartnerPort(ctx, req.Config, &plan, &state, &resp.Diagnostics) && !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.validateBEndPartnerPort(ctx, &plan, &state)...)
+		resp.Diagnostics.Append(r.validateIPsecTunnelCapacity(ctx, &plan, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// If VXC is not yet created, return
 	if !state.UID.IsNull() {
 		if !req.Plan.Raw.IsNull() {
@@ -2828,7 +3253,7 @@ func (r *vxcResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 				return
 			}
 
-			plan.AEndConfiguration = reconcileVXCEnd(ctx, vxcEndReconcileInput{
+			plan.AEndConfiguration = aEndConfigurationValue(reconcileVXCEnd(ctx, vxcEndReconcileInput{
 				endLabel:              "A-End",
 				partnerConfigPathRoot: "a_end_partner_config",
 				planEndObj:            aEndPlanObj,
@@ -2837,7 +3262,7 @@ func (r *vxcResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 				statePartnerConfig:    &state.AEndPartnerConfig,
 				requiresReplace:       &resp.RequiresReplace,
 				diags:                 &diags,
-			})
+			}))
 			plan.BEndConfiguration = reconcileVXCEnd(ctx, vxcEndReconcileInput{
 				endLabel:              "B-End",
 				partnerConfigPathRoot: "b_end_partner_config",
@@ -2848,6 +3273,9 @@ func (r *vxcResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 				requiresReplace:       &resp.RequiresReplace,
 				diags:                 &diags,
 			})
+			if move := plannedVXCMove(ctx, &plan, &state, &diags); move.planned() {
+				planMakeBeforeBreakMove(ctx, &plan, move, &diags)
+			}
 			resp.Diagnostics.Append(diags...)
 			if !resp.Diagnostics.HasError() {
 				resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
@@ -2888,8 +3316,8 @@ func anyVXCEndObjectUnknownOrNull(ends ...types.Object) bool {
 func reconcileVXCEnd(ctx context.Context, in vxcEndReconcileInput) types.Object {
 	stateConfig := &vxcEndConfigurationModel{}
 	planConfig := &vxcEndConfigurationModel{}
-	*in.diags = append(*in.diags, in.stateEndObj.As(ctx, stateConfig, basetypes.ObjectAsOptions{})...)
-	*in.diags = append(*in.diags, in.planEndObj.As(ctx, planConfig, basetypes.ObjectAsOptions{})...)
+	*in.diags = append(*in.diags, endConfigurationAs(ctx, in.stateEndObj, stateConfig, basetypes.ObjectAsOptions{})...)
+	*in.diags = append(*in.diags, endConfigurationAs(ctx, in.planEndObj, planConfig, basetypes.ObjectAsOptions{})...)
 
 	if stateConfig.OrderedVLAN.IsUnknown() {
 		planConfig.OrderedVLAN = stateConfig.VLAN
@@ -2938,7 +3366,7 @@ func isCSPPartnerConfig(ctx context.Context, planPartnerConfig types.Object, dia
 		return false
 	}
 	var partnerConfigModel vxcPartnerConfigurationModel
-	*diags = append(*diags, planPartnerConfig.As(ctx, &partnerConfigModel, basetypes.ObjectAsOptions{})...)
+	*diags = append(*diags, partnerConfigAs(ctx, planPartnerConfig, &partnerConfigModel, basetypes.ObjectAsOptions{})...)
 	if partnerConfigModel.Partner.IsNull() {
 		return false
 	}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGenericPartnerConfig(t *testing.T) {
+	ctx := context.Background()
+	peer, diags := types.ObjectValue(partnerOrderAzurePeeringConfigAttrs, map[string]attr.Value{
+		"type":             types.StringValue("private"),
+		"peer_asn":         types.StringValue("64512"),
+		"primary_subnet":   types.StringValue("10.0.0.0/30"),
+		"secondary_subnet": types.StringValue("10.0.0.4/30"),
+		"prefixes":         types.StringNull(),
+		"shared_key":       types.StringNull(),
+		"vlan":             types.Int64Value(100),
+	})
+	require.False(t, diags.HasError(), diags)
+	peers := types.ListValueMust(types.ObjectType{AttrTypes: partnerOrderAzurePeeringConfigAttrs}, []attr.Value{peer})
+
+	config := vxcPartnerConfigGenericModel{
+		ConnectType:     types.StringValue("ALIBABA"),
+		PairingKey:      types.StringValue("alibaba-key"),
+		PairingKeyField: types.StringValue("serviceKey"),
+		Peers:           peers,
+		Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{
+			"regionId":    types.StringValue("cn-hangzhou"),
+			"connectType": types.StringValue("IGNORED"),
+		}),
+	}
+	diags, partnerConfig, obj := createGenericPartnerConfig(ctx, config)
+	require.False(t, diags.HasError(), diags)
+
+	encoded, err := json.Marshal(partnerConfig)
+	require.NoError(t, err)
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal(encoded, &sent))
+	assert.Equal(t, "ALIBABA", sent["connectType"], "connect_type takes precedence over attributes")
+	assert.Equal(t, "alibaba-key", sent["serviceKey"])
+	assert.NotContains(t, sent, "pairingKey")
+	assert.Equal(t, "cn-hangzhou", sent["regionId"])
+	require.Len(t, sent["peers"], 1)
+	assert.Equal(t, "64512", sent["peers"].([]any)[0].(map[string]any)["peer_asn"])
+
+	var model vxcPartnerConfigurationModel
+	require.False(t, obj.As(ctx, &model, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, "generic", model.Partner.ValueString())
+	assert.False(t, model.GenericPartnerConfig.IsNull())
+	assert.True(t, model.AWSPartnerConfig.IsNull())
+
+	// The key is sent as pairingKey unless told otherwise, and omitted when
+	// not given.
+	config.PairingKeyField = types.StringNull()
+	_, partnerConfig, _ = createGenericPartnerConfig(ctx, config)
+	assert.Equal(t, "alibaba-key", partnerConfig.Fields["pairingKey"])
+	config.PairingKey = types.StringNull()
+	_, partnerConfig, _ = createGenericPartnerConfig(ctx, config)
+	assert.NotContains(t, partnerConfig.Fields, "pairingKey")
+}
+
+func TestFromAPIOtherCSPConnection(t *testing.T) {
+	ctx := context.Background()
+	obj, diags := fromAPIOtherCSPConnection(ctx, map[string]any{
+		"connectType":   "ALIBABA",
+		"resource_name": "b_csp_connection",
+		"resource_type": "csp_connection",
+		"vlan":          float64(200),
+		"bandwidth":     float64(100),
+		"bandwidths":    []any{float64(50), float64(100)},
+		"regionId":      "cn-hangzhou",
+		"ownerAccount":  float64(123456),
+		"enabled":       true,
+		"peers":         []any{map[string]any{"peer_asn": "64512"}},
+		"missing":       nil,
+	})
+	require.False(t, diags.HasError(), diags)
+
+	var model cspConnectionModel
+	require.False(t, obj.As(ctx, &model, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, "ALIBABA", model.ConnectType.ValueString())
+	assert.Equal(t, "b_csp_connection", model.ResourceName.ValueString())
+	assert.Equal(t, int64(200), model.VLAN.ValueInt64())
+	assert.Equal(t, int64(100), model.Bandwidth.ValueInt64())
+	assert.Len(t, model.Bandwidths.Elements(), 2)
+	assert.True(t, model.IPAddresses.IsNull())
+
+	attributes := map[string]string{}
+	require.False(t, model.Attributes.ElementsAs(ctx, &attributes, false).HasError())
+	assert.Equal(t, map[string]string{
+		"regionId":     "cn-hangzhou",
+		"ownerAccount": "123456",
+		"enabled":      "true",
+		"peers":        `[{"peer_asn":"64512"}]`,
+	}, attributes)
+}
+
+func TestFromAPIOtherCSPConnection_MissingFields(t *testing.T) {
+	ctx := context.Background()
+	obj, diags := fromAPIOtherCSPConnection(ctx, map[string]any{
+		"connectType": "ALIBABA",
+	})
+	require.False(t, diags.HasError(), diags)
+
+	var model cspConnectionModel
+	require.False(t, obj.As(ctx, &model, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, "ALIBABA", model.ConnectType.ValueString())
+	assert.True(t, model.ResourceName.IsNull())
+	assert.True(t, model.Name.IsNull())
+	assert.True(t, model.CSPName.IsNull())
+	assert.True(t, model.VLAN.IsNull())
+	assert.True(t, model.Bandwidth.IsNull())
+	assert.True(t, model.Bandwidths.IsNull())
+	assert.Empty(t, model.Attributes.Elements())
+}
+
+// The attributes of a CSP connection can hold the partner's keys, so they
+// are kept out of plan output.
+func TestVXCCSPConnectionAttributesSensitive(t *testing.T) {
+	resp := &fwresource.SchemaResponse{}
+	NewVXCResource().Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	connections, ok := resp.Schema.Attributes["csp_connections"].(schema.ListNestedAttribute)
+	require.True(t, ok)
+	assert.True(t, connections.NestedObject.Attributes["attributes"].IsSensitive())
+}
+
+func TestVXCGenericConfigOnlyAtBEnd(t *testing.T) {
+	resp := &fwresource.SchemaResponse{}
+	NewVXCResource().Schema(context.Background(), fwresource.SchemaRequest{}, resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	aEnd, ok := resp.Schema.Attributes["a_end_partner_config"].(schema.SingleNestedAttribute)
+	require.True(t, ok)
+	assert.NotContains(t, aEnd.Attributes, "generic_config")
+	assert.Equal(t, vxcAEndPartnerConfigAttrs, aEnd.GetType().(types.ObjectType).AttrTypes)
+
+	bEnd, ok := resp.Schema.Attributes["b_end_partner_config"].(schema.SingleNestedAttribute)
+	require.True(t, ok)
+	assert.Contains(t, bEnd.Attributes, "generic_config")
+	assert.Equal(t, vxcPartnerConfigAttrs, bEnd.GetType().(types.ObjectType).AttrTypes)
+}
+
+func TestUnitMegaportVXC_GenericPartner(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	aEnd := api.seedPort("Generic Partner A-End")
+	partnerPort := api.seedPartnerPort("ALIBABA", "alibaba-key")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fakeProviderConfig + fmt.Sprintf(`
+				resource "megaport_vxc" "vxc" {
+					product_name         = "Alibaba VXC"
+					rate_limit           = 100
+					contract_term_months = 12
+					a_end = {
+						requested_product_uid = %q
+						ordered_vlan          = 100
+					}
+					b_end = {}
+					b_end_partner_config = {
+						partner = "generic"
+						generic_config = {
+							connect_type = "ALIBABA"
+							pairing_key  = "alibaba-key"
+							attributes = {
+								regionId = "cn-hangzhou"
+							}
+						}
+					}
+				}`, aEnd),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "b_end.current_product_uid", partnerPort),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "csp_connections.#", "1"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "csp_connections.0.connect_type", "ALIBABA"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "csp_connections.0.bandwidth", "100"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "csp_connections.0.attributes.regionId", "cn-hangzhou"),
+					resource.TestCheckNoResourceAttr("megaport_vxc.vxc", "csp_connections.0.attributes.pairingKey"),
+				),
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
@@ -4521,23 +4523,29 @@ func TestVXCModifyPlan_UnknownEndConfiguration(t *testing.T) {
 		t.Fatal("schema type is not tftypes.Object")
 	}
 
-	// Find the end configuration object type from the schema.
-	endObjType, ok := schemaObjType.AttributeTypes["a_end"].(tftypes.Object)
-	if !ok {
-		t.Fatal("a_end type is not tftypes.Object")
-	}
+	// Find each end configuration object type from the schema; the A-End has
+	// no partner_port_selector.
+	endObjTypes := map[string]tftypes.Object{}
+	validEndVals := map[string]tftypes.Value{}
+	for _, end := range []string{"a_end", "b_end"} {
+		endObjType, ok := schemaObjType.AttributeTypes[end].(tftypes.Object)
+		if !ok {
+			t.Fatalf("%s type is not tftypes.Object", end)
+		}
+		endObjTypes[end] = endObjType
 
-	// Build a valid end configuration value (all nulls except requested_product_uid).
-	validEndAttrs := nullValueMap(endObjType)
-	validEndAttrs["requested_product_uid"] = tftypes.NewValue(tftypes.String, "port-uid-123")
-	validEndVal := tftypes.NewValue(endObjType, validEndAttrs)
+		// Build a valid end configuration value (all nulls except requested_product_uid).
+		validEndAttrs := nullValueMap(endObjType)
+		validEndAttrs["requested_product_uid"] = tftypes.NewValue(tftypes.String, "port-uid-123")
+		validEndVals[end] = tftypes.NewValue(endObjType, validEndAttrs)
+	}
 
 	// Build state: needs non-null product_uid so we enter the code path,
 	// plus valid a_end/b_end objects.
 	stateAttrs := nullValueMap(schemaObjType)
 	stateAttrs["product_uid"] = tftypes.NewValue(tftypes.String, "vxc-uid-123")
-	stateAttrs["a_end"] = validEndVal
-	stateAttrs["b_end"] = validEndVal
+	stateAttrs["a_end"] = validEndVals["a_end"]
+	stateAttrs["b_end"] = validEndVals["b_end"]
 	stateVal := tftypes.NewValue(schemaObjType, stateAttrs)
 
 	tests := []struct {
@@ -4559,15 +4567,14 @@ func TestVXCModifyPlan_UnknownEndConfiguration(t *testing.T) {
 			planAttrs := nullValueMap(schemaObjType)
 			planAttrs["product_name"] = tftypes.NewValue(tftypes.String, "test-vxc")
 			planAttrs["rate_limit"] = tftypes.NewValue(tftypes.Number, 1000)
-			planAttrs["a_end"] = validEndVal
-			planAttrs["b_end"] = validEndVal
+			planAttrs["a_end"] = validEndVals["a_end"]
+			planAttrs["b_end"] = validEndVals["b_end"]
 
-			override := tftypes.NewValue(endObjType, tftypes.UnknownValue)
-			if tc.makeNull {
-				override = tftypes.NewValue(endObjType, nil)
-			}
 			for _, end := range tc.overrideEnds {
-				planAttrs[end] = override
+				planAttrs[end] = tftypes.NewValue(endObjTypes[end], tftypes.UnknownValue)
+				if tc.makeNull {
+					planAttrs[end] = tftypes.NewValue(endObjTypes[end], nil)
+				}
 			}
 
 			planVal := tftypes.NewValue(schemaObjType, planAttrs)
@@ -4614,20 +4621,19 @@ func TestVXCModifyPlan_ValidEndsReconcile(t *testing.T) {
 	if !ok {
 		t.Fatal("schema type is not tftypes.Object")
 	}
-	endObjType, ok := schemaObjType.AttributeTypes["a_end"].(tftypes.Object)
-	if !ok {
-		t.Fatal("a_end type is not tftypes.Object")
-	}
-
 	// Distinct UIDs per end so the assertions catch an A-End/B-End wiring swap,
 	// not just a decode crash.
-	makeEnd := func(uid string) tftypes.Value {
+	makeEnd := func(name, uid string) tftypes.Value {
+		endObjType, ok := schemaObjType.AttributeTypes[name].(tftypes.Object)
+		if !ok {
+			t.Fatalf("%s type is not tftypes.Object", name)
+		}
 		attrs := nullValueMap(endObjType)
 		attrs["requested_product_uid"] = tftypes.NewValue(tftypes.String, uid)
 		return tftypes.NewValue(endObjType, attrs)
 	}
-	aEndVal := makeEnd("port-uid-aaa")
-	bEndVal := makeEnd("port-uid-bbb")
+	aEndVal := makeEnd("a_end", "port-uid-aaa")
+	bEndVal := makeEnd("b_end", "port-uid-bbb")
 
 	stateAttrs := nullValueMap(schemaObjType)
 	stateAttrs["product_uid"] = tftypes.NewValue(tftypes.String, "vxc-uid-123")
@@ -4666,7 +4672,7 @@ func TestVXCModifyPlan_ValidEndsReconcile(t *testing.T) {
 		t.Fatalf("decoding resp.Plan failed: %v", diags.Errors())
 	}
 	var aEnd, bEnd vxcEndConfigurationModel
-	if diags := got.AEndConfiguration.As(ctx, &aEnd, basetypes.ObjectAsOptions{}); diags.HasError() {
+	if diags := endConfigurationAs(ctx, got.AEndConfiguration, &aEnd, basetypes.ObjectAsOptions{}); diags.HasError() {
 		t.Fatalf("decoding a_end failed: %v", diags.Errors())
 	}
 	if diags := got.BEndConfiguration.As(ctx, &bEnd, basetypes.ObjectAsOptions{}); diags.HasError() {
@@ -4693,6 +4699,7 @@ func TestReconcileVXCEnd_RequestedProductUID(t *testing.T) {
 		obj, d := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, vxcEndConfigurationModel{
 			RequestedProductUID: requested,
 			CurrentProductUID:   current,
+			PartnerPortSelector: types.ObjectNull(vxcPartnerPortSelectorAttrs),
 		})
 		if d.HasError() {
 			t.Fatalf("build end object: %v", d.Errors())
@@ -4701,7 +4708,7 @@ func TestReconcileVXCEnd_RequestedProductUID(t *testing.T) {
 	}
 	cspPartner := func(t *testing.T) types.Object {
 		t.Helper()
-		partnerType := types.ObjectType{AttrTypes: vxcPartnerConfigAttrs}
+		partnerType := types.ObjectType{AttrTypes: vxcAEndPartnerConfigAttrs}
 		raw, ok := partnerType.TerraformType(ctx).(tftypes.Object)
 		if !ok {
 			t.Fatal("partner config type is not tftypes.Object")
@@ -4780,11 +4787,11 @@ func TestReconcileVXCEnd_RequestedProductUID(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			planPartner := types.ObjectNull(vxcPartnerConfigAttrs)
+			planPartner := types.ObjectNull(vxcAEndPartnerConfigAttrs)
 			if tc.csp {
 				planPartner = cspPartner(t)
 			}
-			statePartner := types.ObjectNull(vxcPartnerConfigAttrs)
+			statePartner := types.ObjectNull(vxcAEndPartnerConfigAttrs)
 			diags := diag.Diagnostics{}
 			var rr path.Paths
 
@@ -4829,7 +4836,7 @@ func TestReconcileVXCEnd_RequiresReplace(t *testing.T) {
 
 	partnerWith := func(t *testing.T, name string) types.Object {
 		t.Helper()
-		partnerType := types.ObjectType{AttrTypes: vxcPartnerConfigAttrs}
+		partnerType := types.ObjectType{AttrTypes: vxcAEndPartnerConfigAttrs}
 		raw, ok := partnerType.TerraformType(ctx).(tftypes.Object)
 		if !ok {
 			t.Fatal("partner config type is not tftypes.Object")
@@ -4851,6 +4858,7 @@ func TestReconcileVXCEnd_RequiresReplace(t *testing.T) {
 		obj, d := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, vxcEndConfigurationModel{
 			RequestedProductUID: types.StringValue("port-x"),
 			CurrentProductUID:   types.StringValue("port-x"),
+			PartnerPortSelector: types.ObjectNull(vxcPartnerPortSelectorAttrs),
 		})
 		if d.HasError() {
 			t.Fatalf("build end object: %v", d.Errors())
@@ -4873,7 +4881,7 @@ func TestReconcileVXCEnd_RequiresReplace(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			statePartner := types.ObjectNull(vxcPartnerConfigAttrs)
+			statePartner := types.ObjectNull(vxcAEndPartnerConfigAttrs)
 			if !tc.nullState {
 				statePartner = partnerWith(t, tc.statePartner)
 			}
@@ -5005,3 +5013,227 @@ func TestAccMegaportVXC_IPsecTunnel(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitMegaportVXC_Basic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping lifecycle test in short mode")
+	}
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	config := func(name string, rateLimit, bEnd, bEndVLAN int) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_port" "port" {
+			count                  = 3
+			product_name           = "Unit Test Port ${count.index}"
+			port_speed             = 1000
+			location_id            = %d
+			contract_term_months   = 12
+			marketplace_visibility = false
+		}
+		resource "megaport_vxc" "vxc" {
+			product_name         = %q
+			rate_limit           = %d
+			contract_term_months = 12
+			resource_tags = {
+				"key1" = "value1"
+			}
+			a_end = {
+				requested_product_uid = megaport_port.port[0].product_uid
+				ordered_vlan          = 100
+				inner_vlan            = 300
+			}
+			b_end = {
+				requested_product_uid = megaport_port.port[%d].product_uid
+				ordered_vlan          = %d
+			}
+		}`, fakeLocationID, name, rateLimit, bEnd, bEndVLAN)
+	}
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		CheckDestroy:             api.checkDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Unit Test VXC", 500, 1, 101),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "product_name", "Unit Test VXC"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "rate_limit", "500"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "a_end.vlan", "100"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "a_end.inner_vlan", "300"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "b_end.vlan", "101"),
+					resource.TestCheckResourceAttrPair("megaport_vxc.vxc", "a_end.current_product_uid", "megaport_port.port.0", "product_uid"),
+					resource.TestCheckResourceAttrPair("megaport_vxc.vxc", "b_end.current_product_uid", "megaport_port.port.1", "product_uid"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "resource_tags.key1", "value1"),
+				),
+			},
+			{
+				ResourceName:                         "megaport_vxc.vxc",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "product_uid",
+				ImportStateIdFunc:                    importStateAttr("megaport_vxc.vxc", "product_uid"),
+				ImportStateVerifyIgnore:              []string{"last_updated", "a_end.ordered_vlan", "b_end.ordered_vlan", "a_end.requested_product_uid", "b_end.requested_product_uid", "a_end_partner_config", "b_end_partner_config", "contract_start_date", "contract_end_date", "live_date", "resources", "provisioning_status"},
+			},
+			// Rename, resize and move the B-End in a single update.
+			{
+				Config: config("Unit Test VXC Renamed", 200, 2, 102),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "product_name", "Unit Test VXC Renamed"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "rate_limit", "200"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "b_end.vlan", "102"),
+					resource.TestCheckResourceAttrPair("megaport_vxc.vxc", "b_end.current_product_uid", "megaport_port.port.2", "product_uid"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMegaportVXC_UpdateSendsOnlyChangedFields(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	aEnd := api.seedPort("Changed Fields A-End")
+	bEnd := api.seedPort("Changed Fields B-End")
+	config := func(rateLimit int, costCentre string, shutdown bool) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_vxc" "vxc" {
+			product_name         = "Changed Fields VXC"
+			rate_limit           = %d
+			contract_term_months = 12
+			cost_centre          = %q
+			shutdown             = %t
+			a_end = {
+				requested_product_uid = %q
+				ordered_vlan          = 100
+			}
+			b_end = {
+				requested_product_uid = %q
+				ordered_vlan          = 200
+			}
+		}`, rateLimit, costCentre, shutdown, aEnd, bEnd)
+	}
+	// sent checks that the VXC has had n PUTs and that the last one set only
+	// the fields in want.
+	sent := func(n int, want map[string]any) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			uid := s.RootModule().Resources["megaport_vxc.vxc"].Primary.Attributes["product_uid"]
+			api.mu.Lock()
+			defer api.mu.Unlock()
+			updates := api.products[uid].updates
+			if len(updates) != n {
+				return fmt.Errorf("expected %d updates, got %d: %v", n, len(updates), updates)
+			}
+			if got := updates[n-1]; !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("expected the update to send %v, got %v", want, got)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config(100, "cc-1", false),
+			},
+			{
+				Config: config(200, "cc-1", false),
+				Check:  sent(1, map[string]any{"rateLimit": float64(200)}),
+			},
+			{
+				Config: config(200, "cc-2", false),
+				Check:  sent(2, map[string]any{"costCentre": "cc-2"}),
+			},
+			{
+				Config: config(200, "cc-2", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					sent(3, map[string]any{"shutdown": true}),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", "shutdown", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitMegaportVXC_WriteOnlyBGPPassword(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("Write-Only MCR")
+	port := api.seedPort("Write-Only Port")
+	config := func(password string, version int) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_vxc" "vxc" {
+			product_name         = "Write-Only VXC"
+			rate_limit           = 100
+			contract_term_months = 12
+			a_end = {
+				requested_product_uid = %q
+				ordered_vlan          = 100
+			}
+			a_end_partner_config = {
+				partner = "vrouter"
+				vrouter_config = {
+					interfaces = [{
+						ip_addresses = ["10.0.0.1/30"]
+						bgp_connections = [{
+							peer_asn         = 64512
+							local_ip_address = "10.0.0.1"
+							peer_ip_address  = "10.0.0.2"
+							password         = %q
+							password_version = %d
+						}]
+					}]
+				}
+			}
+			b_end = {
+				requested_product_uid = %q
+				ordered_vlan          = 200
+			}
+		}`, mcr, password, version, port)
+	}
+	connection := "a_end_partner_config.vrouter_config.interfaces.0.bgp_connections.0."
+	// sentPassword checks the BGP password the fake API last received.
+	sentPassword := func(want string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			uid := s.RootModule().Resources["megaport_vxc.vxc"].Primary.Attributes["product_uid"]
+			api.mu.Lock()
+			defer api.mu.Unlock()
+			peers := api.products[uid].bgpPeers[mcr]
+			if len(peers) != 1 || peers[0]["password"] != want {
+				return fmt.Errorf("expected the API to receive BGP password %q, got %v", want, peers)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("first-secret", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("megaport_vxc.vxc", connection+"password"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", connection+"password_version", "1"),
+					sentPassword("first-secret"),
+				),
+			},
+			// A new password alone is not visible to Terraform...
+			{
+				Config:   config("second-secret", 1),
+				PlanOnly: true,
+			},
+			// ...so rotating it needs a new password_version.
+			{
+				Config: config("second-secret", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("megaport_vxc.vxc", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("megaport_vxc.vxc", connection+"password"),
+					resource.TestCheckResourceAttr("megaport_vxc.vxc", connection+"password_version", "2"),
+					sentPassword("second-secret"),
+				),
+			},
+		},
+	})
+}
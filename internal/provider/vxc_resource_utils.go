@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -103,11 +104,14 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 	var aEndInnerVLAN, bEndInnerVLAN *int64
 	var aEndVnicIndex, bEndVnicIndex *int64
 	var aEndRequestedProductUID, bEndRequestedProductUID string
+	// The partner port selector is only configuration, so it is carried over
+	// from the plan, or from state when refreshing.
+	bEndSelector := types.ObjectNull(vxcPartnerPortSelectorAttrs)
 
 	// First, try to get values from existing state
 	if !orm.AEndConfiguration.IsNull() {
 		existingAEnd := &vxcEndConfigurationModel{}
-		aEndDiags := orm.AEndConfiguration.As(ctx, existingAEnd, basetypes.ObjectAsOptions{})
+		aEndDiags := endConfigurationAs(ctx, orm.AEndConfiguration, existingAEnd, basetypes.ObjectAsOptions{})
 		apiDiags = append(apiDiags, aEndDiags...)
 		aEndRequestedProductUID = existingAEnd.RequestedProductUID.ValueString()
 		if !existingAEnd.OrderedVLAN.IsNull() && !existingAEnd.OrderedVLAN.IsUnknown() {
@@ -131,7 +135,7 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 	// the Create case where the API may not yet reflect the vnic_index.
 	if plan != nil && !plan.AEndConfiguration.IsNull() {
 		planAEnd := &vxcEndConfigurationModel{}
-		planDiags := plan.AEndConfiguration.As(ctx, planAEnd, basetypes.ObjectAsOptions{})
+		planDiags := endConfigurationAs(ctx, plan.AEndConfiguration, planAEnd, basetypes.ObjectAsOptions{})
 		apiDiags = append(apiDiags, planDiags...)
 
 		if aEndRequestedProductUID == "" && !planAEnd.RequestedProductUID.IsNull() {
@@ -160,6 +164,7 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 		Location:              types.StringValue(v.AEndConfiguration.Location),
 		NetworkInterfaceIndex: types.Int64Value(int64(v.AEndConfiguration.NetworkInterfaceIndex)),
 		SecondaryName:         types.StringValue(v.AEndConfiguration.SecondaryName),
+		PartnerPortSelector:   types.ObjectNull(vxcPartnerPortSelectorAttrs),
 	}
 	if aEndVnicIndex != nil {
 		aEndModel.NetworkInterfaceIndex = types.Int64Value(*aEndVnicIndex)
@@ -188,7 +193,7 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 	}
 	aEnd, aEndDiags := types.ObjectValueFrom(ctx, vxcEndConfigurationAttrs, aEndModel)
 	apiDiags = append(apiDiags, aEndDiags...)
-	orm.AEndConfiguration = aEnd
+	orm.AEndConfiguration = aEndConfigurationValue(aEnd)
 
 	// First, try to get B-End values from existing state
 	if !orm.BEndConfiguration.IsNull() {
@@ -210,6 +215,9 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 			bEndVnicIndex = &idx
 		}
 		bEndRequestedProductUID = existingBEnd.RequestedProductUID.ValueString()
+		if !existingBEnd.PartnerPortSelector.IsUnknown() && !existingBEnd.PartnerPortSelector.IsNull() {
+			bEndSelector = existingBEnd.PartnerPortSelector
+		}
 	}
 
 	// If plan is provided and state values are empty, use plan values for B-End.
@@ -221,6 +229,9 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 		if bEndRequestedProductUID == "" && !planBEnd.RequestedProductUID.IsNull() {
 			bEndRequestedProductUID = planBEnd.RequestedProductUID.ValueString()
 		}
+		if !planBEnd.PartnerPortSelector.IsUnknown() {
+			bEndSelector = planBEnd.PartnerPortSelector
+		}
 		if bEndOrderedVLAN == nil && !planBEnd.OrderedVLAN.IsNull() && !planBEnd.OrderedVLAN.IsUnknown() {
 			vlan := planBEnd.OrderedVLAN.ValueInt64()
 			bEndOrderedVLAN = &vlan
@@ -244,6 +255,7 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 		Location:              types.StringValue(v.BEndConfiguration.Location),
 		NetworkInterfaceIndex: types.Int64Value(int64(v.BEndConfiguration.NetworkInterfaceIndex)),
 		SecondaryName:         types.StringValue(v.BEndConfiguration.SecondaryName),
+		PartnerPortSelector:   bEndSelector,
 	}
 	if bEndVnicIndex != nil {
 		bEndModel.NetworkInterfaceIndex = types.Int64Value(*bEndVnicIndex)
@@ -320,7 +332,10 @@ func (orm *vxcResourceModel) fromAPIVXC(ctx context.Context, v *megaport.VXC, ta
 
 // These functions are used for partner configurations for ordering VXC Resources through the Megaport API.
 
-func createAWSPartnerConfig(ctx context.Context, awsConfig vxcPartnerConfigAWSModel) (diag.Diagnostics, *megaport.VXCPartnerConfigAWS, basetypes.ObjectValue) {
+// createAWSPartnerConfig builds the AWS partner configuration to order. The
+// auth key is write-only, so it is passed in from the configuration rather
+// than read from awsConfig.
+func createAWSPartnerConfig(ctx context.Context, awsConfig vxcPartnerConfigAWSModel, authKey string) (diag.Diagnostics, *megaport.VXCPartnerConfigAWS, basetypes.ObjectValue) {
 	diags := diag.Diagnostics{}
 	partnerConfig := &megaport.VXCPartnerConfigAWS{
 		ConnectType:       awsConfig.ConnectType.ValueString(),
@@ -328,7 +343,7 @@ func createAWSPartnerConfig(ctx context.Context, awsConfig vxcPartnerConfigAWSMo
 		OwnerAccount:      awsConfig.OwnerAccount.ValueString(),
 		ASN:               int(awsConfig.ASN.ValueInt64()),
 		AmazonASN:         int(awsConfig.AmazonASN.ValueInt64()),
-		AuthKey:           awsConfig.AuthKey.ValueString(),
+		AuthKey:           authKey,
 		Prefixes:          awsConfig.Prefixes.ValueString(),
 		CustomerIPAddress: awsConfig.CustomerIPAddress.ValueString(),
 		AmazonIPAddress:   awsConfig.AmazonIPAddress.ValueString(),
@@ -343,6 +358,7 @@ func createAWSPartnerConfig(ctx context.Context, awsConfig vxcPartnerConfigAWSMo
 	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
 	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
 	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 	partnerConfigModel := &vxcPartnerConfigurationModel{
 		Partner:              types.StringValue("aws"),
 		AWSPartnerConfig:     awsConfigObj,
@@ -352,6 +368,7 @@ func createAWSPartnerConfig(ctx context.Context, awsConfig vxcPartnerConfigAWSMo
 		VrouterPartnerConfig: vrouter,
 		PartnerAEndConfig:    aEndPartner,
 		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: generic,
 	}
 
 	partnerConfigObj, partnerDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigAttrs, partnerConfigModel)
@@ -399,6 +416,7 @@ func createAzurePartnerConfig(ctx context.Context, azureConfig vxcPartnerConfigA
 	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
 	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
 	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 	partnerConfigModel := &vxcPartnerConfigurationModel{
 		Partner:              types.StringValue("azure"),
 		AWSPartnerConfig:     aws,
@@ -408,6 +426,7 @@ func createAzurePartnerConfig(ctx context.Context, azureConfig vxcPartnerConfigA
 		VrouterPartnerConfig: vrouter,
 		PartnerAEndConfig:    aEndPartner,
 		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: generic,
 	}
 
 	partnerConfigObj, partnerDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigAttrs, partnerConfigModel)
@@ -429,6 +448,7 @@ func createGooglePartnerConfig(ctx context.Context, googleConfig vxcPartnerConfi
 	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
 	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
 	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 	aEndPartnerConfigModel := &vxcPartnerConfigurationModel{
 		Partner:              types.StringValue("google"),
 		AWSPartnerConfig:     aws,
@@ -437,6 +457,7 @@ func createGooglePartnerConfig(ctx context.Context, googleConfig vxcPartnerConfi
 		OraclePartnerConfig:  oracle,
 		VrouterPartnerConfig: vrouter,
 		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: generic,
 		PartnerAEndConfig:    aEndPartner,
 	}
 
@@ -460,6 +481,7 @@ func createOraclePartnerConfig(ctx context.Context, oracleConfig vxcPartnerConfi
 	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
 	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
 	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 	bEndPartnerConfigModel := &vxcPartnerConfigurationModel{
 		Partner:              types.StringValue("oracle"),
 		AWSPartnerConfig:     aws,
@@ -467,6 +489,7 @@ func createOraclePartnerConfig(ctx context.Context, oracleConfig vxcPartnerConfi
 		GooglePartnerConfig:  google,
 		OraclePartnerConfig:  oracleConfigObj,
 		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: generic,
 		VrouterPartnerConfig: vrouter,
 		PartnerAEndConfig:    aEndPartner,
 	}
@@ -492,6 +515,7 @@ func createIBMPartnerConfig(ctx context.Context, ibmConfig vxcPartnerConfigIbmMo
 	oracle := types.ObjectNull(vxcPartnerConfigOracleAttrs)
 	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
 	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 	ibmParnterConfigObj, ibmDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigIbmAttrs, ibmConfig)
 	diags.Append(ibmDiags...)
 	aEndPartnerConfigModel := &vxcPartnerConfigurationModel{
@@ -503,12 +527,87 @@ func createIBMPartnerConfig(ctx context.Context, ibmConfig vxcPartnerConfigIbmMo
 		VrouterPartnerConfig: vrouter,
 		PartnerAEndConfig:    aEndPartner,
 		IBMPartnerConfig:     ibmParnterConfigObj,
+		GenericPartnerConfig: generic,
 	}
 	partnerConfigObj, partnerDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigAttrs, aEndPartnerConfigModel)
 	diags.Append(partnerDiags...)
 	return diags, ibmPartnerConfig, partnerConfigObj
 }
 
+// genericVXCPartnerConfig is the partner configuration ordered for a
+// generic_config, sent to the API as the fields it was built from.
+type genericVXCPartnerConfig struct {
+	megaport.VXCPartnerConfiguration `json:"-"`
+	Fields                           map[string]any
+}
+
+func (c genericVXCPartnerConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Fields)
+}
+
+func createGenericPartnerConfig(ctx context.Context, genericConfig vxcPartnerConfigGenericModel) (diag.Diagnostics, genericVXCPartnerConfig, basetypes.ObjectValue) {
+	diags := diag.Diagnostics{}
+	fields := map[string]any{}
+	attributes := map[string]string{}
+	diags.Append(genericConfig.Attributes.ElementsAs(ctx, &attributes, false)...)
+	for k, v := range attributes {
+		fields[k] = v
+	}
+	// The named fields take precedence over the same keys in attributes.
+	fields["connectType"] = genericConfig.ConnectType.ValueString()
+	if !genericConfig.PairingKey.IsNull() {
+		keyField := "pairingKey"
+		if !genericConfig.PairingKeyField.IsNull() {
+			keyField = genericConfig.PairingKeyField.ValueString()
+		}
+		fields[keyField] = genericConfig.PairingKey.ValueString()
+	}
+
+	peerModels := []partnerOrderAzurePeeringConfigModel{}
+	diags.Append(genericConfig.Peers.ElementsAs(ctx, &peerModels, false)...)
+	if len(peerModels) > 0 {
+		peers := []megaport.PartnerOrderAzurePeeringConfig{}
+		for _, peer := range peerModels {
+			peers = append(peers, megaport.PartnerOrderAzurePeeringConfig{
+				Type:            peer.Type.ValueString(),
+				PeerASN:         peer.PeerASN.ValueString(),
+				PrimarySubnet:   peer.PrimarySubnet.ValueString(),
+				SecondarySubnet: peer.SecondarySubnet.ValueString(),
+				Prefixes:        peer.Prefixes.ValueString(),
+				SharedKey:       peer.SharedKey.ValueString(),
+				VLAN:            int(peer.VLAN.ValueInt64()),
+			})
+		}
+		fields["peers"] = peers
+	}
+
+	genericConfigObj, genericDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigGenericAttrs, genericConfig)
+	diags.Append(genericDiags...)
+
+	aws := types.ObjectNull(vxcPartnerConfigAWSAttrs)
+	azure := types.ObjectNull(vxcPartnerConfigAzureAttrs)
+	google := types.ObjectNull(vxcPartnerConfigGoogleAttrs)
+	oracle := types.ObjectNull(vxcPartnerConfigOracleAttrs)
+	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
+	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
+	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	partnerConfigModel := &vxcPartnerConfigurationModel{
+		Partner:              types.StringValue("generic"),
+		AWSPartnerConfig:     aws,
+		AzurePartnerConfig:   azure,
+		GooglePartnerConfig:  google,
+		OraclePartnerConfig:  oracle,
+		VrouterPartnerConfig: vrouter,
+		PartnerAEndConfig:    aEndPartner,
+		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: genericConfigObj,
+	}
+
+	partnerConfigObj, partnerDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigAttrs, partnerConfigModel)
+	diags.Append(partnerDiags...)
+	return diags, genericVXCPartnerConfig{Fields: fields}, partnerConfigObj
+}
+
 // ipSecPreSharedKeysFromConfig reads the write-only pre_shared_key for each
 // vrouter interface from the configuration, keyed by interface index. The PSK is
 // a write-only argument, so it is null in the plan and must be sourced from
@@ -545,7 +644,38 @@ func ipSecPreSharedKeysFromConfig(ctx context.Context, config tfsdk.Config, path
 	return psks, diags
 }
 
-func createVrouterPartnerConfig(ctx context.Context, vrouterConfig vxcPartnerConfigVrouterModel, prefixFilterList []*megaport.PrefixFilterList, preSharedKeys map[int]string) (diag.Diagnostics, *megaport.VXCOrderVrouterPartnerConfig, basetypes.ObjectValue) {
+// bgpPasswordsFromConfig reads the write-only password of each BGP connection
+// of the interfaces at interfacesPath from the configuration, keyed by
+// interface and connection index. Write-only values are always null in the
+// plan, so the configuration is the only place to find them.
+func bgpPasswordsFromConfig(ctx context.Context, config tfsdk.Config, interfacesPath path.Path, ifaceCount int) (map[[2]int]string, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+	passwords := map[[2]int]string{}
+	for i := 0; i < ifaceCount; i++ {
+		connectionsPath := interfacesPath.AtListIndex(i).AtName("bgp_connections")
+		var connections types.List
+		diags.Append(config.GetAttribute(ctx, connectionsPath, &connections)...)
+		if diags.HasError() {
+			return passwords, diags
+		}
+		if connections.IsNull() || connections.IsUnknown() {
+			continue
+		}
+		for j := range connections.Elements() {
+			var password types.String
+			diags.Append(config.GetAttribute(ctx, connectionsPath.AtListIndex(j).AtName("password"), &password)...)
+			if diags.HasError() {
+				return passwords, diags
+			}
+			if !password.IsNull() && !password.IsUnknown() {
+				passwords[[2]int{i, j}] = password.ValueString()
+			}
+		}
+	}
+	return passwords, diags
+}
+
+func createVrouterPartnerConfig(ctx context.Context, vrouterConfig vxcPartnerConfigVrouterModel, prefixFilterList []*megaport.PrefixFilterList, preSharedKeys map[int]string, bgpPasswords map[[2]int]string) (diag.Diagnostics, *megaport.VXCOrderVrouterPartnerConfig, basetypes.ObjectValue) {
 	diags := diag.Diagnostics{}
 	vrouterPartnerConfig := &megaport.VXCOrderVrouterPartnerConfig{}
 	ifaceModels := []*vxcPartnerConfigInterfaceModel{}
@@ -609,12 +739,12 @@ func createVrouterPartnerConfig(ctx context.Context, vrouterConfig vxcPartnerCon
 			bgpConnections := []*bgpConnectionConfigModel{}
 			bgpDiags := iface.BgpConnections.ElementsAs(ctx, &bgpConnections, false)
 			diags.Append(bgpDiags...)
-			for _, bgpConnection := range bgpConnections {
+			for j, bgpConnection := range bgpConnections {
 				bgpToAppend := megaport.BgpConnectionConfig{
 					PeerAsn:            int(bgpConnection.PeerAsn.ValueInt64()),
 					LocalIpAddress:     bgpConnection.LocalIPAddress.ValueString(),
 					PeerIpAddress:      bgpConnection.PeerIPAddress.ValueString(),
-					Password:           bgpConnection.Password.ValueString(),
+					Password:           bgpPasswords[[2]int{i, j}],
 					Shutdown:           bgpConnection.Shutdown.ValueBool(),
 					Description:        bgpConnection.Description.ValueString(),
 					MedIn:              int(bgpConnection.MedIn.ValueInt64()),
@@ -700,6 +830,7 @@ func createVrouterPartnerConfig(ctx context.Context, vrouterConfig vxcPartnerCon
 	oracle := types.ObjectNull(vxcPartnerConfigOracleAttrs)
 	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
 	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 	partnerConfigModel := &vxcPartnerConfigurationModel{
 		Partner:              types.StringValue("vrouter"),
 		AWSPartnerConfig:     aws,
@@ -707,6 +838,7 @@ func createVrouterPartnerConfig(ctx context.Context, vrouterConfig vxcPartnerCon
 		GooglePartnerConfig:  google,
 		OraclePartnerConfig:  oracle,
 		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: generic,
 		VrouterPartnerConfig: vrouterConfigObj,
 		PartnerAEndConfig:    aEndPartner,
 	}
@@ -715,13 +847,13 @@ func createVrouterPartnerConfig(ctx context.Context, vrouterConfig vxcPartnerCon
 	return diags, vrouterPartnerConfig, partnerConfigObj
 }
 
-func createAEndPartnerConfig(ctx context.Context, partnerConfigAEndModel vxcPartnerConfigAEndModel, prefixFilterList []*megaport.PrefixFilterList) (diag.Diagnostics, *megaport.VXCOrderVrouterPartnerConfig, basetypes.ObjectValue) {
+func createAEndPartnerConfig(ctx context.Context, partnerConfigAEndModel vxcPartnerConfigAEndModel, prefixFilterList []*megaport.PrefixFilterList, bgpPasswords map[[2]int]string) (diag.Diagnostics, *megaport.VXCOrderVrouterPartnerConfig, basetypes.ObjectValue) {
 	diags := diag.Diagnostics{}
 	aEndMegaportConfig := &megaport.VXCOrderVrouterPartnerConfig{}
 	ifaceModels := []*vxcPartnerConfigInterfaceModel{}
 	ifaceDiags := partnerConfigAEndModel.Interfaces.ElementsAs(ctx, &ifaceModels, true)
 	diags.Append(ifaceDiags...)
-	for _, iface := range ifaceModels {
+	for i, iface := range ifaceModels {
 		toAppend := megaport.PartnerConfigInterface{}
 		if !iface.IpMtu.IsNull() {
 			toAppend.IpMtu = int(iface.IpMtu.ValueInt64())
@@ -764,12 +896,12 @@ func createAEndPartnerConfig(ctx context.Context, partnerConfigAEndModel vxcPart
 			bgpConnections := []*bgpConnectionConfigModel{}
 			bgpDiags := iface.BgpConnections.ElementsAs(ctx, &bgpConnections, false)
 			diags.Append(bgpDiags...)
-			for _, bgpConnection := range bgpConnections {
+			for j, bgpConnection := range bgpConnections {
 				bgpToAppend := megaport.BgpConnectionConfig{
 					PeerAsn:            int(bgpConnection.PeerAsn.ValueInt64()),
 					LocalIpAddress:     bgpConnection.LocalIPAddress.ValueString(),
 					PeerIpAddress:      bgpConnection.PeerIPAddress.ValueString(),
-					Password:           bgpConnection.Password.ValueString(),
+					Password:           bgpPasswords[[2]int{i, j}],
 					Shutdown:           bgpConnection.Shutdown.ValueBool(),
 					Description:        bgpConnection.Description.ValueString(),
 					MedIn:              int(bgpConnection.MedIn.ValueInt64()),
@@ -829,6 +961,7 @@ func createAEndPartnerConfig(ctx context.Context, partnerConfigAEndModel vxcPart
 	oracle := types.ObjectNull(vxcPartnerConfigOracleAttrs)
 	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
 	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 	aEndPartnerConfigModel := &vxcPartnerConfigurationModel{
 		Partner:              types.StringValue("a-end"),
 		AWSPartnerConfig:     aws,
@@ -838,6 +971,7 @@ func createAEndPartnerConfig(ctx context.Context, partnerConfigAEndModel vxcPart
 		PartnerAEndConfig:    aEndConfigObj,
 		VrouterPartnerConfig: vrouter,
 		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: generic,
 	}
 	partnerConfigObj, partnerDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigAttrs, aEndPartnerConfigModel)
 	diags.Append(partnerDiags...)
@@ -857,6 +991,7 @@ func createTransitPartnerConfig(ctx context.Context) (diag.Diagnostics, megaport
 	vrouter := types.ObjectNull(vxcPartnerConfigVrouterAttrs)
 	aEndPartner := types.ObjectNull(vxcPartnerConfigAEndAttrs)
 	ibmPartner := types.ObjectNull(vxcPartnerConfigIbmAttrs)
+	generic := types.ObjectNull(vxcPartnerConfigGenericAttrs)
 
 	transitPartnerConfigModel := &vxcPartnerConfigurationModel{
 		Partner:              types.StringValue("transit"),
@@ -867,6 +1002,7 @@ func createTransitPartnerConfig(ctx context.Context) (diag.Diagnostics, megaport
 		VrouterPartnerConfig: vrouter,
 		PartnerAEndConfig:    aEndPartner,
 		IBMPartnerConfig:     ibmPartner,
+		GenericPartnerConfig: generic,
 	}
 
 	transitConfigObj, transitDiags := types.ObjectValueFrom(ctx, vxcPartnerConfigAttrs, transitPartnerConfigModel)
@@ -897,17 +1033,20 @@ func supportVLANUpdates(partnerType string) bool {
 //   - The context is cancelled
 //   - The timeout is reached before the update is verified
 func (r *vxcResource) waitForVXCUpdate(ctx context.Context, uid string, updateReq *megaport.UpdateVXCRequest, timeout time.Duration) error {
+	// The delays are capped at the poll interval, which unit tests shorten.
+	pollInterval := pollIntervalOrDefault(r.pollInterval)
 	deadline := time.Now().Add(timeout)
-	backoff := 2 * time.Second
-	maxBackoff := 10 * time.Second
+	backoff := min(2*time.Second, pollInterval)
+	maxBackoff := min(10*time.Second, pollInterval)
 
 	// Add initial delay before first check to allow for quick propagation
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-time.After(1 * time.Second):
+	case <-time.After(min(1*time.Second, pollInterval)):
 	}
 
+	var unapplied []vxcFieldMismatch
 	for time.Now().Before(deadline) {
 		vxc, err := r.client.VXCService.GetVXC(ctx, uid)
 		if err != nil {
@@ -915,7 +1054,8 @@ func (r *vxcResource) waitForVXCUpdate(ctx context.Context, uid string, updateRe
 		}
 
 		// Verify the expected changes are reflected
-		if r.verifyUpdateApplied(vxc, updateReq) {
+		unapplied = unappliedVXCUpdateFields(vxc, updateReq)
+		if len(unapplied) == 0 {
 			return nil
 		}
 
@@ -930,7 +1070,25 @@ func (r *vxcResource) waitForVXCUpdate(ctx context.Context, uid string, updateRe
 		}
 	}
 
-	return fmt.Errorf("update verification timed out after %v", timeout)
+	if len(unapplied) == 0 {
+		return fmt.Errorf("update verification timed out after %v", timeout)
+	}
+	return &vxcUpdateTimeoutError{timeout: timeout, unapplied: unapplied}
+}
+
+// vxcUpdateTimeoutError is returned by waitForVXCUpdate when the fields of an
+// update have not all been read back from the API before the timeout.
+type vxcUpdateTimeoutError struct {
+	timeout   time.Duration
+	unapplied []vxcFieldMismatch
+}
+
+func (e *vxcUpdateTimeoutError) Error() string {
+	fields := make([]string, len(e.unapplied))
+	for i, m := range e.unapplied {
+		fields[i] = m.String()
+	}
+	return fmt.Sprintf("update verification timed out after %v, fields not applied: %s", e.timeout, strings.Join(fields, ", "))
 }
 
 // innerVLANMatches compares a requested inner VLAN to the value returned by the API,
@@ -943,67 +1101,61 @@ func innerVLANMatches(requested, actual int) bool {
 	return requested == actual
 }
 
-// verifyUpdateApplied checks if the VXC returned from the API matches the expected values
-// from the update request. It verifies all fields that can be updated.
-//
-// Parameters:
-//   - vxc: The VXC object retrieved from the API
-//   - updateReq: The update request containing the expected values
+// vxcFieldMismatch is an updated VXC attribute whose value read back from the
+// API does not yet match the value sent in the update request.
+type vxcFieldMismatch struct {
+	attribute path.Path
+	requested any
+	observed  any
+}
+
+func (m vxcFieldMismatch) String() string {
+	return fmt.Sprintf("%s (requested %v, observed %v)", m.attribute, m.requested, m.observed)
+}
+
+// unappliedVXCUpdateFields compares the VXC returned from the API with the
+// fields set in the update request and returns the ones that do not match yet,
+// named after their resource attributes.
 //
-// Returns true if all updated fields match their expected values, false otherwise.
-func (r *vxcResource) verifyUpdateApplied(vxc *megaport.VXC, updateReq *megaport.UpdateVXCRequest) bool {
-	// Verify VLAN-related fields
-	if updateReq.AEndInnerVLAN != nil && !innerVLANMatches(*updateReq.AEndInnerVLAN, vxc.AEndConfiguration.InnerVLAN) {
-		return false
-	}
-	if updateReq.BEndInnerVLAN != nil && !innerVLANMatches(*updateReq.BEndInnerVLAN, vxc.BEndConfiguration.InnerVLAN) {
-		return false
-	}
-	if updateReq.AEndVLAN != nil && vxc.AEndConfiguration.VLAN != *updateReq.AEndVLAN {
-		return false
-	}
-	if updateReq.BEndVLAN != nil && vxc.BEndConfiguration.VLAN != *updateReq.BEndVLAN {
-		return false
+// Note: Partner configs (AEndPartnerConfig, BEndPartnerConfig) are complex objects
+// and their verification would require deep comparison. For now, we focus on the
+// simpler scalar fields that are more prone to propagation delays.
+func unappliedVXCUpdateFields(vxc *megaport.VXC, updateReq *megaport.UpdateVXCRequest) []vxcFieldMismatch {
+	var mismatches []vxcFieldMismatch
+	check := func(attribute path.Path, requested *int, observed int, matches func(requested, observed int) bool) {
+		if requested != nil && !matches(*requested, observed) {
+			mismatches = append(mismatches, vxcFieldMismatch{attribute, *requested, observed})
+		}
 	}
+	equal := func(requested, observed int) bool { return requested == observed }
 
-	// Verify basic VXC properties
 	if updateReq.Name != nil && vxc.Name != *updateReq.Name {
-		return false
-	}
-	if updateReq.RateLimit != nil && vxc.RateLimit != *updateReq.RateLimit {
-		return false
-	}
-	if updateReq.CostCentre != nil && vxc.CostCentre != *updateReq.CostCentre {
-		return false
+		mismatches = append(mismatches, vxcFieldMismatch{path.Root("product_name"), fmt.Sprintf("%q", *updateReq.Name), fmt.Sprintf("%q", vxc.Name)})
 	}
+	check(path.Root("rate_limit"), updateReq.RateLimit, vxc.RateLimit, equal)
+	check(path.Root("contract_term_months"), updateReq.Term, vxc.ContractTermMonths, equal)
 	if updateReq.Shutdown != nil && vxc.Shutdown != *updateReq.Shutdown {
-		return false
+		mismatches = append(mismatches, vxcFieldMismatch{path.Root("shutdown"), *updateReq.Shutdown, vxc.Shutdown})
 	}
-	if updateReq.Term != nil && vxc.ContractTermMonths != *updateReq.Term {
-		return false
+	if updateReq.CostCentre != nil && vxc.CostCentre != *updateReq.CostCentre {
+		mismatches = append(mismatches, vxcFieldMismatch{path.Root("cost_centre"), fmt.Sprintf("%q", *updateReq.CostCentre), fmt.Sprintf("%q", vxc.CostCentre)})
 	}
 
-	// Verify endpoint product UIDs
+	check(path.Root("a_end").AtName("ordered_vlan"), updateReq.AEndVLAN, vxc.AEndConfiguration.VLAN, equal)
+	check(path.Root("a_end").AtName("inner_vlan"), updateReq.AEndInnerVLAN, vxc.AEndConfiguration.InnerVLAN, innerVLANMatches)
+	check(path.Root("a_end").AtName("vnic_index"), updateReq.AVnicIndex, vxc.AEndConfiguration.NetworkInterfaceIndex, equal)
 	if updateReq.AEndProductUID != nil && vxc.AEndConfiguration.UID != *updateReq.AEndProductUID {
-		return false
-	}
-	if updateReq.BEndProductUID != nil && vxc.BEndConfiguration.UID != *updateReq.BEndProductUID {
-		return false
+		mismatches = append(mismatches, vxcFieldMismatch{path.Root("a_end").AtName("requested_product_uid"), *updateReq.AEndProductUID, vxc.AEndConfiguration.UID})
 	}
 
-	// Verify VNIC indices
-	if updateReq.AVnicIndex != nil && vxc.AEndConfiguration.NetworkInterfaceIndex != *updateReq.AVnicIndex {
-		return false
-	}
-	if updateReq.BVnicIndex != nil && vxc.BEndConfiguration.NetworkInterfaceIndex != *updateReq.BVnicIndex {
-		return false
+	check(path.Root("b_end").AtName("ordered_vlan"), updateReq.BEndVLAN, vxc.BEndConfiguration.VLAN, equal)
+	check(path.Root("b_end").AtName("inner_vlan"), updateReq.BEndInnerVLAN, vxc.BEndConfiguration.InnerVLAN, innerVLANMatches)
+	check(path.Root("b_end").AtName("vnic_index"), updateReq.BVnicIndex, vxc.BEndConfiguration.NetworkInterfaceIndex, equal)
+	if updateReq.BEndProductUID != nil && vxc.BEndConfiguration.UID != *updateReq.BEndProductUID {
+		mismatches = append(mismatches, vxcFieldMismatch{path.Root("b_end").AtName("requested_product_uid"), *updateReq.BEndProductUID, vxc.BEndConfiguration.UID})
 	}
 
-	// Note: Partner configs (AEndPartnerConfig, BEndPartnerConfig) are complex objects
-	// and their verification would require deep comparison. For now, we focus on the
-	// simpler scalar fields that are more prone to propagation delays.
-
-	return true
+	return mismatches
 }
 
 // waitForVnicIndex polls the VXC API until the NetworkInterfaceIndex for the
@@ -1017,15 +1169,16 @@ func (r *vxcResource) waitForVnicIndex(ctx context.Context, uid string, expected
 		return r.client.VXCService.GetVXC(ctx, uid)
 	}
 
+	pollInterval := pollIntervalOrDefault(r.pollInterval)
 	deadline := time.Now().Add(timeout)
-	backoff := 2 * time.Second
-	maxBackoff := 10 * time.Second
+	backoff := min(2*time.Second, pollInterval)
+	maxBackoff := min(10*time.Second, pollInterval)
 
 	// Small initial delay to let the API propagate.
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
-	case <-time.After(1 * time.Second):
+	case <-time.After(min(1*time.Second, pollInterval)):
 	}
 
 	for time.Now().Before(deadline) {
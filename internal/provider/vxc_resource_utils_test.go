@@ -75,7 +75,7 @@ func TestCreateVrouterPartnerConfig_IPsecTunnelOptions(t *testing.T) {
 	model := vxcPartnerConfigVrouterModel{Interfaces: ifaceList}
 
 	preSharedKeys := map[int]string{0: "secret-one", 1: "secret-two"}
-	diags, vrouterConfig, _ := createVrouterPartnerConfig(ctx, model, nil, preSharedKeys)
+	diags, vrouterConfig, _ := createVrouterPartnerConfig(ctx, model, nil, preSharedKeys, nil)
 	require.False(t, diags.HasError(), "createVrouterPartnerConfig: %v", diags)
 	require.Len(t, vrouterConfig.Interfaces, 3)
 
@@ -147,7 +147,7 @@ func TestCreateVrouterPartnerConfig_BgpAsOverride(t *testing.T) {
 
 	model := vxcPartnerConfigVrouterModel{Interfaces: ifaceList}
 
-	diags, vrouterConfig, _ := createVrouterPartnerConfig(ctx, model, nil, nil)
+	diags, vrouterConfig, _ := createVrouterPartnerConfig(ctx, model, nil, nil, nil)
 	require.False(t, diags.HasError(), "createVrouterPartnerConfig: %v", diags)
 	require.Len(t, vrouterConfig.Interfaces, 1)
 	conns := vrouterConfig.Interfaces[0].BgpConnections
@@ -212,15 +212,16 @@ func TestIPSecPhaseLifetimeValidator(t *testing.T) {
 	}
 }
 
-// TestVerifyUpdateApplied covers the -1 (requested untagged) / 0 (API
-// returned untagged) inner VLAN normalization, a genuine mismatch that must
-// still fail, and unrelated fields being unaffected by the normalization.
-func TestVerifyUpdateApplied(t *testing.T) {
+// TestUnappliedVXCUpdateFields_InnerVLAN covers the -1 (requested untagged) /
+// 0 (API returned untagged) inner VLAN normalization, a genuine mismatch that
+// must still be reported, and unrelated fields being unaffected by the
+// normalization.
+func TestUnappliedVXCUpdateFields_InnerVLAN(t *testing.T) {
 	cases := []struct {
 		name      string
 		vxc       *megaport.VXC
 		updateReq *megaport.UpdateVXCRequest
-		want      bool
+		want      []string
 	}{
 		{
 			name: "untagged inner VLAN match: requested -1, API returns 0",
@@ -232,7 +233,7 @@ func TestVerifyUpdateApplied(t *testing.T) {
 				AEndInnerVLAN: megaport.PtrTo(-1),
 				BEndInnerVLAN: megaport.PtrTo(-1),
 			},
-			want: true,
+			want: nil,
 		},
 		{
 			name: "genuine inner VLAN mismatch is not masked",
@@ -242,7 +243,7 @@ func TestVerifyUpdateApplied(t *testing.T) {
 			updateReq: &megaport.UpdateVXCRequest{
 				AEndInnerVLAN: megaport.PtrTo(100),
 			},
-			want: false,
+			want: []string{"a_end.inner_vlan (requested 100, observed 200)"},
 		},
 		{
 			name: "requested -1 but API returns a tagged value is a mismatch",
@@ -252,7 +253,7 @@ func TestVerifyUpdateApplied(t *testing.T) {
 			updateReq: &megaport.UpdateVXCRequest{
 				AEndInnerVLAN: megaport.PtrTo(-1),
 			},
-			want: false,
+			want: []string{"a_end.inner_vlan (requested -1, observed 100)"},
 		},
 		{
 			name: "matching non-zero inner VLAN values still verify",
@@ -264,7 +265,7 @@ func TestVerifyUpdateApplied(t *testing.T) {
 				AEndInnerVLAN: megaport.PtrTo(100),
 				BEndInnerVLAN: megaport.PtrTo(200),
 			},
-			want: true,
+			want: nil,
 		},
 		{
 			name: "unrelated fields (name, rate limit) unaffected by normalization",
@@ -280,7 +281,7 @@ func TestVerifyUpdateApplied(t *testing.T) {
 				Name:          megaport.PtrTo("updated-name"),
 				RateLimit:     megaport.PtrTo(500),
 			},
-			want: true,
+			want: nil,
 		},
 		{
 			name: "unrelated field mismatch still fails despite VLAN match",
@@ -294,14 +295,55 @@ func TestVerifyUpdateApplied(t *testing.T) {
 				AEndInnerVLAN: megaport.PtrTo(-1),
 				Name:          megaport.PtrTo("different-name"),
 			},
-			want: false,
+			want: []string{`product_name (requested "different-name", observed "current-name")`},
 		},
 	}
 
-	r := &vxcResource{}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.want, r.verifyUpdateApplied(tc.vxc, tc.updateReq))
+			assert.Equal(t, tc.want, unappliedFields(tc.vxc, tc.updateReq))
 		})
 	}
 }
+
+// unappliedFields returns the unapplied fields of an update, each as its
+// attribute path with the requested and observed values.
+func unappliedFields(vxc *megaport.VXC, updateReq *megaport.UpdateVXCRequest) []string {
+	var fields []string
+	for _, m := range unappliedVXCUpdateFields(vxc, updateReq) {
+		fields = append(fields, m.String())
+	}
+	return fields
+}
+
+func TestUnappliedVXCUpdateFields(t *testing.T) {
+	vxc := &megaport.VXC{
+		Name:               "current-name",
+		RateLimit:          100,
+		ContractTermMonths: 12,
+		Shutdown:           false,
+		AEndConfiguration:  megaport.VXCEndConfiguration{VLAN: 100, InnerVLAN: 0, NetworkInterfaceIndex: 0},
+		BEndConfiguration:  megaport.VXCEndConfiguration{VLAN: 200, InnerVLAN: 300, NetworkInterfaceIndex: 1},
+	}
+	updateReq := &megaport.UpdateVXCRequest{
+		Name:          megaport.PtrTo("new-name"),
+		RateLimit:     megaport.PtrTo(500),
+		Term:          megaport.PtrTo(12),
+		Shutdown:      megaport.PtrTo(true),
+		AEndVLAN:      megaport.PtrTo(100),
+		AEndInnerVLAN: megaport.PtrTo(-1),
+		BEndVLAN:      megaport.PtrTo(201),
+		BEndInnerVLAN: megaport.PtrTo(301),
+		BVnicIndex:    megaport.PtrTo(2),
+	}
+
+	assert.Equal(t, []string{
+		`product_name (requested "new-name", observed "current-name")`,
+		"rate_limit (requested 500, observed 100)",
+		"shutdown (requested true, observed false)",
+		"b_end.ordered_vlan (requested 201, observed 200)",
+		"b_end.inner_vlan (requested 301, observed 300)",
+		"b_end.vnic_index (requested 2, observed 1)",
+	}, unappliedFields(vxc, updateReq))
+	assert.Empty(t, unappliedVXCUpdateFields(vxc, &megaport.UpdateVXCRequest{}))
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"net/http"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	megaport "github.com/megaport/megaportgo"
 )
 
@@ -74,3 +76,132 @@ func TestWaitForVXCProvision_ContextCancelled(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorIs(t, err, context.Canceled)
 }
+
+// The tests below run the wait helpers against the fake API with faults
+// injected, reproducing the propagation delays and partial reads seen in
+// staging.
+
+func TestWaitForVXCUpdate_EventualConsistency(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedVXC("Lagging VXC")
+	reads := &fakeFault{Method: http.MethodGet, Path: "/v2/product/" + uid}
+	api.inject(reads)
+	api.setReadLag(1)
+	api.update(uid, func(data map[string]any) {
+		data["productName"] = "Lagging VXC Renamed"
+		data["rateLimit"] = 500
+	})
+
+	r := &vxcResource{client: api.client(t), pollInterval: 10 * time.Millisecond}
+	err := r.waitForVXCUpdate(context.Background(), uid, &megaport.UpdateVXCRequest{
+		Name:      megaport.PtrTo("Lagging VXC Renamed"),
+		RateLimit: megaport.PtrTo(500),
+	}, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 2, api.hits(reads), "the stale read should be retried once the update propagates")
+}
+
+func TestWaitForVXCUpdate_TransientReadErrors(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedVXC("Flaky VXC")
+	unavailable := &fakeFault{Method: http.MethodGet, Path: "/v2/product/" + uid, Times: 2, Status: http.StatusServiceUnavailable, Message: "Service Unavailable"}
+	api.inject(unavailable)
+	api.update(uid, func(data map[string]any) { data["rateLimit"] = 500 })
+
+	r := &vxcResource{client: api.client(t), pollInterval: 10 * time.Millisecond}
+	err := r.waitForVXCUpdate(context.Background(), uid, &megaport.UpdateVXCRequest{RateLimit: megaport.PtrTo(500)}, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 2, api.hits(unavailable))
+}
+
+func TestWaitForVXCUpdate_NeverConverges(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedVXC("Stubborn VXC")
+	// The API accepts the update but keeps reporting the old rate limit.
+	api.inject(&fakeFault{Method: http.MethodGet, Path: "/v2/product/" + uid, Mutate: func(data map[string]any) {
+		data["rateLimit"] = 100
+	}})
+	api.update(uid, func(data map[string]any) { data["rateLimit"] = 500 })
+
+	r := &vxcResource{client: api.client(t), pollInterval: 10 * time.Millisecond}
+	err := r.waitForVXCUpdate(context.Background(), uid, &megaport.UpdateVXCRequest{
+		Name:      megaport.PtrTo("Stubborn VXC"),
+		RateLimit: megaport.PtrTo(500),
+	}, 200*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	var timeoutErr *vxcUpdateTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	require.Len(t, timeoutErr.unapplied, 1, "only the rate limit should be reported")
+	assert.Equal(t, path.Root("rate_limit"), timeoutErr.unapplied[0].attribute)
+	assert.Contains(t, err.Error(), "rate_limit (requested 500, observed 100)")
+}
+
+func TestWaitForVXCUpdate_ReadFailure(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedVXC("Missing VXC")
+	api.inject(&fakeFault{Method: http.MethodGet, Path: "/v2/product/" + uid, Status: http.StatusInternalServerError, Message: "Internal Server Error"})
+
+	r := &vxcResource{client: api.client(t), pollInterval: 10 * time.Millisecond}
+	err := r.waitForVXCUpdate(context.Background(), uid, &megaport.UpdateVXCRequest{RateLimit: megaport.PtrTo(500)}, 30*time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to retrieve VXC status")
+}
+
+func TestUnappliedVXCUpdateFields_PartialReads(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedVXC("Partial VXC")
+	client := api.client(t)
+	get := func() *megaport.VXC {
+		vxc, err := client.VXCService.GetVXC(context.Background(), uid)
+		require.NoError(t, err)
+		return vxc
+	}
+
+	// An untagged inner VLAN is omitted from the response rather than echoed
+	// back as -1.
+	api.update(uid, func(data map[string]any) { delete(fakeMap(data["aEnd"]), "innerVlan") })
+	assert.Empty(t, unappliedFields(get(), &megaport.UpdateVXCRequest{AEndInnerVLAN: megaport.PtrTo(-1)}))
+
+	// A read missing the B-End cannot confirm a B-End change.
+	fault := &fakeFault{Method: http.MethodGet, Path: "/v2/product/" + uid, Times: 1, Mutate: withoutFields("bEnd")}
+	api.inject(fault)
+	assert.Equal(t, []string{"b_end.ordered_vlan (requested 200, observed 0)"},
+		unappliedFields(get(), &megaport.UpdateVXCRequest{BEndVLAN: megaport.PtrTo(200)}))
+	assert.Empty(t, unappliedFields(get(), &megaport.UpdateVXCRequest{BEndVLAN: megaport.PtrTo(200)}))
+}
+
+func TestWaitForVnicIndex_EventualConsistency(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedVXC("vNIC VXC")
+	api.setReadLag(1)
+	api.update(uid, func(data map[string]any) { fakeMap(data["aEnd"])["vNicIndex"] = 1 })
+
+	r := &vxcResource{client: api.client(t), pollInterval: 10 * time.Millisecond}
+	vxc, err := r.waitForVnicIndex(context.Background(), uid, megaport.PtrTo(1), nil, 30*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 1, vxc.AEndConfiguration.NetworkInterfaceIndex)
+}
+
+func TestWaitForVnicIndex_NeverPropagates(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	uid := api.seedVXC("Stale vNIC VXC")
+	api.inject(&fakeFault{Method: http.MethodGet, Path: "/v2/product/" + uid, Mutate: func(data map[string]any) {
+		fakeMap(data["aEnd"])["vNicIndex"] = 0
+	}})
+	api.update(uid, func(data map[string]any) { fakeMap(data["aEnd"])["vNicIndex"] = 1 })
+
+	r := &vxcResource{client: api.client(t), pollInterval: 10 * time.Millisecond}
+	vxc, err := r.waitForVnicIndex(context.Background(), uid, megaport.PtrTo(1), nil, 200*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+	require.NotNil(t, vxc)
+	assert.Equal(t, 1, vxc.AEndConfiguration.NetworkInterfaceIndex, "the expected index is patched in so state matches the plan")
+}
@@ -2,8 +2,10 @@ package provider
 
 import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -40,9 +42,14 @@ var (
 				Optional:    true,
 			},
 			"auth_key": schema.StringAttribute{
-				Description: "The authentication key of the partner configuration.",
+				Description: "The BGP authentication key of the partner configuration. A write-only argument (Terraform 1.11+), so the key is never stored in the plan or state; it is only read from the configuration when the VXC is ordered. Change `auth_key_version` to order the VXC again with a new key.",
 				Sensitive:   true,
 				Optional:    true,
+				WriteOnly:   true,
+			},
+			"auth_key_version": schema.Int64Attribute{
+				Description: "A version number for `auth_key`. Changing it replaces the VXC, which is the only way to change the key of an AWS connection.",
+				Optional:    true,
 			},
 			"prefixes": schema.StringAttribute{
 				Description: "The prefixes of the partner configuration.",
@@ -154,6 +161,74 @@ var (
 			},
 		},
 	}
+	genericPartnerConfigSchema = schema.SingleNestedAttribute{
+		Description: "The configuration for a cloud partner without a dedicated configuration block, such as Alibaba Cloud or Salesforce. The fields are sent to the Megaport API as they are given, so check the partner's documentation for the ones it requires. Only valid on the B-End, with `partner` set to \"generic\".",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"connect_type": schema.StringAttribute{
+				Description: "The connect type of the partner, as listed by the `megaport_partner` data source (e.g. \"ALIBABA\").",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"pairing_key": schema.StringAttribute{
+				Description: "The pairing or service key issued by the partner. When `requested_product_uid` is not set in the `b_end` block it is also used to look up the partner port to connect to.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"pairing_key_field": schema.StringAttribute{
+				Description: "The name of the field the pairing key is sent in, for partners that call it something else (e.g. \"serviceKey\"). Defaults to \"pairingKey\".",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"peers": schema.ListNestedAttribute{
+				Description:  "The BGP peerings to request from the partner, for partners that support them.",
+				Optional:     true,
+				NestedObject: azurePartnerConfigSchema.Attributes["peers"].(schema.ListNestedAttribute).NestedObject,
+			},
+			"attributes": schema.MapAttribute{
+				Description: "Any other fields of the partner configuration, keyed by their name in the Megaport API. Values are sent as strings.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+	partnerPortSelectorSchema = schema.SingleNestedAttribute{
+		Description: "Selects the partner port for the B-End, instead of looking it up with the `megaport_partner` data source and setting `requested_product_uid`. The filters work as they do on the data source, and the highest ranked port accepting VXCs is chosen when the VXC is planned. The chosen port is kept in `requested_product_uid` for the life of the VXC: if the selector would pick a different port on a later plan, a warning is shown and the VXC is left where it is. Cannot be used together with `requested_product_uid`.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"connect_type": schema.StringAttribute{
+				Description: "The connect type of the partner port, such as AWS (for Hosted VIF), AWSHC (for Hosted Connection), AZURE, GOOGLE, ORACLE or IBM.",
+				Required:    true,
+			},
+			"company_name": schema.StringAttribute{
+				Description: "The name of the company that owns the partner port.",
+				Optional:    true,
+			},
+			"product_name": schema.StringAttribute{
+				Description: "The name of the partner port.",
+				Optional:    true,
+			},
+			"location_id": schema.Int64Attribute{
+				Description: "The ID of the location of the partner port.",
+				Optional:    true,
+			},
+			"metro": schema.StringAttribute{
+				Description: "The metro the partner port is in, such as \"Sydney\".",
+				Optional:    true,
+			},
+			"diversity_zone": schema.StringAttribute{
+				Description: "The diversity zone of the partner port.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("red", "blue"),
+				},
+			},
+		},
+	}
 	oraclePartnerConfigSchema = schema.SingleNestedAttribute{
 		Description: "The Oracle partner configuration.",
 		Optional:    true,
@@ -171,6 +246,10 @@ var (
 			"interfaces": schema.ListNestedAttribute{
 				Description: "The interfaces of the partner configuration.",
 				Required:    true,
+				Validators: []validator.List{
+					vrouterInterfacesValidator{},
+					ipSecTunnelInterfacesValidator{},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"description": schema.StringAttribute{
@@ -200,9 +279,12 @@ var (
 							},
 						},
 						"ip_addresses": schema.ListAttribute{
-							Description: "The IP addresses of the partner configuration. Each entry must be in CIDR notation (e.g., \"169.254.100.6/29\").",
+							Description: "The IPv4 and IPv6 addresses of the interface, each in CIDR notation (e.g., \"169.254.100.6/29\" or \"2001:db8::1/64\"). Give addresses of both families for a dual-stack interface. The subnets of different interfaces must not overlap.",
 							Optional:    true,
 							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(cidrValidator{}),
+							},
 						},
 						"ip_routes": schema.ListNestedAttribute{
 							Description: "The IP routes of the partner configuration.",
@@ -210,16 +292,23 @@ var (
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"prefix": schema.StringAttribute{
-										Description: "The prefix of the IP route.",
+										Description: "The prefix of the IP route in CIDR notation, with no host bits set (e.g., \"10.0.1.0/24\").",
 										Optional:    true,
+										Validators: []validator.String{
+											cidrValidator{},
+											canonicalCIDRValidator{},
+										},
 									},
 									"description": schema.StringAttribute{
 										Description: "The description of the IP route.",
 										Optional:    true,
 									},
 									"next_hop": schema.StringAttribute{
-										Description: "The next hop of the IP route.",
+										Description: "The next hop of the IP route. Must be an IP address in the same address family as `prefix`, inside the subnet of one of the interfaces.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddressValidator{},
+										},
 									},
 								},
 							},
@@ -252,36 +341,59 @@ var (
 							Optional:    true,
 						},
 						"ip_sec_tunnel_options": schema.SingleNestedAttribute{
-							Description: "The IPsec tunnel to configure on this interface. Requires `interface_type` to be `ipSecTunnel` and the attached MCR to have an IPsec add-on with available tunnel capacity. There is one tunnel per `ipSecTunnel` interface; declare multiple interfaces for multiple tunnels. The API does not return the pre-shared key or lifetimes on read: `pre_shared_key` is a write-only argument (never stored in state), and the lifetimes are preserved from config so they never show drift.",
+							Description: "The IPsec tunnel to configure on this interface. Requires `interface_type` to be `ipSecTunnel` and the attached MCR to have an IPsec add-on with available tunnel capacity; the provider warns at plan time when the MCR's `megaport_mcr_ipsec_addon` has fewer tunnels than the VXC. There is one tunnel per `ipSecTunnel` interface; declare multiple interfaces for multiple tunnels. The API does not return the pre-shared key or lifetimes on read: `pre_shared_key` is a write-only argument (never stored in state), and the lifetimes are preserved from config so they never show drift.",
 							Optional:    true,
 							Validators: []validator.Object{
 								ipSecPhaseLifetimeValidator{},
+								ipSecTunnelOptionsValidator{},
 							},
 							Attributes: map[string]schema.Attribute{
 								"source_ip_address": schema.StringAttribute{
 									Description: "Local (Megaport-side) IPv4 address used as the tunnel source. Must live on a separate `subInterface` interface, not on this `ipSecTunnel` interface.",
 									Required:    true,
+									Validators: []validator.String{
+										ipAddressValidator{},
+									},
 								},
 								"destination_ip_address": schema.StringAttribute{
-									Description: "Remote peer IPv4 address the tunnel connects to.",
+									Description: "Remote peer IPv4 address the tunnel connects to. Use `0.0.0.0` to accept a connection from a peer at any address, which requires `remote_id` and a passive tunnel.",
 									Required:    true,
+									Validators: []validator.String{
+										ipAddressValidator{},
+									},
 								},
 								"pre_shared_key": schema.StringAttribute{
-									Description: "Pre-shared key used to authenticate the IPsec tunnel. Declared as a [write-only argument](https://developer.hashicorp.com/terraform/language/v1.11.x/resources/ephemeral/write-only) (Terraform 1.11+), so the key is never written to the plan or state; it is read from the configuration only when the tunnel is provisioned. The API does not return it on read.",
-									Required:    true,
+									Description: "Pre-shared key used to authenticate the IPsec tunnel. Declared as a [write-only argument](https://developer.hashicorp.com/terraform/language/v1.11.x/resources/ephemeral/write-only) (Terraform 1.11+), so the key is never written to the plan or state; it is read from the configuration only when the tunnel is provisioned. The API does not return it on read. Exactly one of `pre_shared_key` and `generate_pre_shared_key` must be set. Change `pre_shared_key_version` to send a new key.",
+									Optional:    true,
 									Sensitive:   true,
 									WriteOnly:   true,
 								},
+								"pre_shared_key_version": schema.Int64Attribute{
+									Description: "A version number for the pre-shared key. Changing it sends `pre_shared_key` to the tunnel again, or generates a new `generated_pre_shared_key` when `generate_pre_shared_key` is true.",
+									Optional:    true,
+								},
+								"generate_pre_shared_key": schema.BoolAttribute{
+									Description: "Whether the provider generates the pre-shared key of the tunnel instead of reading `pre_shared_key`. The key is generated when the tunnel is provisioned and kept in `generated_pre_shared_key`.",
+									Optional:    true,
+								},
+								"generated_pre_shared_key": schema.StringAttribute{
+									Description: "The pre-shared key generated by the provider when `generate_pre_shared_key` is true, to configure on the peer. It is a random 26 character key of upper-case letters and digits. Unlike `pre_shared_key` it is stored in state, as a sensitive value. It stays the same across updates; change `pre_shared_key_version` to generate a new one.",
+									Computed:    true,
+									Sensitive:   true,
+									PlanModifiers: []planmodifier.String{
+										generatedPreSharedKeyPlanModifier{},
+									},
+								},
 								"passive": schema.BoolAttribute{
-									Description: "Whether the tunnel operates in passive mode (waits for the peer to initiate). Defaults to true on the API when omitted.",
+									Description: "Whether the tunnel operates in passive mode (waits for the peer to initiate). Defaults to true on the API when omitted. An active tunnel needs a specific `destination_ip_address`.",
 									Optional:    true,
 								},
 								"local_id": schema.StringAttribute{
-									Description: "IKE local identifier override, typically used when the Megaport endpoint is behind NAT.",
+									Description: "IKE local identifier override, typically used when the Megaport endpoint is behind NAT. Must be an IP address, a fully qualified domain name or user@FQDN.",
 									Optional:    true,
 								},
 								"remote_id": schema.StringAttribute{
-									Description: "IKE remote identifier override, typically used when the peer is behind NAT.",
+									Description: "IKE remote identifier override, typically used when the peer is behind NAT. Must be an IP address, a fully qualified domain name or user@FQDN.",
 									Optional:    true,
 								},
 								"phase1_lifetime": schema.Int64Attribute{
@@ -321,17 +433,28 @@ var (
 										},
 									},
 									"local_ip_address": schema.StringAttribute{
-										Description: "The local IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.6\").",
+										Description: "The local IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.6\"). Must be one of the `ip_addresses` of the interface.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddressValidator{},
+										},
 									},
 									"peer_ip_address": schema.StringAttribute{
-										Description: "The peer IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.1\").",
+										Description: "The peer IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.1\"). Must be another address in the subnet of the interface address used as `local_ip_address`.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddressValidator{},
+										},
 									},
 									"password": schema.StringAttribute{
-										Description: "The password of the BGP connection.",
+										Description: "The password of the BGP connection. A write-only argument (Terraform 1.11+): it is sent when the connection is configured and is never stored in the plan or state. Change `password_version` to send a new password.",
 										Sensitive:   true,
 										Optional:    true,
+										WriteOnly:   true,
+									},
+									"password_version": schema.Int64Attribute{
+										Description: "A version number for `password`. Terraform cannot see changes to a write-only argument, so change this, for example by incrementing it, when the password changes to have the provider send it again.",
+										Optional:    true,
 									},
 									"shutdown": schema.BoolAttribute{
 										Description: "Whether the BGP connection is shut down.",
@@ -408,12 +531,18 @@ var (
 			"interfaces": schema.ListNestedAttribute{
 				Description: "The interfaces of the partner configuration.",
 				Required:    true,
+				Validators: []validator.List{
+					vrouterInterfacesValidator{},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"ip_addresses": schema.ListAttribute{
-							Description: "The IP addresses of the partner configuration. Each entry must be in CIDR notation (e.g., \"169.254.100.6/29\").",
+							Description: "The IPv4 and IPv6 addresses of the interface, each in CIDR notation (e.g., \"169.254.100.6/29\" or \"2001:db8::1/64\"). Give addresses of both families for a dual-stack interface. The subnets of different interfaces must not overlap.",
 							Optional:    true,
 							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(cidrValidator{}),
+							},
 						},
 						"ip_routes": schema.ListNestedAttribute{
 							Description: "The IP routes of the partner configuration.",
@@ -421,16 +550,23 @@ var (
 							NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"prefix": schema.StringAttribute{
-										Description: "The prefix of the IP route.",
+										Description: "The prefix of the IP route in CIDR notation, with no host bits set (e.g., \"10.0.1.0/24\").",
 										Optional:    true,
+										Validators: []validator.String{
+											cidrValidator{},
+											canonicalCIDRValidator{},
+										},
 									},
 									"description": schema.StringAttribute{
 										Description: "The description of the IP route.",
 										Optional:    true,
 									},
 									"next_hop": schema.StringAttribute{
-										Description: "The next hop of the IP route.",
+										Description: "The next hop of the IP route. Must be an IP address in the same address family as `prefix`, inside the subnet of one of the interfaces.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddressValidator{},
+										},
 									},
 								},
 							},
@@ -472,17 +608,28 @@ var (
 										Optional:    true,
 									},
 									"local_ip_address": schema.StringAttribute{
-										Description: "The local IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.6\").",
+										Description: "The local IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.6\"). Must be one of the `ip_addresses` of the interface.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddressValidator{},
+										},
 									},
 									"peer_ip_address": schema.StringAttribute{
-										Description: "The peer IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.1\").",
+										Description: "The peer IP address of the BGP connection. Must be an IP address without a CIDR mask (e.g., \"169.254.100.1\"). Must be another address in the subnet of the interface address used as `local_ip_address`.",
 										Optional:    true,
+										Validators: []validator.String{
+											ipAddressValidator{},
+										},
 									},
 									"password": schema.StringAttribute{
-										Description: "The password of the BGP connection.",
+										Description: "The password of the BGP connection. A write-only argument (Terraform 1.11+): it is sent when the connection is configured and is never stored in the plan or state. Change `password_version` to send a new password.",
 										Sensitive:   true,
 										Optional:    true,
+										WriteOnly:   true,
+									},
+									"password_version": schema.Int64Attribute{
+										Description: "A version number for `password`. Terraform cannot see changes to a write-only argument, so change this, for example by incrementing it, when the password changes to have the provider send it again.",
+										Optional:    true,
 									},
 									"shutdown": schema.BoolAttribute{
 										Description: "Whether the BGP connection is shut down.",
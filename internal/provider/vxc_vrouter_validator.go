@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// cidrValidator checks that a string is a prefix in CIDR notation, such as
+// "10.0.0.0/24" or "2001:db8::/64". Host bits are allowed; pair it with
+// canonicalCIDRValidator where they are not.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 prefix in CIDR notation"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := netip.ParsePrefix(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid CIDR prefix",
+			fmt.Sprintf("%q is not an IPv4 or IPv6 prefix in CIDR notation, such as \"169.254.100.6/29\" or \"2001:db8::1/64\".", req.ConfigValue.ValueString()))
+	}
+}
+
+// ipAddressValidator checks that a string is an IPv4 or IPv6 address without
+// a CIDR mask.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address without a CIDR mask"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := netip.ParseAddr(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP address",
+			fmt.Sprintf("%q is not an IPv4 or IPv6 address. Give the address without a CIDR mask, such as \"169.254.100.1\" or \"2001:db8::2\".", req.ConfigValue.ValueString()))
+	}
+}
+
+// vrouterInterfacesValidator checks the addressing of the interfaces of a
+// virtual router or A-End partner configuration against each other, which the
+// API otherwise only reports when the VXC is ordered:
+//
+//   - the subnets of different interfaces must not overlap,
+//   - a route's next hop must be in the same address family as its prefix,
+//     and inside the subnet of one of the interfaces,
+//   - a BGP connection's local address must be an address of its interface,
+//     and its peer address must be another address in the same subnet.
+//
+// The syntax of each address is checked by the attribute's own validator, so
+// values that do not parse are skipped here, as are unknown values.
+type vrouterInterfacesValidator struct{}
+
+func (v vrouterInterfacesValidator) Description(_ context.Context) string {
+	return "interface subnets must not overlap, and route next hops and BGP peers must be on an interface subnet"
+}
+
+func (v vrouterInterfacesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// vrouterInterfaceAddress is an address of an interface, with the path of
+// the ip_addresses entry it was given in.
+type vrouterInterfaceAddress struct {
+	prefix netip.Prefix
+	path   path.Path
+}
+
+func (v vrouterInterfacesValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	interfaces := make([]map[string]attr.Value, len(req.ConfigValue.Elements()))
+	addresses := make([][]vrouterInterfaceAddress, len(interfaces))
+	// An interface whose addresses are not all known cannot show that an
+	// address is off its subnets.
+	known := make([]bool, len(interfaces))
+	allKnown, anyAddress := true, false
+	for i, elem := range req.ConfigValue.Elements() {
+		iface, ok := elem.(types.Object)
+		if !ok || iface.IsNull() || iface.IsUnknown() {
+			allKnown = false
+			continue
+		}
+		interfaces[i] = iface.Attributes()
+		var ipAddresses []attr.Value
+		ipAddresses, known[i] = knownListElements(interfaces[i]["ip_addresses"])
+		for k, ipAddress := range ipAddresses {
+			s, ok := ipAddress.(types.String)
+			if !ok || s.IsUnknown() {
+				known[i] = false
+				continue
+			}
+			if s.IsNull() {
+				continue
+			}
+			prefix, err := netip.ParsePrefix(s.ValueString())
+			if err != nil {
+				continue
+			}
+			addresses[i] = append(addresses[i], vrouterInterfaceAddress{prefix, req.Path.AtListIndex(i).AtName("ip_addresses").AtListIndex(k)})
+			anyAddress = true
+		}
+		allKnown = allKnown && known[i]
+	}
+
+	for i := range interfaces {
+		for _, a := range addresses[i] {
+			for j := range i {
+				for _, b := range addresses[j] {
+					if a.prefix.Masked().Overlaps(b.prefix.Masked()) {
+						resp.Diagnostics.AddAttributeError(a.path, "Overlapping interface subnets",
+							fmt.Sprintf("%s on interface %d overlaps %s on interface %d. The subnets of different interfaces must not overlap.", a.prefix, i, b.prefix, j))
+					}
+				}
+			}
+		}
+	}
+
+	for i, iface := range interfaces {
+		if iface == nil {
+			continue
+		}
+		ifacePath := req.Path.AtListIndex(i)
+
+		routes, _ := knownListElements(iface["ip_routes"])
+		for r, route := range routes {
+			routeObj, ok := route.(types.Object)
+			if !ok || routeObj.IsNull() || routeObj.IsUnknown() {
+				continue
+			}
+			prefix, prefixOK := parsedPrefix(routeObj.Attributes()["prefix"])
+			nextHop, nextHopOK := parsedAddr(routeObj.Attributes()["next_hop"])
+			if !nextHopOK {
+				continue
+			}
+			nextHopPath := ifacePath.AtName("ip_routes").AtListIndex(r).AtName("next_hop")
+			if prefixOK && prefix.Addr().Is4() != nextHop.Is4() {
+				resp.Diagnostics.AddAttributeError(nextHopPath, "Address family mismatch",
+					fmt.Sprintf("The next hop %s of the route to %s is in a different address family to the route.", nextHop, prefix))
+				continue
+			}
+			if allKnown && anyAddress && !onAnyInterface(addresses, nextHop) {
+				resp.Diagnostics.AddAttributeError(nextHopPath, "Next hop not reachable",
+					fmt.Sprintf("The next hop %s is not inside the subnet of any interface, so the route cannot be used. Use an address in one of the interface subnets.", nextHop))
+			}
+		}
+
+		if !known[i] {
+			continue
+		}
+		connections, _ := knownListElements(iface["bgp_connections"])
+		for c, connection := range connections {
+			connectionObj, ok := connection.(types.Object)
+			if !ok || connectionObj.IsNull() || connectionObj.IsUnknown() {
+				continue
+			}
+			connectionPath := ifacePath.AtName("bgp_connections").AtListIndex(c)
+			resp.Diagnostics.Append(checkBGPConnectionAddresses(connectionObj.Attributes(), addresses[i], i, connectionPath)...)
+		}
+	}
+}
+
+// checkBGPConnectionAddresses checks the local and peer addresses of a BGP
+// connection on interface index, whose addresses are given.
+func checkBGPConnectionAddresses(connection map[string]attr.Value, addresses []vrouterInterfaceAddress, index int, connectionPath path.Path) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	local, localOK := parsedAddr(connection["local_ip_address"])
+	peer, peerOK := parsedAddr(connection["peer_ip_address"])
+	localPath := connectionPath.AtName("local_ip_address")
+	peerPath := connectionPath.AtName("peer_ip_address")
+
+	if localOK && peerOK && local.Is4() != peer.Is4() {
+		diags.AddAttributeError(peerPath, "Address family mismatch",
+			fmt.Sprintf("The BGP peer address %s and local address %s are in different address families.", peer, local))
+		return diags
+	}
+	if len(addresses) == 0 {
+		return diags
+	}
+	if peerOK && !hasFamily(addresses, peer) {
+		diags.AddAttributeError(peerPath, "Address family mismatch",
+			fmt.Sprintf("The BGP peer address %s is %s, but interface %d has no %s address. Add one to ip_addresses to run a dual-stack interface.", peer, addrFamily(peer), index, addrFamily(peer)))
+		return diags
+	}
+
+	var subnet *netip.Prefix
+	if localOK {
+		for _, a := range addresses {
+			if a.prefix.Addr() == local {
+				subnet = &a.prefix
+				break
+			}
+		}
+		if subnet == nil {
+			diags.AddAttributeError(localPath, "BGP local address not on the interface",
+				fmt.Sprintf("The BGP local address %s is not one of the ip_addresses of interface %d.", local, index))
+			return diags
+		}
+	}
+	if !peerOK {
+		return diags
+	}
+	switch {
+	case subnet != nil && peer == local:
+		diags.AddAttributeError(peerPath, "BGP peer address is the local address",
+			fmt.Sprintf("The BGP peer address %s is the local address of the connection.", peer))
+	case subnet != nil && !subnet.Contains(peer):
+		diags.AddAttributeError(peerPath, "BGP peer not on the interface subnet",
+			fmt.Sprintf("The BGP peer address %s is not in %s, the subnet of the local address %s.", peer, subnet.Masked(), local))
+	case subnet == nil && !onInterface(addresses, peer):
+		diags.AddAttributeError(peerPath, "BGP peer not on the interface subnet",
+			fmt.Sprintf("The BGP peer address %s is not in the subnet of any of the ip_addresses of interface %d.", peer, index))
+	}
+	return diags
+}
+
+// knownListElements returns the elements of a list attribute, and whether the
+// list is known. A null list has no elements and is known.
+func knownListElements(v attr.Value) ([]attr.Value, bool) {
+	list, ok := v.(types.List)
+	if !ok || list.IsUnknown() {
+		return nil, false
+	}
+	return list.Elements(), true
+}
+
+// parsedPrefix parses a known string attribute as a CIDR prefix.
+func parsedPrefix(v attr.Value) (netip.Prefix, bool) {
+	s, ok := v.(types.String)
+	if !ok || s.IsNull() || s.IsUnknown() {
+		return netip.Prefix{}, false
+	}
+	prefix, err := netip.ParsePrefix(s.ValueString())
+	return prefix, err == nil
+}
+
+// parsedAddr parses a known string attribute as an IP address.
+func parsedAddr(v attr.Value) (netip.Addr, bool) {
+	s, ok := v.(types.String)
+	if !ok || s.IsNull() || s.IsUnknown() {
+		return netip.Addr{}, false
+	}
+	addr, err := netip.ParseAddr(s.ValueString())
+	return addr, err == nil
+}
+
+// onInterface reports whether addr is inside one of the subnets of an
+// interface.
+func onInterface(addresses []vrouterInterfaceAddress, addr netip.Addr) bool {
+	for _, a := range addresses {
+		if a.prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// onAnyInterface reports whether addr is inside the subnet of any interface.
+func onAnyInterface(addresses [][]vrouterInterfaceAddress, addr netip.Addr) bool {
+	for _, ifaceAddresses := range addresses {
+		if onInterface(ifaceAddresses, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFamily reports whether an interface has an address in the family of
+// addr.
+func hasFamily(addresses []vrouterInterfaceAddress, addr netip.Addr) bool {
+	for _, a := range addresses {
+		if a.prefix.Addr().Is4() == addr.Is4() {
+			return true
+		}
+	}
+	return false
+}
+
+func addrFamily(addr netip.Addr) string {
+	if addr.Is4() {
+		return "IPv4"
+	}
+	return "IPv6"
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestUnitMegaportVXC_VrouterInterfaceValidation(t *testing.T) {
+	t.Parallel()
+	api := newFakeMegaportAPI(t)
+	mcr := api.seedMCR("Validation MCR")
+	port := api.seedPort("Validation Port")
+	config := func(interfaces string) string {
+		return fakeProviderConfig + fmt.Sprintf(`
+		resource "megaport_vxc" "vxc" {
+			product_name         = "Validation VXC"
+			rate_limit           = 100
+			contract_term_months = 12
+			a_end = {
+				requested_product_uid = %q
+			}
+			a_end_partner_config = {
+				partner = "vrouter"
+				vrouter_config = {
+					interfaces = %s
+				}
+			}
+			b_end = {
+				requested_product_uid = %q
+			}
+		}`, mcr, interfaces, port)
+	}
+	step := func(interfaces, wantErr string) resource.TestStep {
+		s := resource.TestStep{Config: config(interfaces), PlanOnly: true, ExpectNonEmptyPlan: true}
+		if wantErr != "" {
+			s.ExpectError = regexp.MustCompile(wantErr)
+		}
+		return s
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: api.providerFactories(),
+		Steps: []resource.TestStep{
+			// Dual-stack interface with a BGP session in each family.
+			step(`[{
+				ip_addresses = ["10.0.0.1/30", "2001:db8::1/64"]
+				ip_routes = [
+					{ prefix = "192.168.0.0/24", next_hop = "10.0.0.2" },
+					{ prefix = "2001:db8:1::/48", next_hop = "2001:db8::2" },
+				]
+				bgp_connections = [
+					{ peer_asn = 64512, local_ip_address = "10.0.0.1", peer_ip_address = "10.0.0.2" },
+					{ peer_asn = 64512, local_ip_address = "2001:db8::1", peer_ip_address = "2001:db8::2" },
+				]
+			}]`, ""),
+			step(`[{ ip_addresses = ["10.0.0.1"] }]`, `Invalid CIDR prefix`),
+			step(`[{
+				ip_addresses = ["10.0.0.1/30"]
+				ip_routes    = [{ prefix = "192.168.0.1/24", next_hop = "10.0.0.2" }]
+			}]`, `Use the network address\s+"192.168.0.0/24"`),
+			step(`[{
+				ip_addresses = ["10.0.0.1/30"]
+				ip_routes    = [{ prefix = "192.168.0.0/24", next_hop = "10.0.0.2/30" }]
+			}]`, `Invalid IP address`),
+			step(`[{
+				ip_addresses = ["10.0.0.1/30"]
+				ip_routes    = [{ prefix = "2001:db8:1::/48", next_hop = "10.0.0.2" }]
+			}]`, `Address family mismatch`),
+			step(`[{
+				ip_addresses = ["10.0.0.1/30"]
+				ip_routes    = [{ prefix = "192.168.0.0/24", next_hop = "10.0.1.2" }]
+			}]`, `Next hop not reachable`),
+			step(`[
+				{ ip_addresses = ["10.0.0.1/24"] },
+				{ ip_addresses = ["10.0.0.129/25"] },
+			]`, `Overlapping interface subnets`),
+			step(`[{
+				ip_addresses    = ["2001:db8::1/64"]
+				bgp_connections = [{ peer_asn = 64512, peer_ip_address = "10.0.0.2" }]
+			}]`, `interface\s+0\s+has\s+no\s+IPv4\s+address`),
+			step(`[{
+				ip_addresses    = ["10.0.0.1/30"]
+				bgp_connections = [{ peer_asn = 64512, local_ip_address = "10.0.0.3", peer_ip_address = "10.0.0.2" }]
+			}]`, `BGP local address not on the interface`),
+			step(`[{
+				ip_addresses    = ["10.0.0.1/30"]
+				bgp_connections = [{ peer_asn = 64512, local_ip_address = "10.0.0.1", peer_ip_address = "10.0.0.5" }]
+			}]`, `BGP peer not on the interface subnet`),
+			step(`[{
+				ip_addresses    = ["10.0.0.1/30", "2001:db8::1/64"]
+				bgp_connections = [{ peer_asn = 64512, local_ip_address = "10.0.0.1", peer_ip_address = "2001:db8::2" }]
+			}]`, `Address family mismatch`),
+		},
+	})
+}